@@ -0,0 +1,71 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+var (
+	errActorRequired = fmt.Errorf("authentication required: %w", errs.ErrUnauthorized)
+	errNotSelf       = fmt.Errorf("only the account owner can do this: %w", errs.ErrForbidden)
+)
+
+// requireSelf hanya mengizinkan actor bertindak atas akunnya sendiri
+func requireSelf(ctx context.Context, userID identity.ID) error {
+	actorID, ok := requestctx.ActorID(ctx)
+	if !ok {
+		return errActorRequired
+	}
+	if actorID != userID {
+		return errNotSelf
+	}
+	return nil
+}
+
+// authorizeUser mengizinkan actor bertindak atas akunnya sendiri, atau atas
+// user lain bila memiliki permission tersebut. Pemanggilan internal memakai
+// requestctx.WithSystemActor.
+func authorizeUser(ctx context.Context, resolver interfaces.IPermissionResolver, userID identity.ID, permission string) error {
+	if requestctx.IsSystemActor(ctx) {
+		return nil
+	}
+
+	subject, ok, err := resolver.ActorSubject(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errActorRequired
+	}
+	if subject.ID == userID || subject.HasPermission(permission) {
+		return nil
+	}
+	return fmt.Errorf("missing permission '%s': %w", permission, errs.ErrForbidden)
+}
+
+// authorizeActor mewajibkan actor yang memiliki permission dan mengembalikan
+// subject-nya. Pemanggilan internal memakai requestctx.WithSystemActor dan
+// mendapat subject kosong.
+func authorizeActor(ctx context.Context, resolver interfaces.IPermissionResolver, permission string) (policy.Subject, error) {
+	if requestctx.IsSystemActor(ctx) {
+		return policy.Subject{}, nil
+	}
+
+	subject, ok, err := resolver.ActorSubject(ctx)
+	if err != nil {
+		return policy.Subject{}, err
+	}
+	if !ok {
+		return policy.Subject{}, errActorRequired
+	}
+	if !subject.HasPermission(permission) {
+		return policy.Subject{}, fmt.Errorf("missing permission '%s': %w", permission, errs.ErrForbidden)
+	}
+	return subject, nil
+}
@@ -0,0 +1,73 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// fakeResolver mengembalikan subject tetap, nil berarti tidak ada actor
+type fakeResolver struct {
+	subject *policy.Subject
+}
+
+func (f fakeResolver) UserPermissions(ctx context.Context, user *account.User) ([]string, error) {
+	return nil, nil
+}
+
+func (f fakeResolver) ActorSubject(ctx context.Context) (policy.Subject, bool, error) {
+	if f.subject == nil {
+		return policy.Subject{}, false, nil
+	}
+	return *f.subject, true, nil
+}
+
+func TestAuthorizeUser(t *testing.T) {
+	self, other := identity.New(), identity.New()
+
+	tests := []struct {
+		name    string
+		system  bool
+		subject *policy.Subject
+		target  identity.ID
+		wantErr error
+	}{
+		{name: "no actor", subject: nil, target: self, wantErr: errs.ErrUnauthorized},
+		{name: "system actor", system: true, subject: nil, target: other},
+		{name: "self", subject: &policy.Subject{ID: self}, target: self},
+		{name: "other without permission", subject: &policy.Subject{ID: self}, target: other, wantErr: errs.ErrForbidden},
+		{
+			name:    "other with another permission",
+			subject: &policy.Subject{ID: self, Permissions: []string{account.PermissionSessionsRead}},
+			target:  other,
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "other with permission",
+			subject: &policy.Subject{ID: self, Permissions: []string{account.PermissionSessionsRevoke}},
+			target:  other,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.system {
+				ctx = requestctx.WithSystemActor(ctx)
+			}
+			err := authorizeUser(ctx, fakeResolver{subject: tt.subject}, tt.target, account.PermissionSessionsRevoke)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("authorizeUser() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("authorizeUser() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
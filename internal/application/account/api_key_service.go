@@ -0,0 +1,270 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+// lastUsedInterval membatasi penulisan last_used_at agar tidak terjadi update
+// pada setiap request
+const lastUsedInterval = time.Minute
+
+var errInvalidAPIKey = fmt.Errorf("api key is invalid, expired or revoked: %w", errs.ErrUnauthorized)
+
+type APIKeyService struct {
+	repo     interfaces.IAPIKeyRepository
+	users    interfaces.IUserRepository
+	resolver interfaces.IPermissionResolver
+	auditor  auditInterfaces.IAuditService
+	tx       transaction.IManager
+}
+
+func NewAPIKeyService(repo interfaces.IAPIKeyRepository, users interfaces.IUserRepository, resolver interfaces.IPermissionResolver, auditor auditInterfaces.IAuditService, tx transaction.IManager) *APIKeyService {
+	return &APIKeyService{
+		repo:     repo,
+		users:    users,
+		resolver: resolver,
+		auditor:  auditor,
+		tx:       tx,
+	}
+}
+
+/**
+ * Create issues a new API key for the actor, or for another user when the
+ * actor has api_keys:write. Scopes must be a subset of the permissions of
+ * the user's role, and of the actor's own scopes when the actor is itself
+ * using a scoped credential. The plain key is only returned here.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param payload *account.CreateAPIKeyRequest
+ * @return (*account.CreatedAPIKeyResponse, error)
+ */
+func (a *APIKeyService) Create(ctx context.Context, userID identity.ID, payload *account.CreateAPIKeyRequest) (result *account.CreatedAPIKeyResponse, err error) {
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		return nil, errs.ValidationError{Field: "name", Message: "is required"}
+	}
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		return nil, errs.ValidationError{Field: "expires_at", Message: "must be in the future"}
+	}
+	if err = authorizeUser(ctx, a.resolver, userID, account.PermissionAPIKeysWrite); err != nil {
+		return nil, err
+	}
+
+	// kredensial berscope tidak boleh menerbitkan key yang lebih luas darinya,
+	// termasuk key tanpa scope yang berarti semua permission
+	if scopes, restricted := requestctx.Scopes(ctx); restricted {
+		if len(payload.Scopes) == 0 {
+			return nil, errs.ValidationError{Field: "scopes", Message: "is required when using a scoped credential"}
+		}
+		for _, scope := range payload.Scopes {
+			if !slices.Contains(scopes, scope) {
+				return nil, errs.ValidationError{Field: "scopes", Message: fmt.Sprintf("'%s' is not granted by the current credential", scope)}
+			}
+		}
+	}
+
+	user, err := a.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := a.permissions(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	for _, scope := range payload.Scopes {
+		if !slices.Contains(permissions, scope) {
+			return nil, errs.ValidationError{Field: "scopes", Message: fmt.Sprintf("'%s' is not granted by the user's role", scope)}
+		}
+	}
+
+	key, plain, err := account.NewAPIKey(userID, name, slices.Compact(slices.Sorted(slices.Values(payload.Scopes))), payload.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", errs.ErrInternal)
+	}
+
+	err = a.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := a.repo.Create(ctx, key); err != nil {
+			return err
+		}
+		changes := []audit.Change{
+			{Field: "user_id", After: userID},
+			{Field: "name", After: key.Name},
+			{Field: "scopes", After: key.Scopes},
+		}
+		return a.auditor.Record(ctx, audit.ActionCreate, audit.AggregateAPIKey, key.ID.String(), changes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &account.CreatedAPIKeyResponse{APIKeyResponse: *key.ToAPIKeyResponse(), Key: plain}, nil
+}
+
+/**
+ * List returns all API keys of a user, including revoked ones. Listing the
+ * keys of another user requires api_keys:read.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @return ([]*account.APIKeyResponse, error)
+ */
+func (a *APIKeyService) List(ctx context.Context, userID identity.ID) (result []*account.APIKeyResponse, err error) {
+	if err = authorizeUser(ctx, a.resolver, userID, account.PermissionAPIKeysRead); err != nil {
+		return nil, err
+	}
+
+	keys, err := a.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	result = make([]*account.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, key.ToAPIKeyResponse())
+	}
+	return result, nil
+}
+
+/**
+ * Revoke disables an API key immediately. Revoking the key of another user
+ * requires api_keys:write.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param keyID identity.ID
+ * @return error
+ */
+func (a *APIKeyService) Revoke(ctx context.Context, userID identity.ID, keyID identity.ID) (err error) {
+	if err = authorizeUser(ctx, a.resolver, userID, account.PermissionAPIKeysWrite); err != nil {
+		return err
+	}
+
+	key, err := a.repo.FindByID(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	// Key milik user lain diperlakukan seperti tidak ada
+	if key.UserID != userID || key.RevokedAt != nil {
+		return errs.ErrNotFound
+	}
+
+	now := time.Now()
+	err = a.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := a.repo.Revoke(ctx, keyID, now); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "revoked_at", Before: nil, After: now}}
+		return a.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateAPIKey, keyID.String(), changes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+/**
+ * Authenticate resolves the principal of a plain API key. The key's tenant
+ * scopes the rest of the lookup and a key of another tenant than the request
+ * host is rejected. The effective scopes are limited to the permissions the
+ * user's role grants right now.
+ * @param ctx context.Context
+ * @param plain string
+ * @return (*account.Principal, error)
+ */
+func (a *APIKeyService) Authenticate(ctx context.Context, plain string) (result *account.Principal, err error) {
+	if !account.IsAPIKey(plain) {
+		return nil, errInvalidAPIKey
+	}
+
+	key, err := a.repo.FindByHash(ctx, token.Hash(plain))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, errInvalidAPIKey
+	}
+
+	// Key dicari lintas tenant, sisa lookup memakai tenant milik key. Key
+	// tenant lain ditolak pada host yang sudah terpetakan ke tenant.
+	if key.TenantID.IsNil() {
+		return nil, errInvalidAPIKey
+	}
+	if tenantID, ok := requestctx.TenantID(ctx); ok && tenantID != key.TenantID {
+		return nil, errInvalidAPIKey
+	}
+	ctx = requestctx.WithTenantID(ctx, key.TenantID)
+
+	user, err := a.users.GetByID(ctx, key.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+	if !user.IsActive {
+		return nil, errInvalidAPIKey
+	}
+
+	permissions, err := a.permissions(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedInterval {
+		// Gagal mencatat pemakaian tidak boleh menolak request
+		if err := a.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			slog.WarnContext(ctx, "failed to update api key last used", slog.String("api_key_id", key.ID.String()), slog.Any("error", err))
+		}
+	}
+
+	return &account.Principal{
+		UserID:   user.ID,
+		Method:   account.AuthMethodAPIKey,
+		APIKeyID: key.ID,
+		TenantID: key.TenantID,
+		Scopes:   key.EffectiveScopes(permissions),
+	}, nil
+}
+
+func (a *APIKeyService) activeUser(ctx context.Context, userID identity.ID) (*account.User, error) {
+	user, err := a.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+	if !user.IsActive {
+		return nil, fmt.Errorf("user is inactive: %w", errs.ErrForbidden)
+	}
+	return user, nil
+}
+
+func (a *APIKeyService) permissions(ctx context.Context, user *account.User) ([]string, error) {
+	return a.resolver.UserPermissions(ctx, user)
+}
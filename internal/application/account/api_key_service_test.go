@@ -0,0 +1,92 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// hashedKeys mengembalikan key yang sama untuk hash apa pun, seperti lookup
+// lintas tenant pada repository
+type hashedKeys struct {
+	interfaces.IAPIKeyRepository
+	key *account.APIKey
+}
+
+func (h hashedKeys) FindByHash(ctx context.Context, hash string) (*account.APIKey, error) {
+	return h.key, nil
+}
+
+// tenantUsers hanya menemukan user pada tenant miliknya, seperti repository
+// yang dibatasi tenant
+type tenantUsers struct {
+	interfaces.IUserRepository
+	user *account.User
+}
+
+func (t tenantUsers) GetByID(ctx context.Context, id identity.ID) (*account.User, error) {
+	tenantID, ok := requestctx.TenantID(ctx)
+	if !ok || tenantID != t.user.TenantID || id != t.user.ID {
+		return nil, errs.ErrNotFound
+	}
+	return t.user, nil
+}
+
+func TestAPIKeyServiceAuthenticateUsesKeyTenant(t *testing.T) {
+	keyTenant := identity.New()
+	user := &account.User{ID: identity.New(), TenantID: keyTenant, IsActive: true}
+
+	tests := []struct {
+		name       string
+		hostTenant identity.ID
+		keyTenant  identity.ID
+		wantErr    error
+	}{
+		{name: "no host tenant", keyTenant: keyTenant},
+		{name: "same host tenant", hostTenant: keyTenant, keyTenant: keyTenant},
+		{name: "other host tenant", hostTenant: identity.New(), keyTenant: keyTenant, wantErr: errs.ErrUnauthorized},
+		{name: "key without tenant", wantErr: errs.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, plain, err := account.NewAPIKey(user.ID, "ci", nil, nil)
+			if err != nil {
+				t.Fatalf("NewAPIKey() error = %v", err)
+			}
+			key.TenantID = tt.keyTenant
+			now := key.CreatedAt
+			key.LastUsedAt = &now
+
+			service := &APIKeyService{
+				repo:     hashedKeys{key: key},
+				users:    tenantUsers{user: user},
+				resolver: fakeResolver{},
+			}
+
+			ctx := context.Background()
+			if !tt.hostTenant.IsNil() {
+				ctx = requestctx.WithTenantID(ctx, tt.hostTenant)
+			}
+			principal, err := service.Authenticate(ctx, plain)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if principal.TenantID != keyTenant || principal.UserID != user.ID {
+				t.Errorf("principal = %+v, want user %s in tenant %s", principal, user.ID, keyTenant)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+type AuthConfig struct {
+	// RequireVerifiedEmail menolak login user yang belum memverifikasi email.
+	// User lama tidak memiliki email_verified_at, jadi aktifkan setelah data
+	// tersebut ditandai terverifikasi.
+	RequireVerifiedEmail bool
+
+	// ChallengeTTL adalah batas waktu memasukkan kode MFA, atau menyelesaikan
+	// pendaftaran MFA, setelah password benar
+	ChallengeTTL time.Duration
+
+	// MFARequiredPermissions menandai akun admin: user yang memiliki salah satu
+	// permission ini wajib mendaftarkan MFA sebelum bisa login
+	MFARequiredPermissions []string
+
+	AccountLockout account.LockoutPolicy
+	IPLockout      account.LockoutPolicy
+}
+
+var DefaultAuthConfig = AuthConfig{
+	ChallengeTTL: 5 * time.Minute,
+	MFARequiredPermissions: []string{
+		account.PermissionUsersWrite,
+		account.PermissionUsersDelete,
+		account.PermissionRolesWrite,
+		account.PermissionRolesAssign,
+		account.PermissionRolesApprove,
+	},
+	AccountLockout: account.DefaultAccountLockoutPolicy,
+	IPLockout:      account.DefaultIPLockoutPolicy,
+}
+
+const tokenTypeBearer = "Bearer"
+
+var (
+	errInvalidCredentials = fmt.Errorf("invalid credentials: %w", errs.ErrUnauthorized)
+	errEmailNotVerified   = fmt.Errorf("email address is not verified: %w", errs.ErrForbidden)
+	errInvalidChallenge   = fmt.Errorf("mfa challenge is invalid or has expired: %w", errs.ErrUnauthorized)
+)
+
+type AuthService struct {
+	users    interfaces.IUserRepository
+	tokens   interfaces.IUserTokenRepository
+	mfa      interfaces.IMFARepository
+	cipher   account.ISecretCipher
+	sessions interfaces.ISessionRepository
+	hasher   account.IPasswordHasher
+	issuer   interfaces.IAccessTokenIssuer
+	resolver interfaces.IPermissionResolver
+	throttle loginThrottle
+	config   AuthConfig
+}
+
+func NewAuthService(users interfaces.IUserRepository, tokens interfaces.IUserTokenRepository, mfa interfaces.IMFARepository, cipher account.ISecretCipher, sessions interfaces.ISessionRepository, hasher account.IPasswordHasher, issuer interfaces.IAccessTokenIssuer, resolver interfaces.IPermissionResolver, attempts interfaces.ILoginAttemptStore, config AuthConfig) *AuthService {
+	// challenge dengan TTL nol langsung kedaluwarsa sehingga user MFA tidak bisa login
+	if config.ChallengeTTL <= 0 {
+		config.ChallengeTTL = DefaultAuthConfig.ChallengeTTL
+	}
+
+	return &AuthService{
+		users:    users,
+		tokens:   tokens,
+		mfa:      mfa,
+		cipher:   cipher,
+		sessions: sessions,
+		hasher:   hasher,
+		issuer:   issuer,
+		resolver: resolver,
+		throttle: loginThrottle{store: attempts, account: config.AccountLockout, ip: config.IPLockout},
+		config:   config,
+	}
+}
+
+/**
+ * Login checks the credentials of a user. The identifier may be a username
+ * or an email address.
+ * @param ctx context.Context
+ * @param payload *account.LoginRequest
+ * @return (*account.LoginResponse, error)
+ */
+func (a *AuthService) Login(ctx context.Context, payload *account.LoginRequest) (result *account.LoginResponse, err error) {
+	ipKey := clientIPKey(ctx)
+	if err = a.throttle.check(ctx, ipKey); err != nil {
+		return nil, err
+	}
+
+	var user *account.User
+	if strings.Contains(payload.Identifier, "@") {
+		user, err = a.users.GetByEmail(ctx, payload.Identifier)
+	} else {
+		user, err = a.users.GetByUsername(ctx, payload.Identifier)
+	}
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			pending, err := a.throttle.begin(ctx, account.IdentifierAttemptKey(payload.Identifier), ipKey)
+			if err != nil {
+				return nil, err
+			}
+			a.throttle.fail(ctx, pending)
+			return nil, errInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// percobaan dihitung sebelum password diverifikasi agar tebakan paralel
+	// tetap terbatas, lalu dikembalikan jika password benar
+	pending, err := a.throttle.begin(ctx, account.AccountAttemptKey(user.ID), ipKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, rehashed := user.VerifyPassword(a.hasher, payload.Password)
+	if !ok || !user.IsActive {
+		a.throttle.fail(ctx, pending)
+		return nil, errInvalidCredentials
+	}
+	a.throttle.release(ctx, pending)
+
+	if rehashed {
+		// Kegagalan menyimpan hash baru tidak membatalkan login, hash lama
+		// masih valid dan akan dicoba lagi pada login berikutnya. Versi tidak
+		// dinaikkan agar If-Match milik client lain tetap berlaku
+		if err := a.users.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+			slog.WarnContext(ctx, "failed to store rehashed password", slog.String("user_id", user.ID.String()), slog.Any("error", err))
+		}
+	}
+	if a.config.RequireVerifiedEmail && !user.IsEmailVerified() {
+		return nil, errEmailNotVerified
+	}
+
+	return a.complete(ctx, user)
+}
+
+/**
+ * VerifyMFA consumes the challenge token and checks the second factor.
+ * @param ctx context.Context
+ * @param payload *account.VerifyMFARequest
+ * @return (*account.LoginResponse, error)
+ */
+func (a *AuthService) VerifyMFA(ctx context.Context, payload *account.VerifyMFARequest) (result *account.LoginResponse, err error) {
+	challenge, err := a.tokens.FindByHash(ctx, token.Hash(payload.ChallengeToken), account.TokenMFAChallenge)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+	if !challenge.IsUsable(time.Now()) {
+		return nil, errInvalidChallenge
+	}
+
+	user, err := a.users.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+	if !user.IsActive {
+		return nil, errInvalidChallenge
+	}
+
+	mfa, err := a.mfa.FindByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	pending, err := a.throttle.begin(ctx, account.AccountAttemptKey(user.ID), clientIPKey(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err = verifySecondFactor(ctx, a.mfa, a.cipher, mfa, payload.Code, payload.RecoveryCode); err != nil {
+		if errs.IsValidationError(err) {
+			a.throttle.fail(ctx, pending)
+		} else {
+			a.throttle.release(ctx, pending)
+		}
+		return nil, err
+	}
+	a.throttle.release(ctx, pending)
+
+	if err = a.tokens.MarkUsed(ctx, challenge); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, fmt.Errorf("failed to consume mfa challenge: %w", err)
+	}
+
+	a.resetAttempts(ctx, user)
+	return a.issue(ctx, user)
+}
+
+// complete melanjutkan login yang faktor pertamanya sudah lolos, baik password
+// maupun identity provider eksternal, ke challenge MFA atau penerbitan token
+func (a *AuthService) complete(ctx context.Context, user *account.User) (*account.LoginResponse, error) {
+	mfa, err := a.mfa.FindByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if mfa != nil && mfa.IsEnabled() {
+		return a.challenge(ctx, user, account.TokenMFAChallenge)
+	}
+
+	required, err := a.requiresMFA(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return a.challenge(ctx, user, account.TokenMFAEnrollment)
+	}
+
+	// Hitungan kegagalan baru direset setelah semua faktor lolos
+	a.resetAttempts(ctx, user)
+	return a.issue(ctx, user)
+}
+
+// challenge menerbitkan token untuk memasukkan kode MFA, atau untuk
+// mendaftarkan MFA bila purpose-nya TokenMFAEnrollment
+func (a *AuthService) challenge(ctx context.Context, user *account.User, purpose account.TokenPurpose) (*account.LoginResponse, error) {
+	challenge, plain, err := account.NewUserToken(user.ID, purpose, a.config.ChallengeTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa challenge: %w", errs.ErrInternal)
+	}
+	if err = a.tokens.Create(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("failed to store mfa challenge: %w", err)
+	}
+
+	return &account.LoginResponse{
+		MFARequired:           purpose == account.TokenMFAChallenge,
+		MFAEnrollmentRequired: purpose == account.TokenMFAEnrollment,
+		ChallengeToken:        plain,
+		ExpiresAt:             challenge.ExpiresAt,
+	}, nil
+}
+
+// requiresMFA bernilai true untuk akun admin yang belum mengaktifkan MFA
+func (a *AuthService) requiresMFA(ctx context.Context, user *account.User) (bool, error) {
+	if len(a.config.MFARequiredPermissions) == 0 {
+		return false, nil
+	}
+
+	permissions, err := a.resolver.UserPermissions(ctx, user)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve permissions: %w", err)
+	}
+	for _, permission := range a.config.MFARequiredPermissions {
+		if slices.Contains(permissions, permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *AuthService) issue(ctx context.Context, user *account.User) (*account.LoginResponse, error) {
+	sessionID := identity.New()
+	accessToken, expiresAt, err := a.issuer.Issue(ctx, user, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	session := account.NewSession(sessionID, user.ID, requestctx.UserAgent(ctx), requestctx.ClientIP(ctx), expiresAt)
+	if err = a.sessions.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return &account.LoginResponse{
+		AccessToken: accessToken,
+		TokenType:   tokenTypeBearer,
+		ExpiresAt:   expiresAt,
+		User:        user.ToUserResponse(),
+	}, nil
+}
+
+func (a *AuthService) resetAttempts(ctx context.Context, user *account.User) {
+	if err := a.throttle.reset(ctx, account.AccountAttemptKey(user.ID)); err != nil {
+		slog.WarnContext(ctx, "failed to reset login attempts", slog.String("user_id", user.ID.String()), slog.Any("error", err))
+	}
+}
+
+func clientIPKey(ctx context.Context) string {
+	ip := requestctx.ClientIP(ctx)
+	if ip == "" {
+		return ""
+	}
+	return account.IPAttemptKey(ip)
+}
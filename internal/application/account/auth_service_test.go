@@ -0,0 +1,143 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// upgradingHasher menerima password apa pun dan selalu meminta rehash untuk hash lama
+type upgradingHasher struct{}
+
+func (upgradingHasher) Hash(password string) (string, error) {
+	return "new:" + password, nil
+}
+
+func (upgradingHasher) Verify(password string, encoded string) (bool, error) {
+	return strings.HasSuffix(encoded, ":"+password), nil
+}
+
+func (upgradingHasher) NeedsRehash(encoded string) bool {
+	return !strings.HasPrefix(encoded, "new:")
+}
+
+// rehashUsers mencatat cara hash baru disimpan
+type rehashUsers struct {
+	interfaces.IUserRepository
+	user      *account.User
+	stored    account.PasswordHash
+	updateAll bool
+}
+
+func (r *rehashUsers) GetByUsername(ctx context.Context, username string) (*account.User, error) {
+	return r.user, nil
+}
+
+func (r *rehashUsers) Update(ctx context.Context, user *account.User) error {
+	r.updateAll = true
+	return nil
+}
+
+func (r *rehashUsers) UpdatePassword(ctx context.Context, id identity.ID, password account.PasswordHash) error {
+	r.stored = password
+	return nil
+}
+
+type noMFA struct {
+	interfaces.IMFARepository
+}
+
+func (noMFA) FindByUserID(ctx context.Context, userID identity.ID) (*account.UserMFA, error) {
+	return nil, errs.ErrNotFound
+}
+
+type staticIssuer struct {
+	interfaces.IAccessTokenIssuer
+}
+
+func (staticIssuer) Issue(ctx context.Context, user *account.User, sessionID identity.ID) (string, time.Time, error) {
+	return "token", time.Now().Add(time.Hour), nil
+}
+
+type discardSessions struct {
+	interfaces.ISessionRepository
+}
+
+func (discardSessions) Create(ctx context.Context, session *account.Session) error {
+	return nil
+}
+
+func TestAuthServiceLoginRehash(t *testing.T) {
+	tests := []struct {
+		name       string
+		stored     string
+		wantStored string
+	}{
+		{name: "legacy hash is upgraded", stored: "old:secret", wantStored: "new:secret"},
+		{name: "current hash is left alone", stored: "new:secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Username: "user", Password: account.NewPasswordHash(tt.stored), IsActive: true, Version: 3}
+			users := &rehashUsers{user: user}
+			config := DefaultAuthConfig
+			config.MFARequiredPermissions = nil
+			service := NewAuthService(users, nil, noMFA{}, nil, discardSessions{}, upgradingHasher{}, staticIssuer{}, fakeResolver{}, newMemoryAttemptStore(), config)
+
+			if _, err := service.Login(context.Background(), &account.LoginRequest{Identifier: "user", Password: "secret"}); err != nil {
+				t.Fatalf("Login() error = %v", err)
+			}
+			if users.stored.Encoded() != tt.wantStored {
+				t.Errorf("stored hash = %q, want %q", users.stored.Encoded(), tt.wantStored)
+			}
+			// rehash hanya menulis kolom password, versi user tetap
+			if users.updateAll {
+				t.Error("Login() rewrote the whole user")
+			}
+			if user.Version != 3 {
+				t.Errorf("version = %d, want 3", user.Version)
+			}
+		})
+	}
+}
+
+func TestAuthServiceLoginRequiresVerifiedEmail(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name     string
+		require  bool
+		verified *time.Time
+		wantErr  error
+	}{
+		{name: "gate disabled"},
+		{name: "verified user", require: true, verified: &verifiedAt},
+		{name: "unverified user", require: true, wantErr: errEmailNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Username: "user", Password: account.NewPasswordHash("new:secret"), IsActive: true, EmailVerifiedAt: tt.verified}
+			config := DefaultAuthConfig
+			config.MFARequiredPermissions = nil
+			config.RequireVerifiedEmail = tt.require
+			service := NewAuthService(&rehashUsers{user: user}, nil, noMFA{}, nil, discardSessions{}, upgradingHasher{}, staticIssuer{}, fakeResolver{}, newMemoryAttemptStore(), config)
+
+			result, err := service.Login(context.Background(), &account.LoginRequest{Identifier: "user", Password: "secret"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && result.AccessToken == "" {
+				t.Error("Login() issued no access token")
+			}
+		})
+	}
+}
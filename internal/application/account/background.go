@@ -0,0 +1,26 @@
+package account
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const defaultBackgroundTimeout = 30 * time.Second
+
+// runInBackground menjalankan fn setelah respons dikirim sehingga lama respons
+// tidak bergantung pada ada tidaknya akun. Error hanya di-log.
+func runInBackground(ctx context.Context, timeout time.Duration, operation string, fn func(ctx context.Context) error) {
+	if timeout <= 0 {
+		timeout = defaultBackgroundTimeout
+	}
+
+	// lepas dari pembatalan request agar proses tetap jalan setelah respons terkirim
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	go func() {
+		defer cancel()
+		if err := fn(ctx); err != nil {
+			slog.ErrorContext(ctx, "background "+operation+" failed", slog.Any("error", err))
+		}
+	}()
+}
@@ -0,0 +1,264 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/mail"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type EmailVerificationConfig struct {
+	// VerifyURL adalah halaman frontend yang menerima query parameter token
+	VerifyURL string
+	TokenTTL  time.Duration
+
+	// ResendCooldown adalah jeda minimum antar pengiriman ulang ke satu email,
+	// MaxResends dan MaxResendsPerIP membatasi jumlah permintaan dalam ResendWindow
+	ResendCooldown  time.Duration
+	ResendWindow    time.Duration
+	MaxResends      int
+	MaxResendsPerIP int
+
+	// SendTimeout membatasi proses kirim ulang yang berjalan di background
+	SendTimeout time.Duration
+}
+
+var DefaultEmailVerificationConfig = EmailVerificationConfig{
+	TokenTTL:        48 * time.Hour,
+	ResendCooldown:  time.Minute,
+	ResendWindow:    time.Hour,
+	MaxResends:      5,
+	MaxResendsPerIP: 20,
+}
+
+var errInvalidVerificationToken = errs.ValidationError{Field: "token", Message: "is invalid or has expired"}
+
+type EmailVerificationService struct {
+	users     interfaces.IUserRepository
+	tokens    interfaces.IUserTokenRepository
+	attempts  interfaces.ILoginAttemptStore
+	mailer    mail.IMailer
+	auditor   auditInterfaces.IAuditService
+	committer shared.Committer
+	config    EmailVerificationConfig
+}
+
+func NewEmailVerificationService(users interfaces.IUserRepository, tokens interfaces.IUserTokenRepository, attempts interfaces.ILoginAttemptStore, mailer mail.IMailer, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher, config EmailVerificationConfig) *EmailVerificationService {
+	return &EmailVerificationService{
+		users:     users,
+		tokens:    tokens,
+		attempts:  attempts,
+		mailer:    mailer,
+		auditor:   auditor,
+		committer: shared.NewCommitter(tx, outbox, dispatcher),
+		config:    config,
+	}
+}
+
+/**
+ * Subscribe sends a verification link whenever a user registers or changes
+ * their email.
+ * @param dispatcher *event.Dispatcher
+ */
+func (e *EmailVerificationService) Subscribe(dispatcher *event.Dispatcher) {
+	event.Subscribe(dispatcher, func(ctx context.Context, registered account.UserRegistered) error {
+		return e.SendVerification(ctx, registered.UserID)
+	})
+	event.Subscribe(dispatcher, func(ctx context.Context, changed account.UserEmailChanged) error {
+		return e.SendVerification(ctx, changed.UserID)
+	})
+}
+
+/**
+ * SendVerification issues a verification token and mails the link.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @return error
+ */
+func (e *EmailVerificationService) SendVerification(ctx context.Context, userID identity.ID) (err error) {
+	user, err := e.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	return e.issue(ctx, user)
+}
+
+/**
+ * Confirm consumes the token and marks the email as verified.
+ * @param ctx context.Context
+ * @param plainToken string
+ * @return error
+ */
+func (e *EmailVerificationService) Confirm(ctx context.Context, plainToken string) (err error) {
+	verifyToken, err := e.tokens.FindByHash(ctx, token.Hash(plainToken), account.TokenEmailVerification)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errInvalidVerificationToken
+		}
+		return fmt.Errorf("failed to get verification token: %w", err)
+	}
+	if !verifyToken.IsUsable(time.Now()) {
+		return errInvalidVerificationToken
+	}
+
+	user, err := e.users.GetByID(ctx, verifyToken.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errInvalidVerificationToken
+		}
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	before := *user
+	user.VerifyEmail(time.Now())
+
+	err = e.committer.Commit(ctx, user, func(ctx context.Context) error {
+		if err := e.tokens.MarkUsed(ctx, verifyToken); err != nil {
+			return err
+		}
+		if err := e.users.Update(ctx, user); err != nil {
+			return err
+		}
+		return e.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, user.ID.String(), audit.Diff(&before, user))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errInvalidVerificationToken
+		}
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	return nil
+}
+
+/**
+ * Resend sends a new verification link in the background. Requests are
+ * throttled per email and per client IP before the email is looked up, so
+ * registered and unknown emails get the same answer and the same limits.
+ * @param ctx context.Context
+ * @param email string
+ * @return error
+ */
+func (e *EmailVerificationService) Resend(ctx context.Context, email string) (err error) {
+	if err = e.throttle(ctx, email); err != nil {
+		return err
+	}
+
+	runInBackground(ctx, e.config.SendTimeout, "verification resend", func(ctx context.Context) error {
+		user, err := e.users.GetByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to get user by email: %w", err)
+		}
+		if !user.IsActive || user.IsEmailVerified() {
+			return nil
+		}
+		return e.issue(ctx, user)
+	})
+	return nil
+}
+
+type resendLimit struct {
+	key      string
+	cooldown time.Duration
+	max      int
+}
+
+// throttle mencatat permintaan pada kunci email dan IP. Setiap permintaan
+// mengunci email selama ResendCooldown dan kunci yang melewati batas dikunci
+// sampai ResendWindow habis. Gangguan store hanya di-log seperti loginThrottle.
+func (e *EmailVerificationService) throttle(ctx context.Context, email string) error {
+	limits := []resendLimit{
+		{key: account.ResendEmailKey(email), cooldown: e.config.ResendCooldown, max: e.config.MaxResends},
+	}
+	if ip := requestctx.ClientIP(ctx); ip != "" {
+		limits = append(limits, resendLimit{key: account.ResendIPKey(ip), max: e.config.MaxResendsPerIP})
+	}
+
+	now := time.Now()
+	for _, limit := range limits {
+		attempt, err := e.attempts.Get(ctx, limit.key)
+		if err != nil {
+			if !errors.Is(err, errs.ErrNotFound) {
+				slog.WarnContext(ctx, "failed to read resend attempts", slog.String("key", limit.key), slog.Any("error", err))
+			}
+			continue
+		}
+		if attempt.IsLocked(now) {
+			return fmt.Errorf("verification email was requested too often: %w", errs.ErrTooManyRequests)
+		}
+	}
+
+	for _, limit := range limits {
+		attempt, err := e.attempts.RecordFailure(ctx, limit.key, now, e.config.ResendWindow)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to record resend attempt", slog.String("key", limit.key), slog.Any("error", err))
+			continue
+		}
+
+		var until time.Time
+		switch {
+		case limit.max > 0 && attempt.Failures >= limit.max:
+			until = now.Add(e.config.ResendWindow)
+		case limit.cooldown > 0:
+			until = now.Add(limit.cooldown)
+		default:
+			continue
+		}
+		if err := e.attempts.Lock(ctx, limit.key, until); err != nil {
+			slog.WarnContext(ctx, "failed to lock resend attempts", slog.String("key", limit.key), slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+func (e *EmailVerificationService) issue(ctx context.Context, user *account.User) error {
+	verifyToken, plain, err := account.NewUserToken(user.ID, account.TokenEmailVerification, e.config.TokenTTL)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", errs.ErrInternal)
+	}
+
+	err = e.committer.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := e.tokens.InvalidateForUser(ctx, user.ID, account.TokenEmailVerification); err != nil {
+			return err
+		}
+		return e.tokens.Create(ctx, verifyToken)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store verification token: %w", err)
+	}
+
+	message := mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Use the link below to verify your email address. It expires in %s.\n\n%s",
+			e.config.TokenTTL, linkWithToken(e.config.VerifyURL, plain)),
+	}
+	if err := e.mailer.Send(ctx, message); err != nil {
+		slog.ErrorContext(ctx, "failed to send verification mail", slog.Any("error", err))
+	}
+	return nil
+}
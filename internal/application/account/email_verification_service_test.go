@@ -0,0 +1,177 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/mail"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+func newEmailVerificationService(users interfaces.IUserRepository, tokens interfaces.IUserTokenRepository, mailer mail.IMailer, config EmailVerificationConfig) *EmailVerificationService {
+	config.VerifyURL = "https://app.example.com/verify"
+	return NewEmailVerificationService(users, tokens, newMemoryAttemptStore(), mailer, discardAuditor{}, inlineTx{}, discardOutbox{}, discardDispatcher{}, config)
+}
+
+func TestEmailVerificationServiceSendVerification(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name     string
+		verified *time.Time
+		unknown  bool
+		wantErr  error
+		wantMail bool
+	}{
+		{name: "unverified user", wantMail: true},
+		{name: "verified user", verified: &verifiedAt},
+		{name: "unknown user", unknown: true, wantErr: errs.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Email: "jane@example.com", IsActive: true, EmailVerifiedAt: tt.verified}
+			previous, _, _ := account.NewUserToken(user.ID, account.TokenEmailVerification, time.Hour)
+			tokens := newMemoryTokens(previous)
+			mailer := &recordingMailer{}
+			service := newEmailVerificationService(&accountUsers{user: user}, tokens, mailer, DefaultEmailVerificationConfig)
+
+			userID := user.ID
+			if tt.unknown {
+				userID = identity.New()
+			}
+			err := service.SendVerification(context.Background(), userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SendVerification() error = %v, want %v", err, tt.wantErr)
+			}
+			if !tt.wantMail {
+				if len(mailer.messages) != 0 {
+					t.Errorf("sent %d mails, want none", len(mailer.messages))
+				}
+				return
+			}
+
+			if len(mailer.messages) != 1 || mailer.messages[0].To != user.Email {
+				t.Fatalf("mails = %+v, want one to %s", mailer.messages, user.Email)
+			}
+			usable := tokens.usable(user.ID, account.TokenEmailVerification)
+			if len(usable) != 1 || usable[0].TokenHash != token.Hash(tokenFromMail(t, mailer.messages[0])) {
+				t.Errorf("usable tokens = %d, want only the mailed token", len(usable))
+			}
+		})
+	}
+}
+
+func TestEmailVerificationServiceConfirm(t *testing.T) {
+	used := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		purpose account.TokenPurpose
+		expires time.Duration
+		usedAt  *time.Time
+		unknown bool
+		wantErr error
+	}{
+		{name: "valid token", purpose: account.TokenEmailVerification, expires: time.Hour},
+		{name: "unknown token", purpose: account.TokenEmailVerification, expires: time.Hour, unknown: true, wantErr: errInvalidVerificationToken},
+		{name: "expired token", purpose: account.TokenEmailVerification, expires: -time.Minute, wantErr: errInvalidVerificationToken},
+		{name: "used token", purpose: account.TokenEmailVerification, expires: time.Hour, usedAt: &used, wantErr: errInvalidVerificationToken},
+		{name: "reset token", purpose: account.TokenPasswordReset, expires: time.Hour, wantErr: errInvalidVerificationToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Email: "jane@example.com", IsActive: true}
+			verifyToken, plain, _ := account.NewUserToken(user.ID, tt.purpose, tt.expires)
+			verifyToken.UsedAt = tt.usedAt
+			tokens := newMemoryTokens(verifyToken)
+			if tt.unknown {
+				tokens = newMemoryTokens()
+			}
+			users := &accountUsers{user: user}
+			service := newEmailVerificationService(users, tokens, &recordingMailer{}, DefaultEmailVerificationConfig)
+
+			err := service.Confirm(context.Background(), plain)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Confirm() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantVerified := tt.wantErr == nil; user.IsEmailVerified() != wantVerified || (users.updates == 1) != wantVerified {
+				t.Errorf("verified = %v after %d updates, want %v", user.IsEmailVerified(), users.updates, wantVerified)
+			}
+			if tt.wantErr == nil && verifyToken.IsUsable(time.Now()) {
+				t.Error("verification token is still usable after Confirm()")
+			}
+		})
+	}
+}
+
+func TestEmailVerificationServiceThrottle(t *testing.T) {
+	config := DefaultEmailVerificationConfig
+	config.MaxResends = 3
+	config.MaxResendsPerIP = 2
+
+	type request struct {
+		email   string
+		ip      string
+		wantErr error
+	}
+	tests := []struct {
+		name     string
+		cooldown time.Duration
+		requests []request
+	}{
+		{
+			name:     "cooldown per email",
+			cooldown: time.Minute,
+			requests: []request{
+				{email: "jane@example.com"},
+				{email: "jane@example.com", wantErr: errs.ErrTooManyRequests},
+				{email: "john@example.com"},
+			},
+		},
+		{
+			name: "limit per email without cooldown",
+			requests: []request{
+				{email: "jane@example.com"},
+				{email: "jane@example.com"},
+				{email: "jane@example.com"},
+				{email: "jane@example.com", wantErr: errs.ErrTooManyRequests},
+			},
+		},
+		{
+			// email yang belum terdaftar tetap menghabiskan jatah IP
+			name: "limit per ip across emails",
+			requests: []request{
+				{email: "a@example.com", ip: "10.0.0.1"},
+				{email: "b@example.com", ip: "10.0.0.1"},
+				{email: "c@example.com", ip: "10.0.0.1", wantErr: errs.ErrTooManyRequests},
+				{email: "c@example.com", ip: "10.0.0.2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.ResendCooldown = tt.cooldown
+			service := newEmailVerificationService(&accountUsers{}, newMemoryTokens(), &recordingMailer{}, config)
+
+			for i, req := range tt.requests {
+				ctx := context.Background()
+				if req.ip != "" {
+					ctx = requestctx.WithClientIP(ctx, req.ip)
+				}
+				if err := service.throttle(ctx, req.email); !errors.Is(err, req.wantErr) {
+					t.Fatalf("request %d throttle() error = %v, want %v", i, err, req.wantErr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+// loginThrottle membatasi percobaan login per akun dan per IP. Kegagalan
+// store hanya di-log agar gangguan store tidak menutup akses login.
+type loginThrottle struct {
+	store   interfaces.ILoginAttemptStore
+	account account.LockoutPolicy
+	ip      account.LockoutPolicy
+}
+
+// pendingAttempt adalah percobaan yang sudah dihitung gagal sebelum
+// kredensial diperiksa
+type pendingAttempt struct {
+	key      string
+	policy   account.LockoutPolicy
+	failures int
+}
+
+// begin menolak percobaan jika salah satu kunci masih dalam masa jeda, lalu
+// menaikkan hitungan setiap kunci secara atomik sebelum kredensial diperiksa.
+// Request paralel mendapat nomor percobaan yang berbeda sehingga tidak bisa
+// melewati MaxFailures. Hasilnya wajib diteruskan ke fail atau release.
+func (t loginThrottle) begin(ctx context.Context, accountKey string, ipKey string) ([]pendingAttempt, error) {
+	if err := t.check(ctx, accountKey, ipKey); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pending := make([]pendingAttempt, 0, 2)
+	for _, attempt := range []pendingAttempt{{key: accountKey, policy: t.account}, {key: ipKey, policy: t.ip}} {
+		if attempt.key == "" {
+			continue
+		}
+
+		recorded, err := t.store.RecordFailure(ctx, attempt.key, now, attempt.policy.ResetAfter)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to record login attempt", slog.String("key", attempt.key), slog.Any("error", err))
+			continue
+		}
+		attempt.failures = recorded.Failures
+		pending = append(pending, attempt)
+	}
+
+	for _, attempt := range pending {
+		if attempt.policy.MaxFailures > 0 && attempt.failures > attempt.policy.MaxFailures {
+			t.fail(ctx, pending)
+			return nil, tooManyAttempts(attempt.policy.LockoutDuration)
+		}
+	}
+	return pending, nil
+}
+
+// check menolak percobaan jika salah satu kunci masih dalam masa jeda
+func (t loginThrottle) check(ctx context.Context, keys ...string) error {
+	now := time.Now()
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+
+		attempt, err := t.store.Get(ctx, key)
+		if err != nil {
+			if !errors.Is(err, errs.ErrNotFound) {
+				slog.WarnContext(ctx, "failed to read login attempts", slog.String("key", key), slog.Any("error", err))
+			}
+			continue
+		}
+		if attempt.IsLocked(now) {
+			return tooManyAttempts(attempt.LockedUntil.Sub(now))
+		}
+	}
+	return nil
+}
+
+// fail menetapkan jeda sesuai jumlah kegagalan yang sudah dihitung begin
+func (t loginThrottle) fail(ctx context.Context, pending []pendingAttempt) {
+	now := time.Now()
+	for _, attempt := range pending {
+		until := attempt.policy.LockUntil(attempt.failures, now)
+		if until == nil {
+			continue
+		}
+		if err := t.store.Lock(ctx, attempt.key, *until); err != nil {
+			slog.WarnContext(ctx, "failed to lock login attempts", slog.String("key", attempt.key), slog.Any("error", err))
+		}
+	}
+}
+
+// release mengembalikan hitungan percobaan yang ternyata berhasil
+func (t loginThrottle) release(ctx context.Context, pending []pendingAttempt) {
+	for _, attempt := range pending {
+		if err := t.store.Release(ctx, attempt.key); err != nil {
+			slog.WarnContext(ctx, "failed to release login attempt", slog.String("key", attempt.key), slog.Any("error", err))
+		}
+	}
+}
+
+func (t loginThrottle) reset(ctx context.Context, key string) error {
+	return t.store.Reset(ctx, key)
+}
+
+func tooManyAttempts(wait time.Duration) error {
+	return fmt.Errorf("too many failed login attempts, try again in %s: %w", max(wait.Round(time.Second), time.Second), errs.ErrTooManyRequests)
+}
@@ -0,0 +1,187 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/infrastructure/memory"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+// memoryAttemptStore meniru increment atomik LoginAttemptRepository
+type memoryAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]account.LoginAttempt
+}
+
+func newMemoryAttemptStore() *memoryAttemptStore {
+	return &memoryAttemptStore{attempts: make(map[string]account.LoginAttempt)}
+}
+
+func (m *memoryAttemptStore) Get(ctx context.Context, key string) (*account.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempt, ok := m.attempts[key]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	return &attempt, nil
+}
+
+func (m *memoryAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (*account.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempt := m.attempts[key]
+	attempt.Key = key
+	if attempt.LastFailedAt.Before(now.Add(-resetAfter)) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = now
+	m.attempts[key] = attempt
+	return &attempt, nil
+}
+
+func (m *memoryAttemptStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if attempt, ok := m.attempts[key]; ok && attempt.Failures > 0 {
+		attempt.Failures--
+		m.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (m *memoryAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempt := m.attempts[key]
+	attempt.Key = key
+	attempt.LockedUntil = &until
+	m.attempts[key] = attempt
+	return nil
+}
+
+func (m *memoryAttemptStore) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.attempts, key)
+	return nil
+}
+
+// attemptStores adalah store yang diuji bersama throttle
+func attemptStores() map[string]func() interfaces.ILoginAttemptStore {
+	return map[string]func() interfaces.ILoginAttemptStore{
+		"atomic fake":  func() interfaces.ILoginAttemptStore { return newMemoryAttemptStore() },
+		"memory store": func() interfaces.ILoginAttemptStore { return memory.NewLoginAttemptStore(time.Hour) },
+	}
+}
+
+func TestLoginThrottleParallelAttempts(t *testing.T) {
+	for name, newStore := range attemptStores() {
+		t.Run(name, func(t *testing.T) {
+			testLoginThrottleParallelAttempts(t, newStore())
+		})
+	}
+}
+
+func testLoginThrottleParallelAttempts(t *testing.T, store interfaces.ILoginAttemptStore) {
+	policy := account.LockoutPolicy{MaxFailures: 3, LockoutDuration: time.Minute, ResetAfter: time.Hour}
+	throttle := loginThrottle{store: store, account: policy, ip: policy}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pending, err := throttle.begin(context.Background(), "user:1", "")
+			if err != nil {
+				if !errors.Is(err, errs.ErrTooManyRequests) {
+					t.Errorf("begin() error = %v", err)
+				}
+				return
+			}
+			mu.Lock()
+			allowed++
+			mu.Unlock()
+			// semua tebakan salah
+			throttle.fail(context.Background(), pending)
+		}()
+	}
+	wg.Wait()
+
+	if allowed > policy.MaxFailures {
+		t.Fatalf("%d parallel attempts reached the password check, want at most %d", allowed, policy.MaxFailures)
+	}
+	if _, err := throttle.begin(context.Background(), "user:1", ""); !errors.Is(err, errs.ErrTooManyRequests) {
+		t.Fatalf("begin() after lockout error = %v, want ErrTooManyRequests", err)
+	}
+}
+
+func TestLoginThrottleRelease(t *testing.T) {
+	for name, newStore := range attemptStores() {
+		t.Run(name, func(t *testing.T) {
+			testLoginThrottleRelease(t, newStore())
+		})
+	}
+}
+
+func testLoginThrottleRelease(t *testing.T, store interfaces.ILoginAttemptStore) {
+	policy := account.LockoutPolicy{MaxFailures: 2, LockoutDuration: time.Minute, ResetAfter: time.Hour}
+	throttle := loginThrottle{store: store, account: policy, ip: policy}
+
+	// login yang berhasil tidak boleh menghabiskan jatah percobaan
+	for range 5 {
+		pending, err := throttle.begin(context.Background(), "user:1", "ip:10.0.0.1")
+		if err != nil {
+			t.Fatalf("begin() error = %v", err)
+		}
+		throttle.release(context.Background(), pending)
+	}
+
+	for _, key := range []string{"user:1", "ip:10.0.0.1"} {
+		attempt, _ := store.Get(context.Background(), key)
+		if attempt.Failures != 0 || attempt.LockedUntil != nil {
+			t.Errorf("%s = %d failures, locked %v, want a clean counter", key, attempt.Failures, attempt.LockedUntil)
+		}
+	}
+}
+
+func TestLoginThrottleReleaseKeepsLock(t *testing.T) {
+	for name, newStore := range attemptStores() {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			policy := account.LockoutPolicy{MaxFailures: 1, LockoutDuration: time.Minute, ResetAfter: time.Hour}
+			throttle := loginThrottle{store: store, account: policy}
+
+			pending, err := throttle.begin(context.Background(), "user:1", "")
+			if err != nil {
+				t.Fatalf("begin() error = %v", err)
+			}
+			throttle.fail(context.Background(), pending)
+			// lock tetap berlaku walau hitungan dikembalikan
+			throttle.release(context.Background(), pending)
+			throttle.release(context.Background(), pending)
+
+			attempt, err := store.Get(context.Background(), "user:1")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if attempt.Failures != 0 {
+				t.Errorf("failures = %d, want 0", attempt.Failures)
+			}
+			if _, err := throttle.begin(context.Background(), "user:1", ""); !errors.Is(err, errs.ErrTooManyRequests) {
+				t.Errorf("begin() while locked error = %v, want ErrTooManyRequests", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/otp"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+	"github.com/skip2/go-qrcode"
+)
+
+const qrCodeSize = 256
+
+type MFAConfig struct {
+	// Issuer ditampilkan sebagai nama akun di aplikasi authenticator
+	Issuer string
+}
+
+var errInvalidMFACode = errs.ValidationError{Field: "code", Message: "is invalid or has already been used"}
+
+type MFAService struct {
+	users     interfaces.IUserRepository
+	repo      interfaces.IMFARepository
+	tokens    interfaces.IUserTokenRepository
+	cipher    account.ISecretCipher
+	auditor   auditInterfaces.IAuditService
+	committer shared.Committer
+	config    MFAConfig
+}
+
+func NewMFAService(users interfaces.IUserRepository, repo interfaces.IMFARepository, tokens interfaces.IUserTokenRepository, cipher account.ISecretCipher, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher, config MFAConfig) *MFAService {
+	return &MFAService{
+		users:     users,
+		repo:      repo,
+		tokens:    tokens,
+		cipher:    cipher,
+		auditor:   auditor,
+		committer: shared.NewCommitter(tx, outbox, dispatcher),
+		config:    config,
+	}
+}
+
+/**
+ * EnrollTOTP generates a new pending TOTP secret for the actor. A previous
+ * pending enrollment is replaced.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @return (*account.TOTPEnrollmentResponse, error)
+ */
+func (m *MFAService) EnrollTOTP(ctx context.Context, userID identity.ID) (result *account.TOTPEnrollmentResponse, err error) {
+	if err = requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+	return m.enroll(ctx, userID)
+}
+
+/**
+ * EnrollTOTPWithChallenge starts the enrollment that login demands from
+ * accounts which must use MFA, identified by the enrollment challenge.
+ * @param ctx context.Context
+ * @param challengeToken string
+ * @return (*account.TOTPEnrollmentResponse, error)
+ */
+func (m *MFAService) EnrollTOTPWithChallenge(ctx context.Context, challengeToken string) (result *account.TOTPEnrollmentResponse, err error) {
+	challenge, err := m.enrollmentChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return m.enroll(ctx, challenge.UserID)
+}
+
+func (m *MFAService) enroll(ctx context.Context, userID identity.ID) (*account.TOTPEnrollmentResponse, error) {
+	user, err := m.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	current, err := m.repo.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if current != nil && current.IsEnabled() {
+		return nil, fmt.Errorf("mfa is already enabled: %w", errs.ErrConflict)
+	}
+
+	mfa, secret, err := account.NewUserMFA(userID, m.cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", errs.ErrInternal)
+	}
+	if err = m.repo.Save(ctx, mfa); err != nil {
+		return nil, fmt.Errorf("failed to save mfa: %w", err)
+	}
+
+	uri := otp.URI(m.config.Issuer, user.Email, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render qr code: %w", errs.ErrInternal)
+	}
+
+	return &account.TOTPEnrollmentResponse{Secret: secret, URI: uri, QRCode: png}, nil
+}
+
+/**
+ * ActivateTOTP enables MFA and issues the first set of recovery codes.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param code string
+ * @return (*account.RecoveryCodesResponse, error)
+ */
+func (m *MFAService) ActivateTOTP(ctx context.Context, userID identity.ID, code string) (result *account.RecoveryCodesResponse, err error) {
+	if err = requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+	return m.activate(ctx, userID, code, nil)
+}
+
+/**
+ * ActivateTOTPWithChallenge finishes the enrollment demanded at login and
+ * consumes the challenge. The user then logs in again with the new code.
+ * @param ctx context.Context
+ * @param challengeToken string
+ * @param code string
+ * @return (*account.RecoveryCodesResponse, error)
+ */
+func (m *MFAService) ActivateTOTPWithChallenge(ctx context.Context, challengeToken string, code string) (result *account.RecoveryCodesResponse, err error) {
+	challenge, err := m.enrollmentChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return m.activate(ctx, challenge.UserID, code, challenge)
+}
+
+// activate mengaktifkan MFA, challenge diisi bila aktivasi berasal dari login
+// sehingga challenge ikut terpakai dalam transaksi yang sama
+func (m *MFAService) activate(ctx context.Context, userID identity.ID, code string, challenge *account.UserToken) (*account.RecoveryCodesResponse, error) {
+	mfa, err := m.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if mfa.IsEnabled() {
+		return nil, fmt.Errorf("mfa is already enabled: %w", errs.ErrConflict)
+	}
+
+	step, ok, err := mfa.Check(m.cipher, code, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read totp secret: %w", errs.ErrInternal)
+	}
+	if !ok {
+		return nil, errInvalidMFACode
+	}
+	mfa.Enable(step)
+
+	codes, plain, err := account.NewRecoveryCodes(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", errs.ErrInternal)
+	}
+
+	err = m.committer.Commit(ctx, mfa, func(ctx context.Context) error {
+		if challenge != nil {
+			if err := m.tokens.MarkUsed(ctx, challenge); err != nil {
+				return err
+			}
+		}
+		if err := m.repo.Save(ctx, mfa); err != nil {
+			return err
+		}
+		if err := m.repo.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "mfa_enabled", Before: false, After: true}}
+		return m.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, userID.String(), changes)
+	})
+	if err != nil {
+		if challenge != nil && errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, fmt.Errorf("failed to activate mfa: %w", err)
+	}
+
+	return &account.RecoveryCodesResponse{Codes: plain}, nil
+}
+
+/**
+ * DisableTOTP turns MFA off and removes the recovery codes.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param code string
+ * @return error
+ */
+func (m *MFAService) DisableTOTP(ctx context.Context, userID identity.ID, code string) (err error) {
+	if err = requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	mfa, err := m.enabledMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = verifySecondFactor(ctx, m.repo, m.cipher, mfa, code, ""); err != nil {
+		return err
+	}
+	mfa.MarkDisabled()
+
+	err = m.committer.Commit(ctx, mfa, func(ctx context.Context) error {
+		if err := m.repo.Delete(ctx, userID); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "mfa_enabled", Before: true, After: false}}
+		return m.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, userID.String(), changes)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to disable mfa: %w", err)
+	}
+	return nil
+}
+
+/**
+ * RegenerateRecoveryCodes invalidates the old recovery codes.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param code string
+ * @return (*account.RecoveryCodesResponse, error)
+ */
+func (m *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID identity.ID, code string) (result *account.RecoveryCodesResponse, err error) {
+	if err = requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	mfa, err := m.enabledMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifySecondFactor(ctx, m.repo, m.cipher, mfa, code, ""); err != nil {
+		return nil, err
+	}
+
+	codes, plain, err := account.NewRecoveryCodes(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", errs.ErrInternal)
+	}
+	if err = m.repo.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return &account.RecoveryCodesResponse{Codes: plain}, nil
+}
+
+// enrollmentChallenge memuat challenge pendaftaran MFA yang masih berlaku
+func (m *MFAService) enrollmentChallenge(ctx context.Context, plain string) (*account.UserToken, error) {
+	challenge, err := m.tokens.FindByHash(ctx, token.Hash(plain), account.TokenMFAEnrollment)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, fmt.Errorf("failed to get mfa enrollment challenge: %w", err)
+	}
+	if !challenge.IsUsable(time.Now()) {
+		return nil, errInvalidChallenge
+	}
+	return challenge, nil
+}
+
+func (m *MFAService) enabledMFA(ctx context.Context, userID identity.ID) (*account.UserMFA, error) {
+	mfa, err := m.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if !mfa.IsEnabled() {
+		return nil, errs.ErrNotFound
+	}
+	return mfa, nil
+}
+
+// verifySecondFactor memeriksa kode TOTP atau recovery code dan langsung
+// menandainya terpakai sehingga kode yang sama tidak bisa diputar ulang
+func verifySecondFactor(ctx context.Context, repo interfaces.IMFARepository, cipher account.ISecretCipher, mfa *account.UserMFA, code string, recoveryCode string) error {
+	var err error
+	switch {
+	case code != "":
+		step, ok, checkErr := mfa.Check(cipher, code, time.Now())
+		if checkErr != nil {
+			return fmt.Errorf("failed to read totp secret: %w", errs.ErrInternal)
+		}
+		if !ok {
+			return errInvalidMFACode
+		}
+		err = repo.ConsumeStep(ctx, mfa.UserID, step)
+	case recoveryCode != "":
+		err = repo.ConsumeRecoveryCode(ctx, mfa.UserID, account.HashRecoveryCode(recoveryCode))
+	default:
+		return errInvalidMFACode
+	}
+
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errInvalidMFACode
+		}
+		return fmt.Errorf("failed to verify mfa code: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type OIDCConfig struct {
+	// StateTTL adalah batas waktu user menyelesaikan login di provider
+	StateTTL time.Duration
+
+	// DefaultRoleID diberikan ke user yang dibuat saat login pertama kali.
+	// Nilai kosong berarti user dibuat tanpa role.
+	DefaultRoleID identity.ID
+}
+
+var DefaultOIDCConfig = OIDCConfig{
+	StateTTL: 10 * time.Minute,
+}
+
+// usernameAttempts adalah jumlah percobaan username acak sebelum menyerah
+const usernameAttempts = 5
+
+var (
+	errInvalidOIDCState  = fmt.Errorf("oidc state is invalid or has expired: %w", errs.ErrUnauthorized)
+	errInvalidOIDCNonce  = fmt.Errorf("oidc nonce does not match: %w", errs.ErrUnauthorized)
+	errUnverifiedOIDC    = fmt.Errorf("identity provider did not return a verified email: %w", errs.ErrUnauthorized)
+	errUnverifiedAccount = fmt.Errorf("an account with this email exists but its email is not verified: %w", errs.ErrConflict)
+
+	usernameInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+)
+
+type OIDCService struct {
+	providers  map[string]interfaces.IOIDCProvider
+	states     interfaces.IOIDCStateRepository
+	identities interfaces.IExternalIdentityRepository
+	users      interfaces.IUserRepository
+	auth       *AuthService
+	auditor    auditInterfaces.IAuditService
+	committer  shared.Committer
+	config     OIDCConfig
+}
+
+func NewOIDCService(providers []interfaces.IOIDCProvider, states interfaces.IOIDCStateRepository, identities interfaces.IExternalIdentityRepository, users interfaces.IUserRepository, auth *AuthService, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher, config OIDCConfig) *OIDCService {
+	byName := make(map[string]interfaces.IOIDCProvider, len(providers))
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+
+	return &OIDCService{
+		providers:  byName,
+		states:     states,
+		identities: identities,
+		users:      users,
+		auth:       auth,
+		auditor:    auditor,
+		committer:  shared.NewCommitter(tx, outbox, dispatcher),
+		config:     config,
+	}
+}
+
+/**
+ * Start stores a new state, nonce and PKCE verifier and returns the
+ * authorization URL of the provider.
+ * @param ctx context.Context
+ * @param provider string
+ * @return (string, error)
+ */
+func (o *OIDCService) Start(ctx context.Context, provider string) (authURL string, err error) {
+	client, ok := o.providers[provider]
+	if !ok {
+		return "", fmt.Errorf("oidc provider '%s' not found: %w", provider, errs.ErrNotFound)
+	}
+
+	state, plain, err := account.NewOIDCState(provider, o.config.StateTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate oidc state: %w", errs.ErrInternal)
+	}
+	if err = o.states.Create(ctx, state); err != nil {
+		return "", fmt.Errorf("failed to store oidc state: %w", err)
+	}
+
+	return client.AuthCodeURL(plain, state.Nonce, state.CodeChallenge()), nil
+}
+
+/**
+ * Callback consumes the state, exchanges the code and signs the user in.
+ * An unknown identity is linked to the user with the same verified email,
+ * or a new user is created with the default role.
+ * @param ctx context.Context
+ * @param provider string
+ * @param state string
+ * @param code string
+ * @return (*account.LoginResponse, error)
+ */
+func (o *OIDCService) Callback(ctx context.Context, provider string, state string, code string) (result *account.LoginResponse, err error) {
+	client, ok := o.providers[provider]
+	if !ok {
+		return nil, fmt.Errorf("oidc provider '%s' not found: %w", provider, errs.ErrNotFound)
+	}
+	if state == "" || code == "" {
+		return nil, errInvalidOIDCState
+	}
+
+	stored, err := o.states.Consume(ctx, token.Hash(state))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidOIDCState
+		}
+		return nil, fmt.Errorf("failed to get oidc state: %w", err)
+	}
+	if !stored.IsUsable(provider, time.Now()) {
+		return nil, errInvalidOIDCState
+	}
+
+	claims, err := client.Exchange(ctx, code, stored.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Nonce != stored.Nonce {
+		return nil, errInvalidOIDCNonce
+	}
+
+	user, err := o.resolveUser(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, errInvalidCredentials
+	}
+
+	return o.auth.complete(ctx, user)
+}
+
+func (o *OIDCService) resolveUser(ctx context.Context, provider string, claims *account.ExternalClaims) (*account.User, error) {
+	external, err := o.identities.FindByProviderSubject(ctx, provider, claims.Subject)
+	if err == nil {
+		if err := o.identities.TouchLastLogin(ctx, external.ID, time.Now()); err != nil {
+			slog.WarnContext(ctx, "failed to update external identity last login", slog.String("identity_id", external.ID.String()), slog.Any("error", err))
+		}
+
+		user, err := o.users.GetByID(ctx, external.UserID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, errInvalidCredentials
+			}
+			return nil, fmt.Errorf("failed to get user by id: %w", err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get external identity: %w", err)
+	}
+
+	// Identity baru hanya dihubungkan melalui email yang sudah diverifikasi
+	// provider, jika tidak siapa pun bisa mengklaim email orang lain
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, errUnverifiedOIDC
+	}
+
+	user, err := o.users.GetByEmail(ctx, claims.Email)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+	if user != nil {
+		return o.link(ctx, user, provider, claims)
+	}
+	return o.register(ctx, provider, claims)
+}
+
+// link menghubungkan identity ke user lokal. User yang emailnya belum
+// terverifikasi ditolak karena akun tersebut bisa saja didaftarkan orang lain
+// memakai email korban.
+func (o *OIDCService) link(ctx context.Context, user *account.User, provider string, claims *account.ExternalClaims) (*account.User, error) {
+	if !user.IsEmailVerified() {
+		return nil, errUnverifiedAccount
+	}
+
+	external := account.NewExternalIdentity(user.ID, provider, claims)
+	user.LinkIdentity(provider)
+
+	err := o.committer.Commit(ctx, user, func(ctx context.Context) error {
+		if err := o.identities.Create(ctx, external); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "external_identity", After: provider}}
+		return o.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, user.ID.String(), changes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link external identity: %w", err)
+	}
+	return user, nil
+}
+
+// register membuat user baru tanpa password, user tersebut hanya bisa login
+// melalui provider sampai ia melakukan reset password
+func (o *OIDCService) register(ctx context.Context, provider string, claims *account.ExternalClaims) (*account.User, error) {
+	username, err := o.availableUsername(ctx, claims.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = username
+	}
+
+	user := account.NewUser(name, name, username, claims.Email)
+	user.VerifyEmail(time.Now())
+	if !o.config.DefaultRoleID.IsNil() {
+		user.AssignRole(o.config.DefaultRoleID)
+	}
+	user.LinkIdentity(provider)
+	external := account.NewExternalIdentity(user.ID, provider, claims)
+
+	err = o.committer.Commit(ctx, user, func(ctx context.Context) error {
+		if err := o.users.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := o.identities.Create(ctx, external); err != nil {
+			return err
+		}
+		return o.auditor.Record(ctx, audit.ActionCreate, audit.AggregateUser, user.ID.String(), audit.Diff(nil, user))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register external user: %w", err)
+	}
+	return user, nil
+}
+
+func (o *OIDCService) availableUsername(ctx context.Context, email string) (string, error) {
+	local, _, _ := strings.Cut(email, "@")
+	base := strings.Trim(usernameInvalidChars.ReplaceAllString(strings.ToLower(local), ""), ".-_")
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for range usernameAttempts {
+		_, err := o.users.GetByUsername(ctx, candidate)
+		if errors.Is(err, errs.ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get user by username: %w", err)
+		}
+
+		suffix, err := token.Generate(4)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate username: %w", errs.ErrInternal)
+		}
+		candidate = base + "-" + strings.ToLower(usernameInvalidChars.ReplaceAllString(suffix, ""))
+	}
+	return "", fmt.Errorf("failed to find an available username: %w", errs.ErrConflict)
+}
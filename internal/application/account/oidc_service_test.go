@@ -0,0 +1,181 @@
+package account
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+type memoryStateStore struct {
+	states map[string]*account.OIDCState
+}
+
+func (m *memoryStateStore) Create(ctx context.Context, state *account.OIDCState) error {
+	m.states[state.StateHash] = state
+	return nil
+}
+
+func (m *memoryStateStore) Consume(ctx context.Context, stateHash string) (*account.OIDCState, error) {
+	state, ok := m.states[stateHash]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	delete(m.states, stateHash)
+	return state, nil
+}
+
+// fakeOIDCProvider mencatat parameter yang dikirim service ke provider
+type fakeOIDCProvider struct {
+	state, nonce, challenge string
+	verifier                string
+	claims                  *account.ExternalClaims
+}
+
+func (f *fakeOIDCProvider) Name() string {
+	return "mock"
+}
+
+func (f *fakeOIDCProvider) AuthCodeURL(state string, nonce string, codeChallenge string) string {
+	f.state, f.nonce, f.challenge = state, nonce, codeChallenge
+	return "https://idp.example/authorize"
+}
+
+func (f *fakeOIDCProvider) Exchange(ctx context.Context, code string, codeVerifier string) (*account.ExternalClaims, error) {
+	f.verifier = codeVerifier
+	return f.claims, nil
+}
+
+func TestOIDCServiceStart(t *testing.T) {
+	states := &memoryStateStore{states: map[string]*account.OIDCState{}}
+	provider := &fakeOIDCProvider{}
+	service := NewOIDCService([]interfaces.IOIDCProvider{provider}, states, nil, nil, nil, nil, nil, nil, nil, DefaultOIDCConfig)
+
+	if _, err := service.Start(context.Background(), "unknown"); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("Start(unknown) error = %v, want ErrNotFound", err)
+	}
+	if _, err := service.Start(context.Background(), "mock"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// Hanya hash state yang disimpan, nonce dan challenge berasal dari state itu
+	stored, ok := states.states[token.Hash(provider.state)]
+	if !ok {
+		t.Fatal("state is not stored by its hash")
+	}
+	sum := sha256.Sum256([]byte(stored.CodeVerifier))
+	if provider.challenge != base64.RawURLEncoding.EncodeToString(sum[:]) {
+		t.Errorf("code challenge is not the S256 of the stored verifier")
+	}
+	if provider.nonce != stored.Nonce || provider.nonce == "" {
+		t.Errorf("nonce = %q, want %q", provider.nonce, stored.Nonce)
+	}
+}
+
+func TestOIDCServiceCallbackRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		state    func(states *memoryStateStore) string
+		nonce    string
+		wantErr  error
+	}{
+		{
+			name:     "unknown provider",
+			provider: "unknown",
+			state:    func(*memoryStateStore) string { return "state" },
+			wantErr:  errs.ErrNotFound,
+		},
+		{
+			name:     "empty state",
+			provider: "mock",
+			state:    func(*memoryStateStore) string { return "" },
+			wantErr:  errInvalidOIDCState,
+		},
+		{
+			name:     "unknown state",
+			provider: "mock",
+			state:    func(*memoryStateStore) string { return "forged" },
+			wantErr:  errInvalidOIDCState,
+		},
+		{
+			name:     "expired state",
+			provider: "mock",
+			state: func(states *memoryStateStore) string {
+				state, plain, _ := account.NewOIDCState("mock", -time.Minute)
+				states.Create(context.Background(), state)
+				return plain
+			},
+			wantErr: errInvalidOIDCState,
+		},
+		{
+			name:     "state of another provider",
+			provider: "mock",
+			state: func(states *memoryStateStore) string {
+				state, plain, _ := account.NewOIDCState("other", time.Minute)
+				states.Create(context.Background(), state)
+				return plain
+			},
+			wantErr: errInvalidOIDCState,
+		},
+		{
+			name:     "nonce mismatch",
+			provider: "mock",
+			state: func(states *memoryStateStore) string {
+				state, plain, _ := account.NewOIDCState("mock", time.Minute)
+				states.Create(context.Background(), state)
+				return plain
+			},
+			nonce:   "replayed-nonce",
+			wantErr: errInvalidOIDCNonce,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			states := &memoryStateStore{states: map[string]*account.OIDCState{}}
+			provider := &fakeOIDCProvider{claims: &account.ExternalClaims{Subject: "subject-1", Nonce: tt.nonce}}
+			service := NewOIDCService([]interfaces.IOIDCProvider{provider}, states, nil, nil, nil, nil, nil, nil, nil, DefaultOIDCConfig)
+
+			state := tt.state(states)
+			_, err := service.Callback(context.Background(), tt.provider, state, "code")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Callback() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(states.states) != 0 {
+				t.Errorf("state is not consumed")
+			}
+		})
+	}
+}
+
+func TestOIDCServiceCallbackStateIsSingleUse(t *testing.T) {
+	states := &memoryStateStore{states: map[string]*account.OIDCState{}}
+	provider := &fakeOIDCProvider{}
+	service := NewOIDCService([]interfaces.IOIDCProvider{provider}, states, nil, nil, nil, nil, nil, nil, nil, DefaultOIDCConfig)
+
+	stored, plain, err := account.NewOIDCState("mock", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	states.Create(context.Background(), stored)
+	// Nonce salah menghentikan callback sebelum user dicari
+	provider.claims = &account.ExternalClaims{Subject: "subject-1", Nonce: "other"}
+
+	if _, err := service.Callback(context.Background(), "mock", plain, "code"); !errors.Is(err, errInvalidOIDCNonce) {
+		t.Fatalf("Callback() error = %v, want errInvalidOIDCNonce", err)
+	}
+	if provider.verifier != stored.CodeVerifier {
+		t.Errorf("Exchange() verifier = %q, want the stored verifier", provider.verifier)
+	}
+	if _, err := service.Callback(context.Background(), "mock", plain, "code"); !errors.Is(err, errInvalidOIDCState) {
+		t.Fatalf("second Callback() error = %v, want errInvalidOIDCState", err)
+	}
+}
@@ -0,0 +1,180 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/mail"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type PasswordResetConfig struct {
+	// ResetURL adalah halaman frontend yang menerima query parameter token
+	ResetURL string
+	TokenTTL time.Duration
+	// SendTimeout membatasi proses kirim reset yang berjalan di background
+	SendTimeout time.Duration
+}
+
+var errInvalidResetToken = errs.ValidationError{Field: "token", Message: "is invalid or has expired"}
+
+type PasswordResetService struct {
+	users     interfaces.IUserRepository
+	tokens    interfaces.IUserTokenRepository
+	mailer    mail.IMailer
+	auditor   auditInterfaces.IAuditService
+	passwords *PasswordValidator
+	hasher    account.IPasswordHasher
+	sessions  interfaces.ISessionRepository
+	committer shared.Committer
+	config    PasswordResetConfig
+}
+
+func NewPasswordResetService(users interfaces.IUserRepository, tokens interfaces.IUserTokenRepository, mailer mail.IMailer, auditor auditInterfaces.IAuditService, passwords *PasswordValidator, hasher account.IPasswordHasher, sessions interfaces.ISessionRepository, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher, config PasswordResetConfig) *PasswordResetService {
+	return &PasswordResetService{
+		users:     users,
+		tokens:    tokens,
+		mailer:    mailer,
+		auditor:   auditor,
+		passwords: passwords,
+		hasher:    hasher,
+		sessions:  sessions,
+		committer: shared.NewCommitter(tx, outbox, dispatcher),
+		config:    config,
+	}
+}
+
+/**
+ * RequestReset returns immediately and issues the reset token and email in
+ * the background, so known and unknown emails take the same time to answer.
+ * Unknown or inactive emails and mail failures are not reported to the caller.
+ * @param ctx context.Context
+ * @param email string
+ * @return error
+ */
+func (p *PasswordResetService) RequestReset(ctx context.Context, email string) (err error) {
+	runInBackground(ctx, p.config.SendTimeout, "password reset", func(ctx context.Context) error {
+		return p.sendReset(ctx, email)
+	})
+	return nil
+}
+
+func (p *PasswordResetService) sendReset(ctx context.Context, email string) error {
+	user, err := p.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user by email: %w", err)
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	resetToken, plain, err := account.NewUserToken(user.ID, account.TokenPasswordReset, p.config.TokenTTL)
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", errs.ErrInternal)
+	}
+
+	err = p.committer.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := p.tokens.InvalidateForUser(ctx, user.ID, account.TokenPasswordReset); err != nil {
+			return err
+		}
+		return p.tokens.Create(ctx, resetToken)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	message := mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the link below to reset your password. It expires in %s.\n\n%s",
+			p.config.TokenTTL, linkWithToken(p.config.ResetURL, plain)),
+	}
+	if err := p.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send password reset mail: %w", err)
+	}
+	return nil
+}
+
+/**
+ * ConfirmReset consumes the token and sets the new password.
+ * @param ctx context.Context
+ * @param plainToken string
+ * @param password string
+ * @return error
+ */
+func (p *PasswordResetService) ConfirmReset(ctx context.Context, plainToken string, password string) (err error) {
+	resetToken, err := p.tokens.FindByHash(ctx, token.Hash(plainToken), account.TokenPasswordReset)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errInvalidResetToken
+		}
+		return fmt.Errorf("failed to get reset token: %w", err)
+	}
+	if !resetToken.IsUsable(time.Now()) {
+		return errInvalidResetToken
+	}
+
+	user, err := p.users.GetByID(ctx, resetToken.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errInvalidResetToken
+		}
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	if err = p.passwords.Validate(ctx, password, user); err != nil {
+		return err
+	}
+
+	before := *user
+	if err = user.ChangePassword(p.hasher, password); err != nil {
+		return fmt.Errorf("failed to encrypt password: %w", errs.ErrInternal)
+	}
+
+	err = p.committer.Commit(ctx, user, func(ctx context.Context) error {
+		if err := p.tokens.MarkUsed(ctx, resetToken); err != nil {
+			return err
+		}
+		if err := p.users.Update(ctx, user); err != nil {
+			return err
+		}
+		if err := p.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, user.ID.String(), audit.Diff(&before, user)); err != nil {
+			return err
+		}
+		return revokeSessions(ctx, p.sessions, p.auditor, user.ID)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errInvalidResetToken
+		}
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	return nil
+}
+
+func linkWithToken(base string, plain string) string {
+	link, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(plain)
+	}
+
+	query := link.Query()
+	query.Set("token", plain)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
@@ -0,0 +1,296 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/mail"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type discardOutbox struct{}
+
+func (discardOutbox) Enqueue(ctx context.Context, events ...event.Event) error {
+	return nil
+}
+
+type discardDispatcher struct{}
+
+func (discardDispatcher) Dispatch(ctx context.Context, events ...event.Event) {}
+
+type discardAuditor struct{}
+
+func (discardAuditor) Record(ctx context.Context, action audit.Action, aggregateType string, aggregateID string, changes []audit.Change) error {
+	return nil
+}
+
+func (discardAuditor) FindAll(ctx context.Context, filter *audit.Filter) ([]*audit.Entry, int64, error) {
+	return nil, 0, nil
+}
+
+type noSessions struct {
+	interfaces.ISessionRepository
+}
+
+func (noSessions) RevokeAllForUser(ctx context.Context, userID identity.ID, at time.Time) (int64, error) {
+	return 0, nil
+}
+
+// memoryTokens menyimpan token berdasarkan hash seperti UserTokenRepository
+type memoryTokens struct {
+	items map[string]*account.UserToken
+}
+
+func newMemoryTokens(items ...*account.UserToken) *memoryTokens {
+	tokens := &memoryTokens{items: make(map[string]*account.UserToken)}
+	for _, item := range items {
+		tokens.items[item.TokenHash] = item
+	}
+	return tokens
+}
+
+func (m *memoryTokens) Create(ctx context.Context, userToken *account.UserToken) error {
+	m.items[userToken.TokenHash] = userToken
+	return nil
+}
+
+func (m *memoryTokens) FindByHash(ctx context.Context, hash string, purpose account.TokenPurpose) (*account.UserToken, error) {
+	userToken, ok := m.items[hash]
+	if !ok || userToken.Purpose != purpose {
+		return nil, errs.ErrNotFound
+	}
+	return userToken, nil
+}
+
+func (m *memoryTokens) MarkUsed(ctx context.Context, userToken *account.UserToken) error {
+	if userToken.UsedAt != nil {
+		return errs.ErrNotFound
+	}
+	now := time.Now()
+	userToken.UsedAt = &now
+	return nil
+}
+
+func (m *memoryTokens) InvalidateForUser(ctx context.Context, userID identity.ID, purpose account.TokenPurpose) error {
+	for _, userToken := range m.items {
+		if userToken.UserID == userID && userToken.Purpose == purpose && userToken.UsedAt == nil {
+			_ = m.MarkUsed(ctx, userToken)
+		}
+	}
+	return nil
+}
+
+// usable mengembalikan token milik user yang masih bisa dipakai
+func (m *memoryTokens) usable(userID identity.ID, purpose account.TokenPurpose) []*account.UserToken {
+	result := make([]*account.UserToken, 0)
+	for _, userToken := range m.items {
+		if userToken.UserID == userID && userToken.Purpose == purpose && userToken.IsUsable(time.Now()) {
+			result = append(result, userToken)
+		}
+	}
+	return result
+}
+
+type recordingMailer struct {
+	messages []mail.Message
+}
+
+func (r *recordingMailer) Send(ctx context.Context, message mail.Message) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+// tokenFromMail mengambil query parameter token dari link di email
+func tokenFromMail(t *testing.T, message mail.Message) string {
+	t.Helper()
+
+	lines := strings.Split(message.Body, "\n")
+	link, err := url.Parse(lines[len(lines)-1])
+	if err != nil {
+		t.Fatalf("mail body has no link: %q", message.Body)
+	}
+	return link.Query().Get("token")
+}
+
+// accountUsers adalah repository user dengan satu user
+type accountUsers struct {
+	interfaces.IUserRepository
+	user    *account.User
+	updates int
+}
+
+func (a *accountUsers) GetByEmail(ctx context.Context, email string) (*account.User, error) {
+	if a.user == nil || a.user.Email != email {
+		return nil, errs.ErrNotFound
+	}
+	return a.user, nil
+}
+
+func (a *accountUsers) GetByID(ctx context.Context, id identity.ID) (*account.User, error) {
+	if a.user == nil || a.user.ID != id {
+		return nil, errs.ErrNotFound
+	}
+	return a.user, nil
+}
+
+func (a *accountUsers) Update(ctx context.Context, user *account.User) error {
+	a.updates++
+	return nil
+}
+
+func newPasswordResetService(users interfaces.IUserRepository, tokens interfaces.IUserTokenRepository, mailer mail.IMailer) *PasswordResetService {
+	return NewPasswordResetService(users, tokens, mailer, discardAuditor{}, NewPasswordValidator(account.DefaultPasswordPolicy, nil), upgradingHasher{}, noSessions{}, inlineTx{}, discardOutbox{}, discardDispatcher{},
+		PasswordResetConfig{ResetURL: "https://app.example.com/reset?lang=en", TokenTTL: time.Hour})
+}
+
+func TestPasswordResetServiceSendReset(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		inactive bool
+		wantMail bool
+	}{
+		{name: "known email", email: "jane@example.com", wantMail: true},
+		{name: "unknown email", email: "nobody@example.com"},
+		{name: "inactive user", email: "jane@example.com", inactive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Email: "jane@example.com", IsActive: !tt.inactive}
+			previous, _, _ := account.NewUserToken(user.ID, account.TokenPasswordReset, time.Hour)
+			tokens := newMemoryTokens(previous)
+			mailer := &recordingMailer{}
+			service := newPasswordResetService(&accountUsers{user: user}, tokens, mailer)
+
+			// email yang tidak dikenal tidak boleh menghasilkan error yang bisa dibedakan
+			if err := service.sendReset(context.Background(), tt.email); err != nil {
+				t.Fatalf("sendReset() error = %v", err)
+			}
+			if !tt.wantMail {
+				if len(mailer.messages) != 0 {
+					t.Errorf("sent %d mails, want none", len(mailer.messages))
+				}
+				return
+			}
+
+			if len(mailer.messages) != 1 || mailer.messages[0].To != user.Email {
+				t.Fatalf("mails = %+v, want one to %s", mailer.messages, user.Email)
+			}
+			plain := tokenFromMail(t, mailer.messages[0])
+			usable := tokens.usable(user.ID, account.TokenPasswordReset)
+			if len(usable) != 1 || usable[0].TokenHash != token.Hash(plain) {
+				t.Errorf("usable tokens = %d, want only the mailed token", len(usable))
+			}
+			if previous.IsUsable(time.Now()) {
+				t.Error("previous reset token is still usable")
+			}
+		})
+	}
+}
+
+func TestPasswordResetServiceConfirmReset(t *testing.T) {
+	const newPassword = "N3w-Password!"
+	used := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		purpose account.TokenPurpose
+		expires time.Duration
+		usedAt  *time.Time
+		unknown bool
+		wantErr error
+	}{
+		{name: "valid token", purpose: account.TokenPasswordReset, expires: time.Hour},
+		{name: "unknown token", purpose: account.TokenPasswordReset, expires: time.Hour, unknown: true, wantErr: errInvalidResetToken},
+		{name: "expired token", purpose: account.TokenPasswordReset, expires: -time.Minute, wantErr: errInvalidResetToken},
+		{name: "used token", purpose: account.TokenPasswordReset, expires: time.Hour, usedAt: &used, wantErr: errInvalidResetToken},
+		{name: "verification token", purpose: account.TokenEmailVerification, expires: time.Hour, wantErr: errInvalidResetToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Username: "jane", Email: "jane@example.com", IsActive: true, Password: account.NewPasswordHash("new:old")}
+			resetToken, plain, _ := account.NewUserToken(user.ID, tt.purpose, tt.expires)
+			resetToken.UsedAt = tt.usedAt
+			tokens := newMemoryTokens(resetToken)
+			if tt.unknown {
+				tokens = newMemoryTokens()
+			}
+			users := &accountUsers{user: user}
+			service := newPasswordResetService(users, tokens, &recordingMailer{})
+
+			err := service.ConfirmReset(context.Background(), plain, newPassword)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ConfirmReset() error = %v, want %v", err, tt.wantErr)
+				}
+				if users.updates != 0 || user.Password.Encoded() != "new:old" {
+					t.Error("password changed with a rejected token")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfirmReset() error = %v", err)
+			}
+			if user.Password.Encoded() != "new:"+newPassword || users.updates != 1 {
+				t.Errorf("password = %q after %d updates, want the new password stored once", user.Password.Encoded(), users.updates)
+			}
+
+			// token hanya bisa dipakai sekali
+			if err := service.ConfirmReset(context.Background(), plain, "An0ther-Password!"); !errors.Is(err, errInvalidResetToken) {
+				t.Errorf("second ConfirmReset() error = %v, want errInvalidResetToken", err)
+			}
+		})
+	}
+}
+
+func TestPasswordResetServiceConfirmResetValidatesPassword(t *testing.T) {
+	user := &account.User{ID: identity.New(), Username: "jane", Email: "jane@example.com", IsActive: true}
+	resetToken, plain, _ := account.NewUserToken(user.ID, account.TokenPasswordReset, time.Hour)
+	service := newPasswordResetService(&accountUsers{user: user}, newMemoryTokens(resetToken), &recordingMailer{})
+
+	if err := service.ConfirmReset(context.Background(), plain, "short"); !errs.IsValidationError(err) {
+		t.Fatalf("ConfirmReset() error = %v, want a validation error", err)
+	}
+	// password yang ditolak tidak menghabiskan token
+	if !resetToken.IsUsable(time.Now()) {
+		t.Error("token was consumed by a rejected password")
+	}
+}
+
+func TestLinkWithToken(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		want string
+	}{
+		{name: "plain url", base: "https://app.example.com/reset", want: "https://app.example.com/reset?token=a+b%2F"},
+		{name: "keeps existing query", base: "https://app.example.com/reset?lang=en", want: "https://app.example.com/reset?lang=en&token=a+b%2F"},
+		{name: "unparsable url", base: "://bad", want: "://bad?token=a+b%2F"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := linkWithToken(tt.base, "a b/"); got != tt.want {
+				t.Errorf("linkWithToken() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+var errBreachedPassword = errs.ValidationError{Field: "password", Message: "has appeared in a data breach, choose another one"}
+
+// PasswordValidator menggabungkan password policy dan pengecekan password bocor
+type PasswordValidator struct {
+	policy   account.PasswordPolicy
+	breached interfaces.IBreachedPasswordChecker
+}
+
+// NewPasswordValidator menerima breached nil untuk menonaktifkan pengecekan kebocoran
+func NewPasswordValidator(policy account.PasswordPolicy, breached interfaces.IBreachedPasswordChecker) *PasswordValidator {
+	return &PasswordValidator{
+		policy:   policy,
+		breached: breached,
+	}
+}
+
+/**
+ * Validate checks a new password of the given user.
+ * @param ctx context.Context
+ * @param password string
+ * @param user *account.User
+ * @return error
+ */
+func (v *PasswordValidator) Validate(ctx context.Context, password string, user *account.User) error {
+	if err := v.policy.Validate(password, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	if v.breached == nil {
+		return nil
+	}
+	breached, err := v.breached.IsBreached(ctx, password)
+	if err != nil {
+		return fmt.Errorf("failed to check breached password: %w", err)
+	}
+	if breached {
+		return errBreachedPassword
+	}
+	return nil
+}
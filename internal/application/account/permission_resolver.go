@@ -0,0 +1,88 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+type PermissionResolver struct {
+	roles interfaces.IRoleRepository
+	users interfaces.IUserRepository
+}
+
+func NewPermissionResolver(roles interfaces.IRoleRepository, users interfaces.IUserRepository) *PermissionResolver {
+	return &PermissionResolver{
+		roles: roles,
+		users: users,
+	}
+}
+
+func (p *PermissionResolver) UserPermissions(ctx context.Context, user *account.User) (result []string, err error) {
+	ids := make([]identity.ID, 0, 1)
+	if !user.Role.IsNil() {
+		ids = append(ids, user.Role)
+	}
+
+	assignments, err := p.roles.FindUserRoles(ctx, user.ID.String(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		if !slices.Contains(ids, assignment.RoleID) {
+			ids = append(ids, assignment.RoleID)
+		}
+	}
+
+	roles := make([]*account.Role, 0, len(ids))
+	for _, id := range ids {
+		chain, err := p.roles.FindChain(ctx, id.String())
+		if err != nil {
+			// role yang sudah dihapus tidak memberi permission apa pun
+			if errors.Is(err, errs.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get role: %w", err)
+		}
+		roles = append(roles, chain...)
+	}
+
+	return account.EffectivePermissions(roles), nil
+}
+
+// ActorSubject membangun subject dari actor pada context. ok bernilai false
+// bila tidak ada actor, yaitu pemanggilan internal yang tidak melalui HTTP.
+func (p *PermissionResolver) ActorSubject(ctx context.Context) (subject policy.Subject, ok bool, err error) {
+	actorID, ok := requestctx.ActorID(ctx)
+	if !ok {
+		return policy.Subject{}, false, nil
+	}
+
+	actor, err := p.users.GetByID(ctx, actorID)
+	if err != nil {
+		return policy.Subject{}, true, fmt.Errorf("failed to get actor: %w", err)
+	}
+
+	permissions, err := p.UserPermissions(ctx, actor)
+	if err != nil {
+		return policy.Subject{}, true, err
+	}
+
+	// API key dan token OAuth hanya membawa sebagian permission user
+	if scopes, restricted := requestctx.Scopes(ctx); restricted {
+		permissions = slices.DeleteFunc(permissions, func(permission string) bool {
+			return !slices.Contains(scopes, permission)
+		})
+	}
+
+	return policy.Subject{ID: actor.ID, Permissions: permissions, Attributes: actor.Attributes}, true, nil
+}
@@ -0,0 +1,203 @@
+package account
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// assignedRoles menyimpan role dan assignment user_roles di memori
+type assignedRoles struct {
+	interfaces.IRoleRepository
+	roles       map[identity.ID]*account.Role
+	assignments []*account.UserRole
+}
+
+func (a *assignedRoles) FindChain(ctx context.Context, id string) ([]*account.Role, error) {
+	current, err := identity.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := a.roles[current]; !ok {
+		return nil, errs.ErrNotFound
+	}
+
+	chain := make([]*account.Role, 0)
+	for role, ok := a.roles[current]; ok; role, ok = a.roles[current] {
+		chain = append(chain, role)
+		current = role.ParentID
+	}
+	return chain, nil
+}
+
+func (a *assignedRoles) FindUserRoles(ctx context.Context, userID string, now time.Time) ([]*account.UserRole, error) {
+	result := make([]*account.UserRole, 0)
+	for _, assignment := range a.assignments {
+		if assignment.UserID.String() == userID && assignment.IsActive(now) {
+			result = append(result, assignment)
+		}
+	}
+	return result, nil
+}
+
+func (a *assignedRoles) FindManyByID(ctx context.Context, ids []identity.ID) (*[]account.Role, error) {
+	result := make([]account.Role, 0)
+	for _, id := range ids {
+		if role, ok := a.roles[id]; ok {
+			result = append(result, *role)
+		}
+	}
+	return &result, nil
+}
+
+// roleFixture berisi role admin sebagai parent editor, serta viewer
+func roleFixture() (roles map[identity.ID]*account.Role, admin, editor, viewer identity.ID) {
+	admin, editor, viewer = identity.New(), identity.New(), identity.New()
+	roles = map[identity.ID]*account.Role{
+		admin:  {ID: admin, Name: "admin", Permissions: []string{account.PermissionUsersDelete}},
+		editor: {ID: editor, Name: "editor", ParentID: admin, Permissions: []string{account.PermissionUsersWrite, account.PermissionUsersRead}},
+		viewer: {ID: viewer, Name: "viewer", Permissions: []string{account.PermissionUsersRead, account.PermissionRolesRead}},
+	}
+	return roles, admin, editor, viewer
+}
+
+func TestPermissionResolverUserPermissions(t *testing.T) {
+	roles, _, editor, viewer := roleFixture()
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		primary     identity.ID
+		assignments func(userID identity.ID) []*account.UserRole
+		want        []string
+	}{
+		{name: "no role", want: []string{}},
+		{
+			name:    "primary role with its parent",
+			primary: editor,
+			want:    []string{account.PermissionUsersDelete, account.PermissionUsersRead, account.PermissionUsersWrite},
+		},
+		{
+			name: "assignments only",
+			assignments: func(userID identity.ID) []*account.UserRole {
+				return []*account.UserRole{account.NewUserRole(userID, viewer, identity.New(), nil)}
+			},
+			want: []string{account.PermissionRolesRead, account.PermissionUsersRead},
+		},
+		{
+			name:    "primary and assignments are merged without duplicates",
+			primary: editor,
+			assignments: func(userID identity.ID) []*account.UserRole {
+				return []*account.UserRole{
+					account.NewUserRole(userID, viewer, identity.New(), nil),
+					account.NewUserRole(userID, editor, identity.New(), nil),
+				}
+			},
+			want: []string{account.PermissionRolesRead, account.PermissionUsersDelete, account.PermissionUsersRead, account.PermissionUsersWrite},
+		},
+		{
+			name: "expired assignment is ignored",
+			assignments: func(userID identity.ID) []*account.UserRole {
+				return []*account.UserRole{account.NewUserRole(userID, viewer, identity.New(), &past)}
+			},
+			want: []string{},
+		},
+		{
+			name: "deleted role is ignored",
+			assignments: func(userID identity.ID) []*account.UserRole {
+				return []*account.UserRole{
+					account.NewUserRole(userID, identity.New(), identity.New(), nil),
+					account.NewUserRole(userID, viewer, identity.New(), nil),
+				}
+			},
+			want: []string{account.PermissionRolesRead, account.PermissionUsersRead},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Role: tt.primary}
+			repo := &assignedRoles{roles: roles}
+			if tt.assignments != nil {
+				repo.assignments = tt.assignments(user.ID)
+			}
+
+			got, err := NewPermissionResolver(repo, nil).UserPermissions(context.Background(), user)
+			if err != nil {
+				t.Fatalf("UserPermissions() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("UserPermissions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// actorUsers mengembalikan actor dengan ID yang diminta
+type actorUsers struct {
+	interfaces.IUserRepository
+	actor *account.User
+}
+
+func (a actorUsers) GetByID(ctx context.Context, id identity.ID) (*account.User, error) {
+	if id != a.actor.ID {
+		return nil, errs.ErrNotFound
+	}
+	return a.actor, nil
+}
+
+func TestPermissionResolverActorSubject(t *testing.T) {
+	roles, _, _, viewer := roleFixture()
+	actor := &account.User{ID: identity.New(), Role: viewer, Attributes: map[string]string{account.AttributeDepartment: "sales"}}
+	resolver := NewPermissionResolver(&assignedRoles{roles: roles}, actorUsers{actor: actor})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		wantOK bool
+		want   []string
+	}{
+		{name: "no actor", ctx: context.Background()},
+		{
+			name:   "session actor",
+			ctx:    requestctx.WithActorID(context.Background(), actor.ID),
+			wantOK: true,
+			want:   []string{account.PermissionRolesRead, account.PermissionUsersRead},
+		},
+		{
+			// scope tidak bisa menambah permission yang tidak dimiliki user
+			name:   "scoped token",
+			ctx:    requestctx.WithScopes(requestctx.WithActorID(context.Background(), actor.ID), []string{account.PermissionUsersRead, account.PermissionUsersWrite}),
+			wantOK: true,
+			want:   []string{account.PermissionUsersRead},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, ok, err := resolver.ActorSubject(tt.ctx)
+			if err != nil {
+				t.Fatalf("ActorSubject() error = %v", err)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("ActorSubject() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if subject.ID != actor.ID || subject.Attributes[account.AttributeDepartment] != "sales" {
+				t.Errorf("subject = %+v, want the actor with its attributes", subject)
+			}
+			if !slices.Equal(subject.Permissions, tt.want) {
+				t.Errorf("permissions = %v, want %v", subject.Permissions, tt.want)
+			}
+		})
+	}
+}
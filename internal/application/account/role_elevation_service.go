@@ -0,0 +1,248 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type RoleElevationConfig struct {
+	MaxDuration time.Duration
+	// RequireApproval mewajibkan persetujuan admin kedua untuk semua elevation.
+	// Default aktif, menonaktifkannya membuat pemegang roles:elevate bisa
+	// langsung memberi role apa pun.
+	RequireApproval bool
+}
+
+func DefaultRoleElevationConfig() RoleElevationConfig {
+	return RoleElevationConfig{
+		MaxDuration:     8 * time.Hour,
+		RequireApproval: true,
+	}
+}
+
+type RoleElevationService struct {
+	repo      interfaces.IRoleElevationRepository
+	roles     interfaces.IRoleService
+	users     interfaces.IUserRepository
+	resolver  interfaces.IPermissionResolver
+	auditor   auditInterfaces.IAuditService
+	committer shared.Committer
+	config    RoleElevationConfig
+}
+
+func NewRoleElevationService(repo interfaces.IRoleElevationRepository, roles interfaces.IRoleService, users interfaces.IUserRepository, resolver interfaces.IPermissionResolver, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher, config RoleElevationConfig) *RoleElevationService {
+	return &RoleElevationService{
+		repo:      repo,
+		roles:     roles,
+		users:     users,
+		resolver:  resolver,
+		auditor:   auditor,
+		committer: shared.NewCommitter(tx, outbox, dispatcher),
+		config:    config,
+	}
+}
+
+func (r *RoleElevationService) Request(ctx context.Context, userID identity.ID, payload *account.RequestRoleElevationRequest) (result *account.RoleElevationResponse, err error) {
+	requester, err := authorizeActor(ctx, r.resolver, account.PermissionRolesElevate)
+	if err != nil {
+		return nil, err
+	}
+	// Tanpa peminta, aturan admin kedua pada Approve tidak bisa ditegakkan
+	if requester.ID.IsNil() {
+		return nil, errActorRequired
+	}
+
+	duration := time.Duration(payload.DurationSeconds) * time.Second
+	if duration <= 0 {
+		return nil, errs.ValidationError{Field: "duration_seconds", Message: "must be positive"}
+	}
+	if duration > r.config.MaxDuration {
+		return nil, errs.ValidationError{Field: "duration_seconds", Message: fmt.Sprintf("must not exceed %d", int64(r.config.MaxDuration/time.Second))}
+	}
+	reason := strings.TrimSpace(payload.Reason)
+	if reason == "" {
+		return nil, errs.ValidationError{Field: "reason", Message: "is required"}
+	}
+
+	if _, err := r.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	// peminta cukup memiliki roles:elevate, pencarian role dilakukan sebagai sistem
+	if _, err := r.roles.FindById(requestctx.WithSystemActor(ctx), payload.RoleID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := r.heldUntil(ctx, userID, payload.RoleID); err != nil {
+		return nil, err
+	}
+
+	elevation := account.NewRoleElevation(userID, payload.RoleID, requester.ID, duration, reason)
+
+	// tanpa kewajiban approval elevation langsung aktif
+	approve := !r.config.RequireApproval && !payload.RequireApproval
+	if approve {
+		if err := elevation.Approve(identity.ID{}, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	err = r.committer.Commit(ctx, elevation, func(ctx context.Context) error {
+		if err := r.repo.Create(ctx, elevation); err != nil {
+			return err
+		}
+		if err := r.auditor.Record(ctx, audit.ActionRequest, audit.AggregateElevation, elevation.ID.String(), audit.Diff(nil, elevation)); err != nil {
+			return err
+		}
+		if approve {
+			return r.grant(ctx, elevation)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return elevation.ToRoleElevationResponse(), nil
+}
+
+func (r *RoleElevationService) Approve(ctx context.Context, id identity.ID) (result *account.RoleElevationResponse, err error) {
+	approver, err := authorizeActor(ctx, r.resolver, account.PermissionRolesApprove)
+	if err != nil {
+		return nil, err
+	}
+	// ID kosong berarti approval otomatis, actor harus selalu tercatat
+	if approver.ID.IsNil() {
+		return nil, errActorRequired
+	}
+	approverID := approver.ID
+
+	elevation, err := r.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *elevation
+
+	if err := elevation.Approve(approverID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	err = r.committer.Commit(ctx, elevation, func(ctx context.Context) error {
+		if err := r.repo.Update(ctx, elevation); err != nil {
+			return err
+		}
+		if err := r.auditor.Record(ctx, audit.ActionApprove, audit.AggregateElevation, elevation.ID.String(), audit.Diff(&before, elevation)); err != nil {
+			return err
+		}
+		return r.grant(ctx, elevation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return elevation.ToRoleElevationResponse(), nil
+}
+
+func (r *RoleElevationService) Reject(ctx context.Context, id identity.ID) (result *account.RoleElevationResponse, err error) {
+	approver, err := authorizeActor(ctx, r.resolver, account.PermissionRolesApprove)
+	if err != nil {
+		return nil, err
+	}
+	if approver.ID.IsNil() {
+		return nil, errActorRequired
+	}
+	approverID := approver.ID
+
+	elevation, err := r.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *elevation
+
+	if err := elevation.Reject(approverID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	err = r.committer.Commit(ctx, elevation, func(ctx context.Context) error {
+		if err := r.repo.Update(ctx, elevation); err != nil {
+			return err
+		}
+		return r.auditor.Record(ctx, audit.ActionReject, audit.AggregateElevation, elevation.ID.String(), audit.Diff(&before, elevation))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return elevation.ToRoleElevationResponse(), nil
+}
+
+func (r *RoleElevationService) FindByID(ctx context.Context, id identity.ID) (result *account.RoleElevationResponse, err error) {
+	if _, err = authorizeActor(ctx, r.resolver, account.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+
+	elevation, err := r.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return elevation.ToRoleElevationResponse(), nil
+}
+
+func (r *RoleElevationService) FindAll(ctx context.Context, filter *account.RoleElevationFilter) (result []*account.RoleElevationResponse, totalItems int64, err error) {
+	if _, err = authorizeActor(ctx, r.resolver, account.PermissionRolesRead); err != nil {
+		return nil, 0, err
+	}
+
+	elevations, totalItems, err := r.repo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result = make([]*account.RoleElevationResponse, 0, len(elevations))
+	for _, elevation := range elevations {
+		result = append(result, elevation.ToRoleElevationResponse())
+	}
+	return result, totalItems, nil
+}
+
+// grant memberikan role lewat RoleService.GrantUser dengan masa berlaku elevation.
+// Otorisasi sudah dilakukan Request atau Approve, jadi roles:assign tidak diperlukan.
+func (r *RoleElevationService) grant(ctx context.Context, elevation *account.RoleElevation) error {
+	current, err := r.heldUntil(ctx, elevation.UserID, elevation.RoleID)
+	if err != nil {
+		return err
+	}
+	// assignment yang berlaku lebih lama tidak diperpendek
+	if current != nil && !current.Before(*elevation.ExpiresAt) {
+		return nil
+	}
+	return r.roles.GrantUser(ctx, elevation.UserID, elevation.RoleID, elevation.ExpiresAt)
+}
+
+// heldUntil mengembalikan masa berlaku role yang sudah dimiliki user, nil bila
+// belum dimiliki, dan ErrConflict bila dimiliki permanen
+func (r *RoleElevationService) heldUntil(ctx context.Context, userID, roleID identity.ID) (*time.Time, error) {
+	roles, err := r.roles.FindUserRoles(requestctx.WithSystemActor(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.Role.ID != roleID {
+			continue
+		}
+		if role.Primary || role.ExpiresAt == nil {
+			return nil, fmt.Errorf("user already holds role '%s' permanently: %w", roleID, errs.ErrConflict)
+		}
+		return role.ExpiresAt, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,144 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// pendingElevations hanya menyediakan FindByID, pengujian berhenti sebelum
+// elevation disimpan
+type pendingElevations struct {
+	interfaces.IRoleElevationRepository
+	elevation *account.RoleElevation
+}
+
+func (p pendingElevations) FindByID(ctx context.Context, id identity.ID) (*account.RoleElevation, error) {
+	return p.elevation, nil
+}
+
+func TestDefaultRoleElevationConfigRequiresApproval(t *testing.T) {
+	if !DefaultRoleElevationConfig().RequireApproval {
+		t.Fatal("DefaultRoleElevationConfig().RequireApproval = false, want true")
+	}
+}
+
+func TestRoleElevationServiceAuthorization(t *testing.T) {
+	requester, grantee := identity.New(), identity.New()
+	elevation := account.NewRoleElevation(grantee, identity.New(), requester, time.Hour, "incident")
+	payload := &account.RequestRoleElevationRequest{RoleID: identity.New(), DurationSeconds: 60, Reason: "incident"}
+
+	tests := []struct {
+		name    string
+		system  bool
+		subject *policy.Subject
+		call    func(ctx context.Context, service *RoleElevationService) error
+		wantErr error
+	}{
+		{
+			name: "request without actor",
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Request(ctx, grantee, payload)
+				return err
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name:   "request as system actor",
+			system: true,
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Request(ctx, grantee, payload)
+				return err
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name:    "request without elevate",
+			subject: &policy.Subject{ID: requester, Permissions: []string{account.PermissionRolesApprove}},
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Request(ctx, grantee, payload)
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name: "approve without actor",
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Approve(ctx, elevation.ID)
+				return err
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name:    "approve without permission",
+			subject: &policy.Subject{ID: identity.New(), Permissions: []string{account.PermissionRolesElevate}},
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Approve(ctx, elevation.ID)
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "approve own request",
+			subject: &policy.Subject{ID: requester, Permissions: []string{account.PermissionRolesApprove}},
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Approve(ctx, elevation.ID)
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "approve as grantee",
+			subject: &policy.Subject{ID: grantee, Permissions: []string{account.PermissionRolesApprove}},
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Approve(ctx, elevation.ID)
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "reject without permission",
+			subject: &policy.Subject{ID: identity.New()},
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, err := service.Reject(ctx, elevation.ID)
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name: "list without actor",
+			call: func(ctx context.Context, service *RoleElevationService) error {
+				_, _, err := service.FindAll(ctx, &account.RoleElevationFilter{})
+				return err
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending := *elevation
+			service := &RoleElevationService{
+				repo:     pendingElevations{elevation: &pending},
+				resolver: fakeResolver{subject: tt.subject},
+				config:   DefaultRoleElevationConfig(),
+			}
+
+			ctx := context.Background()
+			if tt.system {
+				ctx = requestctx.WithSystemActor(ctx)
+			}
+			if err := tt.call(ctx, service); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type RoleExpirySweeperConfig struct {
+	BatchSize    int
+	PollInterval time.Duration
+}
+
+func DefaultRoleExpirySweeperConfig() RoleExpirySweeperConfig {
+	return RoleExpirySweeperConfig{
+		BatchSize:    100,
+		PollInterval: time.Minute,
+	}
+}
+
+// RoleExpirySweeper mencabut assignment role yang kedaluwarsa dan menandai
+// elevation yang habis masa berlakunya. Permission sudah tidak berlaku sejak
+// expires_at lewat; sweeper membersihkan data dan mencatat audit serta event.
+type RoleExpirySweeper struct {
+	roles      interfaces.IRoleRepository
+	elevations interfaces.IRoleElevationRepository
+	auditor    auditInterfaces.IAuditService
+	tx         transaction.IManager
+	outbox     outboxInterfaces.IOutboxService
+	dispatcher event.IDispatcher
+	config     RoleExpirySweeperConfig
+}
+
+func NewRoleExpirySweeper(roles interfaces.IRoleRepository, elevations interfaces.IRoleElevationRepository, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher, config RoleExpirySweeperConfig) *RoleExpirySweeper {
+	return &RoleExpirySweeper{
+		roles:      roles,
+		elevations: elevations,
+		auditor:    auditor,
+		tx:         tx,
+		outbox:     outbox,
+		dispatcher: dispatcher,
+		config:     config,
+	}
+}
+
+/**
+ * Run sweeps expired role grants until ctx is cancelled.
+ * @param ctx context.Context
+ */
+func (s *RoleExpirySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(ctx); err != nil {
+			slog.ErrorContext(ctx, "role expiry sweep failed", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/**
+ * Sweep revokes one batch of expired role assignments and expires the
+ * matching elevations.
+ * @param ctx context.Context
+ * @return (int, error) number of assignments revoked
+ */
+func (s *RoleExpirySweeper) Sweep(ctx context.Context) (revoked int, err error) {
+	var events []event.Event
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		assignments, err := s.roles.FindExpiredAssignments(ctx, now, s.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, assignment := range assignments {
+			if err := s.roles.DeleteExpiredAssignment(ctx, assignment, now); err != nil {
+				// sudah diperpanjang atau dicabut proses lain
+				if errors.Is(err, errs.ErrNotFound) {
+					continue
+				}
+				return err
+			}
+
+			changes := []audit.Change{
+				{Field: "role_id", Before: assignment.RoleID},
+				{Field: "expires_at", Before: assignment.ExpiresAt},
+			}
+			// sweeper berjalan lintas tenant, audit dicatat di tenant pemilik user
+			tenantCtx := requestctx.WithTenantID(ctx, assignment.TenantID)
+			if err := s.auditor.Record(tenantCtx, audit.ActionExpireRole, audit.AggregateUser, assignment.UserID.String(), changes); err != nil {
+				return err
+			}
+
+			assignment.Expire()
+			events = append(events, assignment.PullEvents()...)
+			revoked++
+		}
+
+		elevations, err := s.elevations.FindExpired(ctx, now, s.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, elevation := range elevations {
+			before := *elevation
+			elevation.Expire(now)
+
+			tenantCtx := requestctx.WithTenantID(ctx, elevation.TenantID)
+			if err := s.elevations.Update(tenantCtx, elevation); err != nil {
+				return err
+			}
+			if err := s.auditor.Record(tenantCtx, audit.ActionExpire, audit.AggregateElevation, elevation.ID.String(), audit.Diff(&before, elevation)); err != nil {
+				return err
+			}
+			events = append(events, elevation.PullEvents()...)
+		}
+
+		return s.outbox.Enqueue(ctx, events...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.dispatcher.Dispatch(ctx, events...)
+	return revoked, nil
+}
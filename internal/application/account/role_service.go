@@ -2,98 +2,369 @@ package account
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
-	"go.mongodb.org/mongo-driver/v2/bson"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
 )
 
 type RoleService struct {
-	repo interfaces.IRoleRepository
+	repo      interfaces.IRoleRepository
+	users     interfaces.IUserRepository
+	catalog   interfaces.IPermissionCatalog
+	resolver  interfaces.IPermissionResolver
+	auditor   auditInterfaces.IAuditService
+	committer shared.Committer
 }
 
-func NewRoleService(repo interfaces.IRoleRepository) *RoleService {
+func NewRoleService(repo interfaces.IRoleRepository, users interfaces.IUserRepository, catalog interfaces.IPermissionCatalog, resolver interfaces.IPermissionResolver, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher) *RoleService {
 	return &RoleService{
-		repo: repo,
+		repo:      repo,
+		users:     users,
+		catalog:   catalog,
+		resolver:  resolver,
+		auditor:   auditor,
+		committer: shared.NewCommitter(tx, outbox, dispatcher),
 	}
 }
 
-func (r *RoleService) Create(ctx context.Context, role *account.Role) (err error) {
-	payload := account.Role{
-		Name:        role.Name,
-		Permissions: role.Permissions,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+func (r *RoleService) Create(ctx context.Context, role *account.CreateRoleRequest) (err error) {
+	if _, err := authorizeActor(ctx, r.resolver, account.PermissionRolesWrite); err != nil {
+		return err
+	}
+	if err := r.catalog.Validate(role.Permissions); err != nil {
+		return err
 	}
 
-	if err := r.repo.Create(ctx, &payload); err != nil {
+	payload := account.NewRole(role.Name, role.ParentID, role.Permissions)
+	if err := r.validateParent(ctx, payload.ID, role.ParentID); err != nil {
 		return err
 	}
 
-	return nil
+	return r.committer.Commit(ctx, payload, func(ctx context.Context) error {
+		if err := r.repo.Create(ctx, payload); err != nil {
+			return err
+		}
+		return r.auditor.Record(ctx, audit.ActionCreate, audit.AggregateRole, payload.ID.String(), audit.Diff(nil, payload))
+	})
 }
 
-func (r *RoleService) FindById(ctx context.Context, id string) (result *account.Role, err error) {
+func (r *RoleService) FindById(ctx context.Context, id string) (result *account.RoleResponse, err error) {
+	if _, err = authorizeActor(ctx, r.resolver, account.PermissionRolesRead); err != nil {
+		return &account.RoleResponse{}, err
+	}
+
 	role, err := r.repo.FindById(ctx, id)
 	if err != nil {
-		return &account.Role{}, err
+		return &account.RoleResponse{}, err
 	}
-	return role, err
+	return role.ToRoleResponse(), err
 }
 
-func (r *RoleService) FindManyByID(ctx context.Context, ids []bson.ObjectID) (result *[]account.Role, err error) {
+func (r *RoleService) FindManyByID(ctx context.Context, ids []identity.ID) (result *[]account.RoleResponse, err error) {
+	if _, err = authorizeActor(ctx, r.resolver, account.PermissionRolesRead); err != nil {
+		return &[]account.RoleResponse{}, err
+	}
+
 	roles, err := r.repo.FindManyByID(ctx, ids)
 	if err != nil {
-		return &[]account.Role{}, err
+		return &[]account.RoleResponse{}, err
 	}
 
-	return roles, nil
+	return toRoleResponses(*roles), nil
 }
 
-func (r *RoleService) FindAll(ctx context.Context, filter *model.PaginationFilter) (result *[]account.Role, totalItems int64, err error) {
+func (r *RoleService) FindAll(ctx context.Context, filter *model.PaginationFilter) (result *[]account.RoleResponse, totalItems int64, err error) {
+	if _, err = authorizeActor(ctx, r.resolver, account.PermissionRolesRead); err != nil {
+		return &[]account.RoleResponse{}, 0, err
+	}
+
 	roles, totalItems, err := r.repo.FindAll(ctx, filter)
 	if err != nil {
-		return &[]account.Role{}, 0, err
+		return &[]account.RoleResponse{}, 0, err
 	}
 
-	return roles, int64(totalItems), nil
+	return toRoleResponses(*roles), int64(totalItems), nil
 }
 
-func (r *RoleService) Update(ctx context.Context, id string, role *account.Role) (err error) {
+func (r *RoleService) Update(ctx context.Context, id string, role *account.UpdateRoleRequest) (err error) {
+	if _, err := authorizeActor(ctx, r.resolver, account.PermissionRolesWrite); err != nil {
+		return err
+	}
+
 	currentRole, err := r.repo.FindById(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	if role.Version != 0 && role.Version != currentRole.Version {
+		return errs.VersionConflictError{Resource: "role", ID: id, Expected: role.Version, Actual: currentRole.Version}
+	}
+
+	before := *currentRole
+
 	if role.Name != "" {
 		currentRole.Name = role.Name
 	}
+	if role.Permissions != nil {
+		// hanya permission baru yang divalidasi agar permission lama yang sudah tidak terdaftar tetap bisa dihapus
+		if err := r.catalog.Validate(account.AddedPermissions(currentRole.Permissions, role.Permissions)); err != nil {
+			return err
+		}
+		currentRole.SetPermissions(role.Permissions)
+	}
+	if role.ParentID != nil {
+		if err := r.validateParent(ctx, currentRole.ID, *role.ParentID); err != nil {
+			return err
+		}
+		currentRole.SetParent(*role.ParentID)
+	}
+	currentRole.UpdatedAt = time.Now()
 
-	return r.repo.Update(ctx, id, currentRole)
+	return r.committer.Commit(ctx, currentRole, func(ctx context.Context) error {
+		if err := r.repo.Update(ctx, id, currentRole); err != nil {
+			return err
+		}
+		return r.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateRole, id, audit.Diff(&before, currentRole))
+	})
 }
 
 func (r *RoleService) Delete(ctx context.Context, id string) (err error) {
-	err = r.repo.Delete(ctx, id)
+	if _, err := authorizeActor(ctx, r.resolver, account.PermissionRolesDelete); err != nil {
+		return err
+	}
+
+	role, err := r.repo.FindById(ctx, id)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	children, err := r.repo.CountChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return fmt.Errorf("role '%s' is the parent of %d role(s): %w", id, children, errs.ErrConflict)
+	}
+	role.MarkDeleted()
+
+	return r.committer.Commit(ctx, role, func(ctx context.Context) error {
+		if err := r.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return r.auditor.Record(ctx, audit.ActionDelete, audit.AggregateRole, id, audit.Diff(role, nil))
+	})
 }
 
-func (r *RoleService) AssignUser(ctx context.Context, userId string, roleId string) (err error) {
-	err = r.repo.AssignUser(ctx, userId, roleId)
+// Catalog mengembalikan seluruh permission yang dapat diberikan ke role
+func (r *RoleService) Catalog(ctx context.Context) (result []permission.Definition, err error) {
+	if _, err = authorizeActor(ctx, r.resolver, account.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+	return r.catalog.All(), nil
+}
+
+// EffectivePermissions mengembalikan permission role beserta yang diwarisi dari leluhurnya
+func (r *RoleService) EffectivePermissions(ctx context.Context, id string) (result *account.EffectivePermissionsResponse, err error) {
+	if _, err = authorizeActor(ctx, r.resolver, account.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+
+	chain, err := r.repo.FindChain(ctx, id)
 	if err != nil {
+		return nil, err
+	}
+
+	ancestors := make([]account.RoleResponse, 0, len(chain)-1)
+	for _, role := range chain[1:] {
+		ancestors = append(ancestors, *role.ToRoleResponse())
+	}
+
+	return &account.EffectivePermissionsResponse{
+		RoleID:      chain[0].ID,
+		Permissions: account.EffectivePermissions(chain),
+		Ancestors:   ancestors,
+	}, nil
+}
+
+func (r *RoleService) AssignUser(ctx context.Context, userId string, roleId string, request *account.AssignRoleRequest) (err error) {
+	if _, err := authorizeActor(ctx, r.resolver, account.PermissionRolesAssign); err != nil {
 		return err
 	}
-	return nil
+
+	userID, err := identity.Parse(userId)
+	if err != nil {
+		return errs.ValidationError{Field: "user_id", Message: "must be a valid UUID"}
+	}
+	roleID, err := identity.Parse(roleId)
+	if err != nil {
+		return errs.ValidationError{Field: "role_id", Message: "must be a valid UUID"}
+	}
+	return r.GrantUser(ctx, userID, roleID, request.ExpiresAt)
+}
+
+// GrantUser memberikan role tanpa memeriksa permission actor. Hanya untuk
+// alur internal yang sudah diotorisasi sendiri, misalnya approval elevation.
+func (r *RoleService) GrantUser(ctx context.Context, userID identity.ID, roleID identity.ID, expiresAt *time.Time) (err error) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errs.ValidationError{Field: "expires_at", Message: "must be in the future"}
+	}
+
+	if _, err := r.users.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	role, err := r.repo.FindById(ctx, roleID.String())
+	if err != nil {
+		return err
+	}
+	role.AssignTo(userID)
+
+	assignedBy, _ := requestctx.ActorID(ctx)
+	assignment := account.NewUserRole(userID, role.ID, assignedBy, expiresAt)
+
+	return r.committer.Commit(ctx, role, func(ctx context.Context) error {
+		if err := r.repo.AssignUser(ctx, assignment); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "role_id", After: roleID.String()}}
+		if assignment.ExpiresAt != nil {
+			changes = append(changes, audit.Change{Field: "expires_at", After: assignment.ExpiresAt})
+		}
+		return r.auditor.Record(ctx, audit.ActionAssignRole, audit.AggregateUser, userID.String(), changes)
+	})
 }
 
 func (r *RoleService) UnassignUser(ctx context.Context, userId string, roleId string) (err error) {
-	err = r.repo.UnassignUser(ctx, userId, roleId)
+	if _, err := authorizeActor(ctx, r.resolver, account.PermissionRolesAssign); err != nil {
+		return err
+	}
+
+	userID, err := identity.Parse(userId)
+	if err != nil {
+		return errs.ValidationError{Field: "user_id", Message: "must be a valid UUID"}
+	}
+
+	role, err := r.repo.FindById(ctx, roleId)
 	if err != nil {
 		return err
 	}
+	role.UnassignFrom(userID)
+
+	return r.committer.Commit(ctx, role, func(ctx context.Context) error {
+		if err := r.repo.UnassignUser(ctx, userId, roleId); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "role_id", Before: roleId}}
+		return r.auditor.Record(ctx, audit.ActionUnassignRole, audit.AggregateUser, userId, changes)
+	})
+}
+
+// FindUserRoles mengembalikan role utama user beserta assignment yang belum kedaluwarsa.
+// User boleh melihat role miliknya sendiri, user lain membutuhkan roles:read.
+func (r *RoleService) FindUserRoles(ctx context.Context, userID identity.ID) (result []*account.UserRoleResponse, err error) {
+	if err = authorizeUser(ctx, r.resolver, userID, account.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+
+	user, err := r.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := r.repo.FindUserRoles(ctx, userID.String(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]identity.ID, 0, len(assignments)+1)
+	if !user.Role.IsNil() {
+		ids = append(ids, user.Role)
+	}
+	for _, assignment := range assignments {
+		ids = append(ids, assignment.RoleID)
+	}
+
+	roles, err := r.repo.FindManyByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[identity.ID]*account.Role, len(*roles))
+	for i := range *roles {
+		byID[(*roles)[i].ID] = &(*roles)[i]
+	}
+
+	result = make([]*account.UserRoleResponse, 0, len(ids))
+	primary := -1
+	if role, ok := byID[user.Role]; ok {
+		primary = len(result)
+		result = append(result, &account.UserRoleResponse{Role: role.ToRoleResponse(), Primary: true})
+	}
+	for _, assignment := range assignments {
+		role, ok := byID[assignment.RoleID]
+		if !ok {
+			continue
+		}
+		response := assignment.ToUserRoleResponse(role)
+		// role utama yang juga di-assign cukup ditampilkan sekali dengan metadata assignment
+		if assignment.RoleID == user.Role && primary >= 0 {
+			response.Primary = true
+			result[primary] = response
+			continue
+		}
+		result = append(result, response)
+	}
+	return result, nil
+}
+
+// validateParent memastikan parent ada, tidak membentuk siklus, dan tidak melebihi MaxRoleDepth.
+// Role yang dipindah membawa seluruh turunannya, jadi tinggi subtree ikut dihitung.
+func (r *RoleService) validateParent(ctx context.Context, roleID identity.ID, parentID identity.ID) error {
+	if parentID.IsNil() {
+		return nil
+	}
+	if parentID == roleID {
+		return errs.ValidationError{Field: "parent_id", Message: "role cannot be its own parent"}
+	}
+
+	chain, err := r.repo.FindChain(ctx, parentID.String())
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ValidationError{Field: "parent_id", Message: "parent role not found"}
+		}
+		return err
+	}
+	if account.ContainsRole(chain, roleID) {
+		return errs.ValidationError{Field: "parent_id", Message: "would create a cycle in the role hierarchy"}
+	}
+
+	descendants, err := r.repo.DescendantDepth(ctx, roleID.String())
+	if err != nil {
+		return err
+	}
+	if len(chain)+1+descendants > account.MaxRoleDepth {
+		return errs.ValidationError{Field: "parent_id", Message: fmt.Sprintf("role hierarchy cannot be deeper than %d levels", account.MaxRoleDepth)}
+	}
 	return nil
 }
+
+func toRoleResponses(roles []account.Role) *[]account.RoleResponse {
+	result := make([]account.RoleResponse, 0, len(roles))
+	for i := range roles {
+		result = append(result, *roles[i].ToRoleResponse())
+	}
+	return &result
+}
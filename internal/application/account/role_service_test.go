@@ -0,0 +1,384 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// hierarchyRepository menyimpan pohon role sebagai peta child ke parent.
+// Method lain dari IRoleRepository tidak dipakai validateParent.
+type hierarchyRepository struct {
+	interfaces.IRoleRepository
+	parents map[identity.ID]identity.ID
+}
+
+func (h *hierarchyRepository) FindChain(ctx context.Context, id string) ([]*account.Role, error) {
+	current, err := identity.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := h.parents[current]; !ok {
+		return nil, errs.ErrNotFound
+	}
+
+	chain := make([]*account.Role, 0)
+	for !current.IsNil() && len(chain) < account.MaxRoleDepth {
+		chain = append(chain, &account.Role{ID: current, ParentID: h.parents[current]})
+		current = h.parents[current]
+	}
+	return chain, nil
+}
+
+func (h *hierarchyRepository) DescendantDepth(ctx context.Context, id string) (int, error) {
+	root, err := identity.Parse(id)
+	if err != nil {
+		return 0, err
+	}
+
+	depth := 0
+	for child, parent := range h.parents {
+		if parent == root {
+			below, _ := h.DescendantDepth(ctx, child.String())
+			depth = max(depth, below+1)
+		}
+	}
+	return depth, nil
+}
+
+// chainOf membuat rantai role sepanjang n dan mengembalikan ID dari akar ke ujung
+func chainOf(parents map[identity.ID]identity.ID, parent identity.ID, n int) []identity.ID {
+	ids := make([]identity.ID, 0, n)
+	for range n {
+		id := identity.New()
+		parents[id] = parent
+		ids = append(ids, id)
+		parent = id
+	}
+	return ids
+}
+
+func TestRoleServiceValidateParent(t *testing.T) {
+	parents := map[identity.ID]identity.ID{}
+	// tree berisi akar dengan kedalaman MaxRoleDepth-2
+	tree := chainOf(parents, identity.ID{}, account.MaxRoleDepth-2)
+	// subtree adalah role dengan dua tingkat turunan di pohon terpisah
+	subtree := chainOf(parents, identity.ID{}, 3)
+	leaf := chainOf(parents, identity.ID{}, 1)[0]
+
+	service := &RoleService{repo: &hierarchyRepository{parents: parents}}
+
+	tests := []struct {
+		name     string
+		roleID   identity.ID
+		parentID identity.ID
+		wantErr  bool
+	}{
+		{name: "no parent", roleID: subtree[0], parentID: identity.ID{}},
+		{name: "self", roleID: leaf, parentID: leaf, wantErr: true},
+		{name: "unknown parent", roleID: leaf, parentID: identity.New(), wantErr: true},
+		{name: "cycle", roleID: tree[0], parentID: tree[len(tree)-1], wantErr: true},
+		{name: "leaf under the deepest role", roleID: leaf, parentID: tree[len(tree)-1]},
+		{name: "new role under the deepest role", roleID: identity.New(), parentID: tree[len(tree)-1]},
+		{name: "subtree fits", roleID: subtree[0], parentID: tree[len(tree)-4]},
+		{name: "subtree exceeds the depth", roleID: subtree[0], parentID: tree[len(tree)-1], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.validateParent(context.Background(), tt.roleID, tt.parentID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateParent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errs.IsValidationError(err) {
+				t.Fatalf("validateParent() error = %v, want a validation error", err)
+			}
+		})
+	}
+}
+
+// missingUsers menganggap semua user tidak ada
+type missingUsers struct {
+	interfaces.IUserRepository
+}
+
+func (missingUsers) GetByID(ctx context.Context, id identity.ID) (*account.User, error) {
+	return nil, errs.ErrNotFound
+}
+
+func TestRoleServiceChangesRequirePermission(t *testing.T) {
+	roleID, userID := identity.New().String(), identity.New().String()
+
+	calls := []struct {
+		name       string
+		permission string
+		call       func(ctx context.Context, service *RoleService) error
+	}{
+		{name: "create", permission: account.PermissionRolesWrite, call: func(ctx context.Context, service *RoleService) error {
+			return service.Create(ctx, &account.CreateRoleRequest{Name: "auditor"})
+		}},
+		{name: "update", permission: account.PermissionRolesWrite, call: func(ctx context.Context, service *RoleService) error {
+			return service.Update(ctx, roleID, &account.UpdateRoleRequest{Name: "auditor"})
+		}},
+		{name: "delete", permission: account.PermissionRolesDelete, call: func(ctx context.Context, service *RoleService) error {
+			return service.Delete(ctx, roleID)
+		}},
+		{name: "assign", permission: account.PermissionRolesAssign, call: func(ctx context.Context, service *RoleService) error {
+			return service.AssignUser(ctx, userID, roleID, &account.AssignRoleRequest{})
+		}},
+		{name: "unassign", permission: account.PermissionRolesAssign, call: func(ctx context.Context, service *RoleService) error {
+			return service.UnassignUser(ctx, userID, roleID)
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			anonymous := &RoleService{resolver: fakeResolver{}}
+			if err := tt.call(context.Background(), anonymous); !errors.Is(err, errs.ErrUnauthorized) {
+				t.Errorf("without actor error = %v, want ErrUnauthorized", err)
+			}
+
+			service := &RoleService{resolver: fakeResolver{subject: &policy.Subject{ID: identity.New(), Permissions: []string{account.PermissionRolesRead}}}}
+			if err := tt.call(context.Background(), service); !errors.Is(err, errs.ErrForbidden) {
+				t.Errorf("without %s error = %v, want ErrForbidden", tt.permission, err)
+			}
+		})
+	}
+}
+
+func TestRoleServiceGrantUserSkipsPermission(t *testing.T) {
+	// tanpa actor GrantUser tetap lanjut hingga mencari user
+	service := &RoleService{resolver: fakeResolver{}, users: missingUsers{}}
+
+	err := service.GrantUser(context.Background(), identity.New(), identity.New(), nil)
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("GrantUser() error = %v, want ErrNotFound", err)
+	}
+}
+
+// versionedRoles mengembalikan role dengan versi tetap
+type versionedRoles struct {
+	interfaces.IRoleRepository
+	version int64
+}
+
+func (v versionedRoles) FindById(ctx context.Context, id string) (*account.Role, error) {
+	roleID, err := identity.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return &account.Role{ID: roleID, Name: "auditor", Version: v.version}, nil
+}
+
+func TestRoleServiceUpdateVersionConflict(t *testing.T) {
+	service := &RoleService{repo: versionedRoles{version: 3}, resolver: fakeResolver{}}
+	ctx := requestctx.WithSystemActor(context.Background())
+
+	err := service.Update(ctx, identity.New().String(), &account.UpdateRoleRequest{Name: "reader", Version: 2})
+	var conflict errs.VersionConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Update() error = %v, want VersionConflictError", err)
+	}
+	if conflict.Expected != 2 || conflict.Actual != 3 {
+		t.Errorf("conflict = expected %d actual %d, want expected 2 actual 3", conflict.Expected, conflict.Actual)
+	}
+}
+
+func TestRoleServiceReadsRequirePermission(t *testing.T) {
+	roleID, userID := identity.New().String(), identity.New()
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context, service *RoleService) error
+	}{
+		{name: "find all", call: func(ctx context.Context, service *RoleService) error {
+			_, _, err := service.FindAll(ctx, &model.PaginationFilter{})
+			return err
+		}},
+		{name: "find by id", call: func(ctx context.Context, service *RoleService) error {
+			_, err := service.FindById(ctx, roleID)
+			return err
+		}},
+		{name: "find many by id", call: func(ctx context.Context, service *RoleService) error {
+			_, err := service.FindManyByID(ctx, []identity.ID{identity.New()})
+			return err
+		}},
+		{name: "catalog", call: func(ctx context.Context, service *RoleService) error {
+			_, err := service.Catalog(ctx)
+			return err
+		}},
+		{name: "effective permissions", call: func(ctx context.Context, service *RoleService) error {
+			_, err := service.EffectivePermissions(ctx, roleID)
+			return err
+		}},
+		{name: "roles of another user", call: func(ctx context.Context, service *RoleService) error {
+			_, err := service.FindUserRoles(ctx, userID)
+			return err
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			anonymous := &RoleService{resolver: fakeResolver{}}
+			if err := tt.call(context.Background(), anonymous); !errors.Is(err, errs.ErrUnauthorized) {
+				t.Errorf("without actor error = %v, want ErrUnauthorized", err)
+			}
+
+			service := &RoleService{resolver: fakeResolver{subject: &policy.Subject{ID: identity.New(), Permissions: []string{account.PermissionRolesWrite}}}}
+			if err := tt.call(context.Background(), service); !errors.Is(err, errs.ErrForbidden) {
+				t.Errorf("without roles:read error = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+func TestRoleServiceFindUserRolesAllowsSelf(t *testing.T) {
+	userID := identity.New()
+	service := &RoleService{resolver: fakeResolver{subject: &policy.Subject{ID: userID}}, users: missingUsers{}}
+
+	// lolos otorisasi lalu berhenti karena user tidak ditemukan
+	if _, err := service.FindUserRoles(context.Background(), userID); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("FindUserRoles() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRoleServiceFindUserRolesMergesAssignments(t *testing.T) {
+	roles, _, editor, viewer := roleFixture()
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		primary     identity.ID
+		assigned    []identity.ID
+		wantRoles   []string
+		wantPrimary []bool
+		wantExpiry  []bool
+	}{
+		{name: "primary only", primary: editor, wantRoles: []string{"editor"}, wantPrimary: []bool{true}, wantExpiry: []bool{false}},
+		{name: "primary and assignment", primary: editor, assigned: []identity.ID{viewer}, wantRoles: []string{"editor", "viewer"}, wantPrimary: []bool{true, false}, wantExpiry: []bool{false, true}},
+		// role utama yang juga di-assign tampil sekali dengan metadata assignment
+		{name: "primary also assigned", primary: editor, assigned: []identity.ID{editor}, wantRoles: []string{"editor"}, wantPrimary: []bool{true}, wantExpiry: []bool{true}},
+		{name: "deleted role is skipped", assigned: []identity.ID{identity.New(), viewer}, wantRoles: []string{"viewer"}, wantPrimary: []bool{false}, wantExpiry: []bool{true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &account.User{ID: identity.New(), Role: tt.primary}
+			repo := &assignedRoles{roles: roles}
+			for _, roleID := range tt.assigned {
+				repo.assignments = append(repo.assignments, account.NewUserRole(user.ID, roleID, identity.New(), &expiresAt))
+			}
+			service := &RoleService{repo: repo, users: &accountUsers{user: user}, resolver: fakeResolver{subject: &policy.Subject{ID: user.ID}}}
+
+			result, err := service.FindUserRoles(context.Background(), user.ID)
+			if err != nil {
+				t.Fatalf("FindUserRoles() error = %v", err)
+			}
+			if len(result) != len(tt.wantRoles) {
+				t.Fatalf("FindUserRoles() returned %d roles, want %v", len(result), tt.wantRoles)
+			}
+			for i, response := range result {
+				if response.Role.Name != tt.wantRoles[i] || response.Primary != tt.wantPrimary[i] || (response.ExpiresAt != nil) != tt.wantExpiry[i] {
+					t.Errorf("role %d = %s primary %v expires %v, want %s primary %v expires %v",
+						i, response.Role.Name, response.Primary, response.ExpiresAt, tt.wantRoles[i], tt.wantPrimary[i], tt.wantExpiry[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoleServiceGrantUserRejectsPastExpiry(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+	}{
+		{name: "expired", expiresAt: now.Add(-time.Minute)},
+		{name: "expires now", expiresAt: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &RoleService{resolver: fakeResolver{}, users: missingUsers{}}
+			err := service.GrantUser(context.Background(), identity.New(), identity.New(), &tt.expiresAt)
+			if !errs.IsValidationError(err) {
+				t.Fatalf("GrantUser() error = %v, want a validation error", err)
+			}
+		})
+	}
+}
+
+// storedRole menyimpan satu role dan mencatat hasil Update
+type storedRole struct {
+	interfaces.IRoleRepository
+	role    *account.Role
+	updated *account.Role
+}
+
+func (s *storedRole) FindById(ctx context.Context, id string) (*account.Role, error) {
+	role := *s.role
+	role.Permissions = slices.Clone(s.role.Permissions)
+	return &role, nil
+}
+
+func (s *storedRole) Update(ctx context.Context, id string, role *account.Role) error {
+	s.updated = role
+	return nil
+}
+
+func TestRoleServiceUpdateValidatesOnlyAddedPermissions(t *testing.T) {
+	catalog := permission.NewRegistry()
+	catalog.MustRegister(account.Permissions...)
+	// legacy:export pernah terdaftar namun sudah dihapus dari katalog
+	current := []string{account.PermissionUsersRead, "legacy:export"}
+
+	tests := []struct {
+		name        string
+		permissions []string
+		wantErr     bool
+	}{
+		{name: "keep a stale permission", permissions: []string{account.PermissionUsersRead, "legacy:export"}},
+		{name: "remove a stale permission", permissions: []string{account.PermissionUsersRead}},
+		{name: "add a known permission next to a stale one", permissions: []string{account.PermissionUsersRead, "legacy:export", account.PermissionUsersWrite}},
+		{name: "add an unknown permission", permissions: []string{account.PermissionUsersRead, "unknown:thing"}, wantErr: true},
+		{name: "add another unregistered permission", permissions: []string{"legacy:export", "legacy:import"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &storedRole{role: &account.Role{ID: identity.New(), Name: "exporter", Permissions: current, Version: 1}}
+			service := &RoleService{repo: repo, catalog: catalog, resolver: fakeResolver{}, auditor: discardAuditor{}, committer: shared.NewCommitter(inlineTx{}, discardOutbox{}, discardDispatcher{})}
+			ctx := requestctx.WithSystemActor(context.Background())
+
+			err := service.Update(ctx, repo.role.ID.String(), &account.UpdateRoleRequest{Permissions: tt.permissions})
+			if tt.wantErr {
+				if !errs.IsValidationError(err) {
+					t.Fatalf("Update() error = %v, want a validation error", err)
+				}
+				if repo.updated != nil {
+					t.Error("role was stored despite an unknown permission")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			if repo.updated == nil || !slices.Equal(repo.updated.Permissions, tt.permissions) {
+				t.Errorf("stored role = %+v, want permissions %v", repo.updated, tt.permissions)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+// lastSeenInterval membatasi penulisan last_seen_at agar tidak terjadi update
+// pada setiap request
+const lastSeenInterval = time.Minute
+
+var errSessionRevoked = fmt.Errorf("session has been revoked or has expired: %w", errs.ErrUnauthorized)
+
+type SessionService struct {
+	repo     interfaces.ISessionRepository
+	tokens   interfaces.IAccessTokenVerifier
+	resolver interfaces.IPermissionResolver
+	auditor  auditInterfaces.IAuditService
+	tx       transaction.IManager
+}
+
+func NewSessionService(repo interfaces.ISessionRepository, tokens interfaces.IAccessTokenVerifier, resolver interfaces.IPermissionResolver, auditor auditInterfaces.IAuditService, tx transaction.IManager) *SessionService {
+	return &SessionService{
+		repo:     repo,
+		tokens:   tokens,
+		resolver: resolver,
+		auditor:  auditor,
+		tx:       tx,
+	}
+}
+
+/**
+ * List returns the active sessions of a user, most recently seen first.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @return ([]*account.SessionResponse, error)
+ */
+func (s *SessionService) List(ctx context.Context, userID identity.ID) (result []*account.SessionResponse, err error) {
+	if err = authorizeUser(ctx, s.resolver, userID, account.PermissionSessionsRead); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.repo.FindActiveByUser(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	current, _ := requestctx.SessionID(ctx)
+	result = make([]*account.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, session.ToSessionResponse(current))
+	}
+	return result, nil
+}
+
+/**
+ * Revoke signs a user out of one session.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param sessionID identity.ID
+ * @return error
+ */
+func (s *SessionService) Revoke(ctx context.Context, userID identity.ID, sessionID identity.ID) (err error) {
+	if err = authorizeUser(ctx, s.resolver, userID, account.PermissionSessionsRevoke); err != nil {
+		return err
+	}
+
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	// Session milik user lain diperlakukan seperti tidak ada
+	if session.UserID != userID || session.RevokedAt != nil {
+		return errs.ErrNotFound
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Revoke(ctx, sessionID, time.Now()); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "session", Before: sessionID, After: "revoked"}}
+		return s.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, userID.String(), changes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+/**
+ * RevokeAll signs a user out everywhere.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @return error
+ */
+func (s *SessionService) RevokeAll(ctx context.Context, userID identity.ID) (err error) {
+	if err = authorizeUser(ctx, s.resolver, userID, account.PermissionSessionsRevoke); err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return revokeSessions(ctx, s.repo, s.auditor, userID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+/**
+ * Verify checks an access token and the session it belongs to, so revoked
+ * sessions are rejected before their tokens expire. The session is looked up
+ * in the token's tenant, which must match the tenant of the request host.
+ * @param ctx context.Context
+ * @param token string
+ * @return (*account.Principal, error)
+ */
+func (s *SessionService) Verify(ctx context.Context, token string) (result *account.Principal, err error) {
+	principal, err := s.tokens.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	// Token tanpa sid tidak bisa dicabut sehingga ikut ditolak
+	if principal.SessionID.IsNil() {
+		return nil, errSessionRevoked
+	}
+	// Session dicari di tenant milik token, token tenant lain ditolak pada
+	// host yang sudah terpetakan ke tenant
+	if principal.TenantID.IsNil() {
+		return nil, errSessionRevoked
+	}
+	if tenantID, ok := requestctx.TenantID(ctx); ok && tenantID != principal.TenantID {
+		return nil, errSessionRevoked
+	}
+	ctx = requestctx.WithTenantID(ctx, principal.TenantID)
+
+	session, err := s.repo.FindByID(ctx, principal.SessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errSessionRevoked
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	now := time.Now()
+	if session.UserID != principal.UserID || !session.IsActive(now) {
+		return nil, errSessionRevoked
+	}
+
+	if now.Sub(session.LastSeenAt) >= lastSeenInterval {
+		// Gagal mencatat last seen tidak boleh menolak request
+		if err := s.repo.TouchLastSeen(ctx, session.ID, now); err != nil {
+			slog.WarnContext(ctx, "failed to update session last seen", slog.String("session_id", session.ID.String()), slog.Any("error", err))
+		}
+	}
+	return principal, nil
+}
+
+// revokeSessions mencabut semua session user dan mencatat audit, dipanggil di
+// dalam transaksi yang sama dengan perubahan yang memicunya
+func revokeSessions(ctx context.Context, sessions interfaces.ISessionRepository, auditor auditInterfaces.IAuditService, userID identity.ID) error {
+	revoked, err := sessions.RevokeAllForUser(ctx, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return nil
+	}
+
+	changes := []audit.Change{{Field: "sessions", Before: revoked, After: "revoked"}}
+	return auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, userID.String(), changes)
+}
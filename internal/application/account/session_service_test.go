@@ -0,0 +1,77 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// staticVerifier menerima token apa pun sebagai principal yang sama
+type staticVerifier struct {
+	principal account.Principal
+}
+
+func (s staticVerifier) Verify(ctx context.Context, token string) (*account.Principal, error) {
+	principal := s.principal
+	return &principal, nil
+}
+
+// tenantSessions hanya menemukan session pada tenant miliknya
+type tenantSessions struct {
+	interfaces.ISessionRepository
+	session *account.Session
+}
+
+func (t tenantSessions) FindByID(ctx context.Context, id identity.ID) (*account.Session, error) {
+	tenantID, ok := requestctx.TenantID(ctx)
+	if !ok || tenantID != t.session.TenantID || id != t.session.ID {
+		return nil, errs.ErrNotFound
+	}
+	return t.session, nil
+}
+
+func TestSessionServiceVerifyUsesTokenTenant(t *testing.T) {
+	tokenTenant, userID := identity.New(), identity.New()
+	session := account.NewSession(identity.New(), userID, "test", "127.0.0.1", time.Now().Add(time.Hour))
+	session.TenantID = tokenTenant
+
+	tests := []struct {
+		name        string
+		hostTenant  identity.ID
+		tokenTenant identity.ID
+		wantErr     error
+	}{
+		{name: "no host tenant", tokenTenant: tokenTenant},
+		{name: "same host tenant", hostTenant: tokenTenant, tokenTenant: tokenTenant},
+		{name: "other host tenant", hostTenant: identity.New(), tokenTenant: tokenTenant, wantErr: errs.ErrUnauthorized},
+		{name: "token without tenant", hostTenant: tokenTenant, wantErr: errs.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &SessionService{
+				repo:   tenantSessions{session: session},
+				tokens: staticVerifier{principal: account.Principal{UserID: userID, SessionID: session.ID, TenantID: tt.tokenTenant}},
+			}
+
+			ctx := context.Background()
+			if !tt.hostTenant.IsNil() {
+				ctx = requestctx.WithTenantID(ctx, tt.hostTenant)
+			}
+			_, err := service.Verify(ctx, "token")
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Verify() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,32 +4,70 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
-	"github.com/google/uuid"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
 )
 
 type UserService struct {
-	repo interfaces.IUserRepository
+	repo      interfaces.IUserRepository
+	roles     interfaces.IRoleRepository
+	auditor   auditInterfaces.IAuditService
+	passwords *PasswordValidator
+	hasher    account.IPasswordHasher
+	attempts  interfaces.ILoginAttemptStore
+	sessions  interfaces.ISessionRepository
+	resolver  interfaces.IPermissionResolver
+	policies  interfaces.IPolicyEngine
+	committer shared.Committer
 }
 
-func NewUserService(repo interfaces.IUserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo interfaces.IUserRepository, roles interfaces.IRoleRepository, auditor auditInterfaces.IAuditService, passwords *PasswordValidator, hasher account.IPasswordHasher, attempts interfaces.ILoginAttemptStore, sessions interfaces.ISessionRepository, resolver interfaces.IPermissionResolver, policies interfaces.IPolicyEngine, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher) *UserService {
+	return &UserService{
+		repo:      repo,
+		roles:     roles,
+		auditor:   auditor,
+		passwords: passwords,
+		hasher:    hasher,
+		attempts:  attempts,
+		sessions:  sessions,
+		resolver:  resolver,
+		policies:  policies,
+		committer: shared.NewCommitter(tx, outbox, dispatcher),
+	}
 }
 
 /**
- * GetALl retrieves a user by filter.
+ * GetALl retrieves a user by filter. The actor needs users:read.
  * @param ctx context.Context
  * @param limit int
  * @param page int
  * @param sort string
  * @param search string
+ * @param fields []string
  * @return (*account.UserResponse, error)
  */
-func (u *UserService) GetAll(ctx context.Context, search string, limit int, page int, sort string) (result []*account.UserResponse, totalItems int64, err error) {
+func (u *UserService) GetAll(ctx context.Context, search string, limit int, page int, sort string, fields []string) (result []*account.UserResponse, totalItems int64, err error) {
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersRead); err != nil {
+		return nil, 0, err
+	}
+	if err = account.ValidateUserFields(fields); err != nil {
+		return nil, 0, err
+	}
+
 	users, totalItem, err := u.repo.GetAll(ctx, search, limit, page, sort)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
@@ -42,23 +80,55 @@ func (u *UserService) GetAll(ctx context.Context, search string, limit int, page
 		return result, 0, errs.ErrNotFound
 	}
 
-	result = make([]*account.UserResponse, 0, len(users))
-
-	for _, user := range users {
-		userResponse := user.ToUserResponse()
-		result = append(result, userResponse)
+	result, err = u.toUserResponses(ctx, users, fields)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	return result, totalItem, nil
 }
 
+func (u *UserService) GetAllByRole(ctx context.Context, roleID identity.ID, search string, limit int, page int, sort string, fields []string) (result []*account.UserResponse, totalItems int64, err error) {
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersRead); err != nil {
+		return nil, 0, err
+	}
+	if err = account.ValidateUserFields(fields); err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := u.roles.FindById(ctx, roleID.String()); err != nil {
+		return nil, 0, err
+	}
+
+	users, totalItems, err := u.repo.GetAllByRole(ctx, roleID, search, limit, page, sort)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	result, err = u.toUserResponses(ctx, users, fields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result, totalItems, nil
+}
+
 /**
- * GetByID retrieves a user by their ID.
+ * GetByID retrieves a user by their ID. Users can read themselves, other
+ * users need users:read.
  * @param ctx context.Context
  * @param id identity.ID
+ * @param fields []string
  * @return (*account.UserResponse, error)
  */
-func (u *UserService) GetByID(ctx context.Context, id identity.ID) (result *account.UserResponse, err error) {
+func (u *UserService) GetByID(ctx context.Context, id identity.ID, fields []string) (result *account.UserResponse, err error) {
+	if err = authorizeUser(ctx, u.resolver, id, account.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	if err = account.ValidateUserFields(fields); err != nil {
+		return nil, err
+	}
+
 	user, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
@@ -67,18 +137,24 @@ func (u *UserService) GetByID(ctx context.Context, id identity.ID) (result *acco
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
 
-	result = user.ToUserResponse()
-
-	return result, nil
+	return u.toUserResponse(ctx, user, fields)
 }
 
 /**
- * GetByEmail retrieves a user by their email.
+ * GetByEmail retrieves a user by their email. The actor needs users:read.
  * @param ctx context.Context
  * @param email string
+ * @param fields []string
  * @return (*account.UserResponse, error)
  */
-func (u *UserService) GetByEmail(ctx context.Context, email string) (result *account.UserResponse, err error) {
+func (u *UserService) GetByEmail(ctx context.Context, email string, fields []string) (result *account.UserResponse, err error) {
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	if err = account.ValidateUserFields(fields); err != nil {
+		return nil, err
+	}
+
 	user, err := u.repo.GetByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
@@ -87,18 +163,25 @@ func (u *UserService) GetByEmail(ctx context.Context, email string) (result *acc
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
 
-	result = user.ToUserResponse()
-
-	return result, nil
+	return u.toUserResponse(ctx, user, fields)
 }
 
 /**
- * GetByUsername retrieves a user by their username.
+ * GetByUsername retrieves a user by their username. The actor needs
+ * users:read.
  * @param ctx context.Context
  * @param username string
+ * @param fields []string
  * @return (*account.UserResponse, error)
  */
-func (u *UserService) GetByUsername(ctx context.Context, username string) (result *account.UserResponse, err error) {
+func (u *UserService) GetByUsername(ctx context.Context, username string, fields []string) (result *account.UserResponse, err error) {
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	if err = account.ValidateUserFields(fields); err != nil {
+		return nil, err
+	}
+
 	user, err := u.repo.GetByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
@@ -107,26 +190,34 @@ func (u *UserService) GetByUsername(ctx context.Context, username string) (resul
 		return nil, fmt.Errorf("failed to get user by username: %w", err)
 	}
 
-	result = user.ToUserResponse()
-
-	return result, nil
+	return u.toUserResponse(ctx, user, fields)
 }
 
 /**
- * Create creates a new user.
+ * Create creates a new user. The actor needs users:write.
  * @param ctx context.Context
  * @param user *account.CreateUserRequest
  */
 func (u *UserService) Create(ctx context.Context, user *account.CreateUserRequest) (err error) {
-	newUser := &account.User{
-		Name:     user.Name,
-		Username: user.Username,
-		Fullname: user.Fullname,
-		Email:    user.Email,
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersWrite); err != nil {
+		return err
 	}
-	newUser.EncryptPassword(user.Password)
 
-	if err = u.repo.Create(ctx, newUser); err != nil {
+	newUser := account.NewUser(user.Name, user.Fullname, user.Username, user.Email)
+	if err = u.passwords.Validate(ctx, user.Password, newUser); err != nil {
+		return err
+	}
+	if err = newUser.EncryptPassword(u.hasher, user.Password); err != nil {
+		return fmt.Errorf("failed to encrypt password: %w", errs.ErrInternal)
+	}
+
+	err = u.committer.Commit(ctx, newUser, func(ctx context.Context) error {
+		if err := u.repo.Create(ctx, newUser); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.ActionCreate, audit.AggregateUser, newUser.ID.String(), audit.Diff(nil, newUser))
+	})
+	if err != nil {
 		if errors.Is(err, errs.ErrConflict) {
 			return errs.ErrConflict
 		}
@@ -151,58 +242,184 @@ func (u *UserService) Update(ctx context.Context, id identity.ID, payload *accou
 		return fmt.Errorf("failed to get user by id: %w", err)
 	}
 
-	if payload.Name != "" {
+	if payload.Version != 0 && payload.Version != user.Version {
+		return errs.VersionConflictError{Resource: "user", ID: id, Expected: payload.Version, Actual: user.Version}
+	}
+
+	if err = u.authorize(ctx, account.PolicyUserUpdate, user, updatedFields(user, payload)); err != nil {
+		return err
+	}
+
+	before := *user
+	changed := make([]string, 0)
+
+	if payload.Name != "" && payload.Name != user.Name {
 		user.Name = payload.Name
+		changed = append(changed, "name")
 	}
 
-	if payload.Fullname != "" {
+	if payload.Fullname != "" && payload.Fullname != user.Fullname {
 		user.Fullname = payload.Fullname
+		changed = append(changed, "fullname")
 	}
 
-	if payload.Username != "" {
+	if payload.Username != "" && payload.Username != user.Username {
 		user.Username = payload.Username
+		changed = append(changed, "username")
 	}
 
 	if payload.Email != "" {
-		user.Email = payload.Email
+		user.ChangeEmail(payload.Email)
 	}
 
 	if payload.Password != "" {
-		if err = user.EncryptPassword(payload.Password); err != nil {
+		if err = u.passwords.Validate(ctx, payload.Password, user); err != nil {
+			return err
+		}
+		if err = user.ChangePassword(u.hasher, payload.Password); err != nil {
 			return fmt.Errorf("failed to encrypt password: %w", errs.ErrInternal)
 		}
 	}
 
-	if uuid.UUID(payload.Role) != uuid.Nil {
-		user.Role = payload.Role
+	if !payload.Role.IsNil() {
+		user.AssignRole(payload.Role)
 	}
 
-	if err = u.repo.Update(ctx, user); err != nil {
+	if payload.Attributes != nil && user.SetAttributes(payload.Attributes) {
+		changed = append(changed, "attributes")
+	}
+
+	user.MarkUpdated(changed)
+
+	err = u.committer.Commit(ctx, user, func(ctx context.Context) error {
+		if err := u.repo.Update(ctx, user); err != nil {
+			return err
+		}
+		if err := u.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, user.ID.String(), audit.Diff(&before, user)); err != nil {
+			return err
+		}
+		// Password baru mengeluarkan user dari semua perangkat
+		if payload.Password != "" {
+			return revokeSessions(ctx, u.sessions, u.auditor, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		if errs.IsVersionConflict(err) {
+			return err
+		}
 		if errors.Is(err, errs.ErrConflict) {
 			return errs.ErrConflict
 		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 	return nil
+}
+
+/**
+ * Deactivate deactivates a user. The actor needs users:delete.
+ * @param ctx context.Context
+ * @param id identity.ID
+ * @return error
+ */
+func (u *UserService) Deactivate(ctx context.Context, id identity.ID) (err error) {
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersDelete); err != nil {
+		return err
+	}
+
+	user, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	before := *user
+	user.Deactivate()
+
+	err = u.committer.Commit(ctx, user, func(ctx context.Context) error {
+		if err := u.repo.Update(ctx, user); err != nil {
+			return err
+		}
+		if err := u.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, user.ID.String(), audit.Diff(&before, user)); err != nil {
+			return err
+		}
+		return revokeSessions(ctx, u.sessions, u.auditor, user.ID)
+	})
+	if err != nil {
+		if errs.IsVersionConflict(err) {
+			return err
+		}
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	return nil
+}
+
+/**
+ * Unlock clears the failed login counter and lockout of a user. The actor
+ * needs users:unlock.
+ * @param ctx context.Context
+ * @param id identity.ID
+ * @return error
+ */
+func (u *UserService) Unlock(ctx context.Context, id identity.ID) (err error) {
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersUnlock); err != nil {
+		return err
+	}
+
+	user, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
 
+	// Audit ditulis lebih dulu di dalam transaksi; bila reset gagal, entry ikut di-rollback
+	err = u.committer.WithinTransaction(ctx, func(ctx context.Context) error {
+		changes := []audit.Change{{Field: "login_lockout", Before: "locked", After: "unlocked"}}
+		if err := u.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateUser, user.ID.String(), changes); err != nil {
+			return err
+		}
+		return u.attempts.Reset(ctx, account.AccountAttemptKey(user.ID))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+	return nil
 }
 
 /**
- * Delete deletes a user.
+ * Delete deletes a user. The actor needs users:delete.
  * @param ctx context.Context
  * @param id identity.ID
  * @return error
  */
 func (u *UserService) Delete(ctx context.Context, id identity.ID) (err error) {
-	_, err = u.repo.GetByID(ctx, id)
+	if _, err = authorizeActor(ctx, u.resolver, account.PermissionUsersDelete); err != nil {
+		return err
+	}
+
+	user, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
 			return errs.ErrNotFound
 		}
 		return fmt.Errorf("failed to get user by id: %w", err)
 	}
+	user.MarkDeleted()
 
-	if err = u.repo.Delete(ctx, id); err != nil {
+	err = u.committer.Commit(ctx, user, func(ctx context.Context) error {
+		if err := u.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if _, err := u.sessions.RevokeAllForUser(ctx, id, time.Now()); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.ActionDelete, audit.AggregateUser, id.String(), audit.Diff(user, nil))
+	})
+	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
 			return errs.ErrNotFound
 		}
@@ -210,3 +427,99 @@ func (u *UserService) Delete(ctx context.Context, id identity.ID) (err error) {
 	}
 	return nil
 }
+
+func (u *UserService) toUserResponse(ctx context.Context, user *account.User, fields []string) (*account.UserResponse, error) {
+	result, err := u.toUserResponses(ctx, []*account.User{user}, fields)
+	if err != nil {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+// toUserResponses memuat role semua user dalam satu query jika field role dipilih
+func (u *UserService) toUserResponses(ctx context.Context, users []*account.User, fields []string) ([]*account.UserResponse, error) {
+	if account.WantsUserField(fields, account.UserFieldRole) {
+		if err := u.loadRoles(ctx, users); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*account.UserResponse, 0, len(users))
+	for _, user := range users {
+		result = append(result, user.ToUserResponse().Only(fields))
+	}
+	return result, nil
+}
+
+// authorize mengevaluasi policy untuk actor pada context terhadap user target.
+// Pemanggilan tanpa actor ditolak kecuali ditandai requestctx.WithSystemActor.
+func (u *UserService) authorize(ctx context.Context, action string, user *account.User, fields []string) error {
+	if requestctx.IsSystemActor(ctx) {
+		return nil
+	}
+
+	subject, ok, err := u.resolver.ActorSubject(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errActorRequired
+	}
+
+	return u.policies.Authorize(policy.Request{Subject: subject, Action: action, Resource: user.PolicyResource(), Fields: fields})
+}
+
+// updatedFields mengembalikan nama JSON field yang benar-benar diubah oleh payload
+func updatedFields(user *account.User, payload *account.UpdateUserRequest) []string {
+	fields := make([]string, 0)
+	if payload.Name != "" && payload.Name != user.Name {
+		fields = append(fields, "name")
+	}
+	if payload.Fullname != "" && payload.Fullname != user.Fullname {
+		fields = append(fields, "fullname")
+	}
+	if payload.Username != "" && payload.Username != user.Username {
+		fields = append(fields, "username")
+	}
+	if payload.Email != "" && payload.Email != user.Email {
+		fields = append(fields, "email")
+	}
+	if payload.Password != "" {
+		fields = append(fields, "password")
+	}
+	if !payload.Role.IsNil() && payload.Role != user.Role {
+		fields = append(fields, "role_id")
+	}
+	if payload.Attributes != nil && !maps.Equal(payload.Attributes, user.Attributes) {
+		fields = append(fields, "attributes")
+	}
+	return fields
+}
+
+func (u *UserService) loadRoles(ctx context.Context, users []*account.User) error {
+	ids := make([]identity.ID, 0, len(users))
+	for _, user := range users {
+		if !user.Role.IsNil() && !slices.Contains(ids, user.Role) {
+			ids = append(ids, user.Role)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	roles, err := u.roles.FindManyByID(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	byID := make(map[identity.ID]account.Role, len(*roles))
+	for _, role := range *roles {
+		byID[role.ID] = role
+	}
+	for _, user := range users {
+		if role, ok := byID[user.Role]; ok {
+			user.RoleData = role
+		}
+	}
+	return nil
+}
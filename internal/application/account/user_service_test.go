@@ -0,0 +1,273 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+func TestUserServiceAuthorize(t *testing.T) {
+	target := &account.User{ID: identity.New()}
+
+	tests := []struct {
+		name    string
+		system  bool
+		subject *policy.Subject
+		fields  []string
+		wantErr error
+	}{
+		{name: "no actor", subject: nil, fields: []string{"name"}, wantErr: errs.ErrUnauthorized},
+		{name: "system actor", system: true, subject: nil, fields: []string{"role_id"}},
+		{name: "self", subject: &policy.Subject{ID: target.ID}, fields: []string{"name"}},
+		{name: "self role", subject: &policy.Subject{ID: target.ID}, fields: []string{"role_id"}, wantErr: errs.ErrForbidden},
+		{name: "other", subject: &policy.Subject{ID: identity.New()}, fields: []string{"name"}, wantErr: errs.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &UserService{resolver: fakeResolver{subject: tt.subject}, policies: policy.NewEngine(account.UserPolicies...)}
+
+			ctx := context.Background()
+			if tt.system {
+				ctx = requestctx.WithSystemActor(ctx)
+			}
+			err := service.authorize(ctx, account.PolicyUserUpdate, target, tt.fields)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("authorize() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("authorize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserServiceAdminActionsRequirePermission(t *testing.T) {
+	target := identity.New()
+
+	calls := []struct {
+		name       string
+		permission string
+		call       func(ctx context.Context, service *UserService) error
+	}{
+		{name: "create", permission: account.PermissionUsersWrite, call: func(ctx context.Context, service *UserService) error {
+			return service.Create(ctx, &account.CreateUserRequest{Name: "n", Username: "u", Email: "u@example.com", Password: "secret"})
+		}},
+		{name: "deactivate", permission: account.PermissionUsersDelete, call: func(ctx context.Context, service *UserService) error {
+			return service.Deactivate(ctx, target)
+		}},
+		{name: "unlock", permission: account.PermissionUsersUnlock, call: func(ctx context.Context, service *UserService) error {
+			return service.Unlock(ctx, target)
+		}},
+		{name: "delete", permission: account.PermissionUsersDelete, call: func(ctx context.Context, service *UserService) error {
+			return service.Delete(ctx, target)
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			anonymous := &UserService{resolver: fakeResolver{}}
+			if err := tt.call(context.Background(), anonymous); !errors.Is(err, errs.ErrUnauthorized) {
+				t.Errorf("without actor error = %v, want ErrUnauthorized", err)
+			}
+
+			// user lain dengan permission lain, termasuk target itu sendiri
+			for _, subject := range []*policy.Subject{{ID: identity.New(), Permissions: []string{account.PermissionUsersRead}}, {ID: target}} {
+				service := &UserService{resolver: fakeResolver{subject: subject}}
+				if err := tt.call(context.Background(), service); !errors.Is(err, errs.ErrForbidden) {
+					t.Errorf("without %s error = %v, want ErrForbidden", tt.permission, err)
+				}
+			}
+		})
+	}
+}
+
+// versionedUsers mengembalikan user dengan versi tetap
+type versionedUsers struct {
+	interfaces.IUserRepository
+	version int64
+}
+
+func (v versionedUsers) GetByID(ctx context.Context, id identity.ID) (*account.User, error) {
+	return &account.User{ID: id, Name: "user", Version: v.version}, nil
+}
+
+func TestUserServiceUpdateVersionConflict(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+	}{
+		{name: "stale version", version: 2},
+		{name: "unknown newer version", version: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &UserService{repo: versionedUsers{version: 3}, resolver: fakeResolver{}}
+			ctx := requestctx.WithSystemActor(context.Background())
+
+			err := service.Update(ctx, identity.New(), &account.UpdateUserRequest{Name: "renamed", Version: tt.version})
+			var conflict errs.VersionConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("Update() error = %v, want VersionConflictError", err)
+			}
+			if conflict.Expected != tt.version || conflict.Actual != 3 {
+				t.Errorf("conflict = expected %d actual %d, want expected %d actual 3", conflict.Expected, conflict.Actual, tt.version)
+			}
+		})
+	}
+}
+
+func TestUserServiceReadsRequirePermission(t *testing.T) {
+	target := identity.New()
+
+	calls := []struct {
+		name string
+		// self berarti target boleh membaca dirinya sendiri tanpa users:read
+		self bool
+		call func(ctx context.Context, service *UserService) error
+	}{
+		{name: "get all", call: func(ctx context.Context, service *UserService) error {
+			_, _, err := service.GetAll(ctx, "", 10, 1, "", nil)
+			return err
+		}},
+		{name: "get all by role", call: func(ctx context.Context, service *UserService) error {
+			_, _, err := service.GetAllByRole(ctx, identity.New(), "", 10, 1, "", nil)
+			return err
+		}},
+		{name: "get by email", call: func(ctx context.Context, service *UserService) error {
+			_, err := service.GetByEmail(ctx, "user@example.com", nil)
+			return err
+		}},
+		{name: "get by username", call: func(ctx context.Context, service *UserService) error {
+			_, err := service.GetByUsername(ctx, "user", nil)
+			return err
+		}},
+		{name: "get by id", self: true, call: func(ctx context.Context, service *UserService) error {
+			_, err := service.GetByID(ctx, target, nil)
+			return err
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			anonymous := &UserService{resolver: fakeResolver{}}
+			if err := tt.call(context.Background(), anonymous); !errors.Is(err, errs.ErrUnauthorized) {
+				t.Errorf("without actor error = %v, want ErrUnauthorized", err)
+			}
+
+			other := &UserService{resolver: fakeResolver{subject: &policy.Subject{ID: identity.New(), Permissions: []string{account.PermissionUsersWrite}}}}
+			if err := tt.call(context.Background(), other); !errors.Is(err, errs.ErrForbidden) {
+				t.Errorf("without users:read error = %v, want ErrForbidden", err)
+			}
+
+			self := &UserService{resolver: fakeResolver{subject: &policy.Subject{ID: target}}, repo: versionedUsers{version: 1}}
+			err := tt.call(context.Background(), self)
+			if tt.self && err != nil {
+				t.Errorf("self error = %v, want nil", err)
+			}
+			if !tt.self && !errors.Is(err, errs.ErrForbidden) {
+				t.Errorf("self error = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+// listedUsers mengembalikan daftar user tetap
+type listedUsers struct {
+	interfaces.IUserRepository
+	users []*account.User
+}
+
+func (l listedUsers) GetAll(ctx context.Context, search string, limit int, page int, sort string) ([]*account.User, int64, error) {
+	return l.users, int64(len(l.users)), nil
+}
+
+// countingRoles mencatat setiap pemanggilan FindManyByID
+type countingRoles struct {
+	interfaces.IRoleRepository
+	roles []account.Role
+	calls [][]identity.ID
+}
+
+func (c *countingRoles) FindManyByID(ctx context.Context, ids []identity.ID) (*[]account.Role, error) {
+	c.calls = append(c.calls, ids)
+	result := make([]account.Role, 0)
+	for _, role := range c.roles {
+		if slices.Contains(ids, role.ID) {
+			result = append(result, role)
+		}
+	}
+	return &result, nil
+}
+
+func TestUserServiceGetAllLoadsRolesInBatch(t *testing.T) {
+	admin := account.Role{ID: identity.New(), Name: "admin", Permissions: []string{account.PermissionUsersRead}}
+	staff := account.Role{ID: identity.New(), Name: "staff"}
+
+	tests := []struct {
+		name      string
+		fields    []string
+		wantCalls int
+	}{
+		{name: "every field", wantCalls: 1},
+		{name: "role selected", fields: []string{"username", account.UserFieldRole}, wantCalls: 1},
+		{name: "role not selected", fields: []string{"username"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := []*account.User{
+				{ID: identity.New(), Username: "a", Role: admin.ID},
+				{ID: identity.New(), Username: "b", Role: staff.ID},
+				{ID: identity.New(), Username: "c", Role: admin.ID},
+				{ID: identity.New(), Username: "d"},
+			}
+			roles := &countingRoles{roles: []account.Role{admin, staff}}
+			service := &UserService{repo: listedUsers{users: users}, roles: roles, resolver: fakeResolver{}}
+
+			result, _, err := service.GetAll(requestctx.WithSystemActor(context.Background()), "", 10, 1, "", tt.fields)
+			if err != nil {
+				t.Fatalf("GetAll() error = %v", err)
+			}
+			if len(roles.calls) != tt.wantCalls {
+				t.Fatalf("FindManyByID called %d times, want %d", len(roles.calls), tt.wantCalls)
+			}
+			if tt.wantCalls == 0 {
+				return
+			}
+
+			// role yang sama hanya diminta sekali
+			if len(roles.calls[0]) != 2 {
+				t.Errorf("FindManyByID ids = %v, want the 2 distinct roles", roles.calls[0])
+			}
+			wantRoles := []string{"admin", "staff", "admin", ""}
+			for i, response := range result {
+				got := ""
+				if response.Role != nil {
+					got = response.Role.Name
+				}
+				if got != wantRoles[i] {
+					t.Errorf("user %s role = %q, want %q", response.Username, got, wantRoles[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUserServiceGetAllRejectsUnknownField(t *testing.T) {
+	service := &UserService{repo: listedUsers{}, resolver: fakeResolver{}}
+
+	_, _, err := service.GetAll(requestctx.WithSystemActor(context.Background()), "", 10, 1, "", []string{"password"})
+	if !errs.IsValidationError(err) {
+		t.Fatalf("GetAll() error = %v, want a validation error", err)
+	}
+}
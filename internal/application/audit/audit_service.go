@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	accountInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+var errActorRequired = fmt.Errorf("authentication required: %w", errs.ErrUnauthorized)
+
+type AuditService struct {
+	repo     interfaces.IAuditRepository
+	resolver accountInterfaces.IPermissionResolver
+}
+
+func NewAuditService(repo interfaces.IAuditRepository, resolver accountInterfaces.IPermissionResolver) *AuditService {
+	return &AuditService{repo: repo, resolver: resolver}
+}
+
+/**
+ * Record appends an audit entry for a mutation. The actor and request ID
+ * are taken from the context.
+ * @param ctx context.Context
+ * @param action audit.Action
+ * @param aggregateType string
+ * @param aggregateID string
+ * @param changes []audit.Change
+ * @return error
+ */
+func (a *AuditService) Record(ctx context.Context, action audit.Action, aggregateType string, aggregateID string, changes []audit.Change) (err error) {
+	entry := &audit.Entry{
+		Action:        action,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Changes:       changes,
+		RequestID:     requestctx.RequestID(ctx),
+		CreatedAt:     time.Now(),
+	}
+	if actorID, ok := requestctx.ActorID(ctx); ok {
+		entry.ActorID = actorID
+	}
+
+	if err = a.repo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+/**
+ * FindAll retrieves audit entries by filter. The actor needs
+ * PermissionAuditRead.
+ * @param ctx context.Context
+ * @param filter *audit.Filter
+ * @return ([]*audit.Entry, int64, error)
+ */
+func (a *AuditService) FindAll(ctx context.Context, filter *audit.Filter) (result []*audit.Entry, totalItems int64, err error) {
+	if err = a.authorize(ctx, audit.PermissionAuditRead); err != nil {
+		return nil, 0, err
+	}
+
+	entries, totalItems, err := a.repo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	return entries, totalItems, nil
+}
+
+func (a *AuditService) authorize(ctx context.Context, permission string) error {
+	subject, ok, err := a.resolver.ActorSubject(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errActorRequired
+	}
+	if !subject.HasPermission(permission) {
+		return fmt.Errorf("missing permission '%s': %w", permission, errs.ErrForbidden)
+	}
+	return nil
+}
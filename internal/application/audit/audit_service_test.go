@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+)
+
+// fakeResolver mengembalikan subject tetap, nil berarti tidak ada actor
+type fakeResolver struct {
+	subject *policy.Subject
+}
+
+func (f fakeResolver) UserPermissions(ctx context.Context, user *account.User) ([]string, error) {
+	return nil, nil
+}
+
+func (f fakeResolver) ActorSubject(ctx context.Context) (policy.Subject, bool, error) {
+	if f.subject == nil {
+		return policy.Subject{}, false, nil
+	}
+	return *f.subject, true, nil
+}
+
+type memoryEntries struct {
+	entries []*audit.Entry
+}
+
+func (m *memoryEntries) Create(ctx context.Context, entry *audit.Entry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *memoryEntries) FindAll(ctx context.Context, filter *audit.Filter) ([]*audit.Entry, int64, error) {
+	return m.entries, int64(len(m.entries)), nil
+}
+
+func TestAuditServiceFindAllRequiresPermission(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject *policy.Subject
+		wantErr error
+	}{
+		{name: "no actor", wantErr: errs.ErrUnauthorized},
+		{name: "without permission", subject: &policy.Subject{ID: identity.New(), Permissions: []string{account.PermissionUsersRead}}, wantErr: errs.ErrForbidden},
+		{name: "with permission", subject: &policy.Subject{ID: identity.New(), Permissions: []string{audit.PermissionAuditRead}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryEntries{}
+			service := NewAuditService(repo, fakeResolver{subject: tt.subject})
+			// Record tidak diperiksa karena dipanggil oleh service lain
+			if err := service.Record(context.Background(), audit.ActionCreate, audit.AggregateUser, identity.New().String(), nil); err != nil {
+				t.Fatalf("Record() error = %v", err)
+			}
+
+			entries, _, err := service.FindAll(context.Background(), &audit.Filter{})
+			if tt.wantErr == nil {
+				if err != nil || len(entries) != 1 {
+					t.Fatalf("FindAll() = %d entries, error %v, want 1 entry", len(entries), err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) || entries != nil {
+				t.Fatalf("FindAll() = %v, error %v, want %v", entries, err, tt.wantErr)
+			}
+		})
+	}
+}
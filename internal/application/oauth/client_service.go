@@ -0,0 +1,230 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	accountInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+var errActorRequired = fmt.Errorf("authentication required: %w", errs.ErrUnauthorized)
+
+type ClientService struct {
+	clients  interfaces.IClientRepository
+	resolver accountInterfaces.IPermissionResolver
+	auditor  auditInterfaces.IAuditService
+	tx       transaction.IManager
+}
+
+func NewClientService(clients interfaces.IClientRepository, resolver accountInterfaces.IPermissionResolver, auditor auditInterfaces.IAuditService, tx transaction.IManager) *ClientService {
+	return &ClientService{
+		clients:  clients,
+		resolver: resolver,
+		auditor:  auditor,
+		tx:       tx,
+	}
+}
+
+/**
+ * Create registers a client. The secret of a confidential client is only
+ * returned here. A first party client also requires PermissionClientsTrust.
+ * @param ctx context.Context
+ * @param payload *oauth.CreateClientRequest
+ * @return (*oauth.ClientSecretResponse, error)
+ */
+func (c *ClientService) Create(ctx context.Context, payload *oauth.CreateClientRequest) (result *oauth.ClientSecretResponse, err error) {
+	subject, err := c.authorize(ctx, oauth.PermissionClientsWrite)
+	if err != nil {
+		return nil, err
+	}
+	if payload.FirstParty {
+		if err = requirePermission(subject, oauth.PermissionClientsTrust); err != nil {
+			return nil, err
+		}
+	}
+	if err = validateClient(payload); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	client := &oauth.Client{
+		ID:           identity.New(),
+		Name:         strings.TrimSpace(payload.Name),
+		RedirectURIs: payload.RedirectURIs,
+		GrantTypes:   payload.GrantTypes,
+		Scopes:       payload.Scopes,
+		Confidential: payload.Confidential,
+		FirstParty:   payload.FirstParty,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if client.RedirectURIs == nil {
+		client.RedirectURIs = []string{}
+	}
+	if client.Scopes == nil {
+		client.Scopes = []string{}
+	}
+
+	var secret string
+	if client.Confidential {
+		if secret, err = client.GenerateSecret(); err != nil {
+			return nil, fmt.Errorf("failed to generate client secret: %w", errs.ErrInternal)
+		}
+	}
+
+	err = c.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := c.clients.Create(ctx, client); err != nil {
+			return err
+		}
+		return c.auditor.Record(ctx, audit.ActionCreate, audit.AggregateOAuthClient, client.ID.String(), audit.Diff(nil, client))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth client: %w", err)
+	}
+
+	return &oauth.ClientSecretResponse{Client: *client, Secret: secret}, nil
+}
+
+func (c *ClientService) GetByID(ctx context.Context, id identity.ID) (result *oauth.Client, err error) {
+	if _, err = c.authorize(ctx, oauth.PermissionClientsRead); err != nil {
+		return nil, err
+	}
+	return c.clients.FindByID(ctx, id)
+}
+
+func (c *ClientService) GetAll(ctx context.Context, filter *model.PaginationFilter) (result []*oauth.Client, totalItems int64, err error) {
+	if _, err = c.authorize(ctx, oauth.PermissionClientsRead); err != nil {
+		return nil, 0, err
+	}
+	return c.clients.FindAll(ctx, filter)
+}
+
+/**
+ * Delete removes a client. Tokens that were already issued stay valid until
+ * they expire unless they are revoked. A first party client also requires
+ * PermissionClientsTrust.
+ * @param ctx context.Context
+ * @param id identity.ID
+ * @return error
+ */
+func (c *ClientService) Delete(ctx context.Context, id identity.ID) (err error) {
+	subject, err := c.authorize(ctx, oauth.PermissionClientsWrite)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.clients.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	if client.FirstParty {
+		if err = requirePermission(subject, oauth.PermissionClientsTrust); err != nil {
+			return err
+		}
+	}
+
+	err = c.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := c.clients.Delete(ctx, id); err != nil {
+			return err
+		}
+		return c.auditor.Record(ctx, audit.ActionDelete, audit.AggregateOAuthClient, id.String(), audit.Diff(client, nil))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete oauth client: %w", err)
+	}
+	return nil
+}
+
+// authorize mewajibkan actor yang memiliki permission. Pemanggilan tanpa
+// actor ditolak karena client hanya dikelola melalui API admin.
+func (c *ClientService) authorize(ctx context.Context, permission string) (policy.Subject, error) {
+	subject, ok, err := c.resolver.ActorSubject(ctx)
+	if err != nil {
+		return policy.Subject{}, err
+	}
+	if !ok {
+		return policy.Subject{}, errActorRequired
+	}
+	return subject, requirePermission(subject, permission)
+}
+
+func requirePermission(subject policy.Subject, permission string) error {
+	if !subject.HasPermission(permission) {
+		return fmt.Errorf("missing permission '%s': %w", permission, errs.ErrForbidden)
+	}
+	return nil
+}
+
+func validateClient(payload *oauth.CreateClientRequest) error {
+	if strings.TrimSpace(payload.Name) == "" {
+		return errs.ValidationError{Field: "name", Message: "is required"}
+	}
+	if len(payload.GrantTypes) == 0 {
+		return errs.ValidationError{Field: "grant_types", Message: "is required"}
+	}
+
+	for _, grant := range payload.GrantTypes {
+		switch grant {
+		case oauth.GrantAuthorizationCode:
+			if len(payload.RedirectURIs) == 0 {
+				return errs.ValidationError{Field: "redirect_uris", Message: "is required for the authorization_code grant"}
+			}
+		case oauth.GrantClientCredentials:
+			// Client public tidak bisa menyimpan secret sehingga tidak boleh
+			// mendapatkan token atas namanya sendiri
+			if !payload.Confidential {
+				return errs.ValidationError{Field: "grant_types", Message: "client_credentials requires a confidential client"}
+			}
+		default:
+			return errs.ValidationError{Field: "grant_types", Message: fmt.Sprintf("'%s' is not supported", grant)}
+		}
+	}
+
+	for _, uri := range payload.RedirectURIs {
+		if err := validateRedirectURI(uri); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRedirectURI mewajibkan URI absolut tanpa fragment. http hanya
+// diizinkan untuk loopback, dipakai aplikasi native dan pengembangan lokal.
+func validateRedirectURI(uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil || !parsed.IsAbs() || parsed.Fragment != "" {
+		return errs.ValidationError{Field: "redirect_uris", Message: fmt.Sprintf("'%s' must be an absolute URI without fragment", uri)}
+	}
+	if parsed.Scheme == "http" && !isLoopback(parsed.Hostname()) {
+		return errs.ValidationError{Field: "redirect_uris", Message: fmt.Sprintf("'%s' must use https", uri)}
+	}
+	return nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,203 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+)
+
+// fakeResolver mengembalikan subject tetap, nil berarti tidak ada actor
+type fakeResolver struct {
+	subject *policy.Subject
+}
+
+func (f fakeResolver) UserPermissions(ctx context.Context, user *account.User) ([]string, error) {
+	return nil, nil
+}
+
+func (f fakeResolver) ActorSubject(ctx context.Context) (policy.Subject, bool, error) {
+	if f.subject == nil {
+		return policy.Subject{}, false, nil
+	}
+	return *f.subject, true, nil
+}
+
+type memoryClients struct {
+	clients map[identity.ID]*oauth.Client
+}
+
+func (m *memoryClients) Create(ctx context.Context, client *oauth.Client) error {
+	m.clients[client.ID] = client
+	return nil
+}
+
+func (m *memoryClients) FindByID(ctx context.Context, id identity.ID) (*oauth.Client, error) {
+	client, ok := m.clients[id]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	return client, nil
+}
+
+func (m *memoryClients) FindAll(ctx context.Context, filter *model.PaginationFilter) ([]*oauth.Client, int64, error) {
+	result := make([]*oauth.Client, 0, len(m.clients))
+	for _, client := range m.clients {
+		result = append(result, client)
+	}
+	return result, int64(len(result)), nil
+}
+
+func (m *memoryClients) Delete(ctx context.Context, id identity.ID) error {
+	delete(m.clients, id)
+	return nil
+}
+
+type noopAuditor struct{}
+
+func (noopAuditor) Record(ctx context.Context, action audit.Action, aggregateType string, aggregateID string, changes []audit.Change) error {
+	return nil
+}
+
+func (noopAuditor) FindAll(ctx context.Context, filter *audit.Filter) ([]*audit.Entry, int64, error) {
+	return nil, 0, nil
+}
+
+type directTx struct{}
+
+func (directTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func actor(permissions ...string) *policy.Subject {
+	return &policy.Subject{ID: identity.New(), Permissions: permissions}
+}
+
+func TestClientServiceAuthorization(t *testing.T) {
+	firstPartyID, thirdPartyID := identity.New(), identity.New()
+	payload := func(firstParty bool) *oauth.CreateClientRequest {
+		return &oauth.CreateClientRequest{
+			Name:         "app",
+			RedirectURIs: []string{"https://app.example/callback"},
+			GrantTypes:   []string{oauth.GrantAuthorizationCode},
+			FirstParty:   firstParty,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		subject *policy.Subject
+		call    func(ctx context.Context, service *ClientService) error
+		wantErr error
+	}{
+		{
+			name:    "create without actor",
+			subject: nil,
+			call: func(ctx context.Context, service *ClientService) error {
+				_, err := service.Create(ctx, payload(false))
+				return err
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name:    "create without permission",
+			subject: actor(oauth.PermissionClientsRead),
+			call: func(ctx context.Context, service *ClientService) error {
+				_, err := service.Create(ctx, payload(false))
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "create third party",
+			subject: actor(oauth.PermissionClientsWrite),
+			call: func(ctx context.Context, service *ClientService) error {
+				_, err := service.Create(ctx, payload(false))
+				return err
+			},
+		},
+		{
+			name:    "create first party without trust",
+			subject: actor(oauth.PermissionClientsWrite),
+			call: func(ctx context.Context, service *ClientService) error {
+				_, err := service.Create(ctx, payload(true))
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "create first party as admin",
+			subject: actor(oauth.PermissionClientsWrite, oauth.PermissionClientsTrust),
+			call: func(ctx context.Context, service *ClientService) error {
+				_, err := service.Create(ctx, payload(true))
+				return err
+			},
+		},
+		{
+			name:    "list without actor",
+			subject: nil,
+			call: func(ctx context.Context, service *ClientService) error {
+				_, _, err := service.GetAll(ctx, &model.PaginationFilter{})
+				return err
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name:    "get without permission",
+			subject: actor(),
+			call: func(ctx context.Context, service *ClientService) error {
+				_, err := service.GetByID(ctx, thirdPartyID)
+				return err
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "delete without permission",
+			subject: actor(oauth.PermissionClientsRead),
+			call: func(ctx context.Context, service *ClientService) error {
+				return service.Delete(ctx, thirdPartyID)
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "delete first party without trust",
+			subject: actor(oauth.PermissionClientsWrite),
+			call: func(ctx context.Context, service *ClientService) error {
+				return service.Delete(ctx, firstPartyID)
+			},
+			wantErr: errs.ErrForbidden,
+		},
+		{
+			name:    "delete third party",
+			subject: actor(oauth.PermissionClientsWrite),
+			call: func(ctx context.Context, service *ClientService) error {
+				return service.Delete(ctx, thirdPartyID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := &memoryClients{clients: map[identity.ID]*oauth.Client{
+				firstPartyID: {ID: firstPartyID, FirstParty: true},
+				thirdPartyID: {ID: thirdPartyID},
+			}}
+			service := NewClientService(clients, fakeResolver{subject: tt.subject}, noopAuditor{}, directTx{})
+
+			err := tt.call(context.Background(), service)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
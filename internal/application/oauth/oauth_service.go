@@ -0,0 +1,575 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	accountInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+type OAuthConfig struct {
+	// Issuer adalah base URL publik service ini, dipakai sebagai klaim iss
+	// dan prefix seluruh endpoint pada discovery document
+	Issuer         string
+	AccessTokenTTL time.Duration
+	IDTokenTTL     time.Duration
+	CodeTTL        time.Duration
+}
+
+var DefaultOAuthConfig = OAuthConfig{
+	AccessTokenTTL: 15 * time.Minute,
+	IDTokenTTL:     15 * time.Minute,
+	CodeTTL:        time.Minute,
+}
+
+const tokenTypeBearer = "Bearer"
+
+var errInvalidClient = oauth.Error{Code: oauth.ErrorInvalidClient, Description: "client authentication failed"}
+
+// accessTokenClaims adalah payload access token OAuth. aud berisi client ID
+// sehingga token ini tidak diterima sebagai access token login user.
+type accessTokenClaims struct {
+	Issuer    string      `json:"iss"`
+	Subject   string      `json:"sub"`
+	Audience  string      `json:"aud"`
+	ClientID  string      `json:"client_id"`
+	Scope     string      `json:"scope,omitempty"`
+	IssuedAt  int64       `json:"iat"`
+	ExpiresAt int64       `json:"exp"`
+	ID        identity.ID `json:"jti"`
+}
+
+type idTokenClaims struct {
+	Issuer            string `json:"iss"`
+	Subject           string `json:"sub"`
+	Audience          string `json:"aud"`
+	IssuedAt          int64  `json:"iat"`
+	ExpiresAt         int64  `json:"exp"`
+	Nonce             string `json:"nonce,omitempty"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// authorization adalah permintaan authorize yang sudah tervalidasi
+type authorization struct {
+	client      *oauth.Client
+	redirectURI string
+	scopes      []string
+	request     *oauth.AuthorizeRequest
+}
+
+type OAuthService struct {
+	clients  interfaces.IClientRepository
+	codes    interfaces.IAuthorizationCodeRepository
+	consents interfaces.IConsentRepository
+	tokens   interfaces.ITokenRepository
+	users    accountInterfaces.IUserRepository
+	resolver accountInterfaces.IPermissionResolver
+	signer   interfaces.ITokenSigner
+	config   OAuthConfig
+}
+
+func NewOAuthService(clients interfaces.IClientRepository, codes interfaces.IAuthorizationCodeRepository, consents interfaces.IConsentRepository, tokens interfaces.ITokenRepository, users accountInterfaces.IUserRepository, resolver accountInterfaces.IPermissionResolver, signer interfaces.ITokenSigner, config OAuthConfig) *OAuthService {
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+	return &OAuthService{
+		clients:  clients,
+		codes:    codes,
+		consents: consents,
+		tokens:   tokens,
+		users:    users,
+		resolver: resolver,
+		signer:   signer,
+		config:   config,
+	}
+}
+
+/**
+ * Authorize validates an authorization request of the signed in user. A code
+ * is issued right away for first-party clients and for scopes the user
+ * already consented to, otherwise consent is required.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param payload *oauth.AuthorizeRequest
+ * @return (*oauth.AuthorizeResponse, error)
+ */
+func (o *OAuthService) Authorize(ctx context.Context, userID identity.ID, payload *oauth.AuthorizeRequest) (result *oauth.AuthorizeResponse, err error) {
+	auth, redirect, err := o.prepare(ctx, userID, payload)
+	if err != nil || redirect != nil {
+		return redirect, err
+	}
+
+	if !auth.client.FirstParty {
+		consent, err := o.consents.Find(ctx, userID, auth.client.ID)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get oauth consent: %w", err)
+		}
+		if consent == nil || !consent.Covers(auth.scopes) {
+			return &oauth.AuthorizeResponse{
+				ConsentRequired: true,
+				Client:          &oauth.ClientSummary{ID: auth.client.ID.String(), Name: auth.client.Name},
+				Scopes:          auth.scopes,
+			}, nil
+		}
+	}
+
+	return o.issueCode(ctx, userID, auth)
+}
+
+/**
+ * Decide records the consent decision of the user. A denial is returned to
+ * the client as access_denied.
+ * @param ctx context.Context
+ * @param userID identity.ID
+ * @param payload *oauth.ConsentDecisionRequest
+ * @return (*oauth.AuthorizeResponse, error)
+ */
+func (o *OAuthService) Decide(ctx context.Context, userID identity.ID, payload *oauth.ConsentDecisionRequest) (result *oauth.AuthorizeResponse, err error) {
+	auth, redirect, err := o.prepare(ctx, userID, &payload.AuthorizeRequest)
+	if err != nil || redirect != nil {
+		return redirect, err
+	}
+
+	if !payload.Approve {
+		return redirectError(auth.redirectURI, payload.State, oauth.ErrorAccessDenied, "the user denied the request"), nil
+	}
+
+	consent, err := o.consents.Find(ctx, userID, auth.client.ID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get oauth consent: %w", err)
+		}
+		consent = oauth.NewConsent(userID, auth.client.ID)
+	}
+	consent.Grant(auth.scopes)
+	if err = o.consents.Save(ctx, consent); err != nil {
+		return nil, fmt.Errorf("failed to save oauth consent: %w", err)
+	}
+
+	return o.issueCode(ctx, userID, auth)
+}
+
+/**
+ * Token handles the authorization_code and client_credentials grants.
+ * @param ctx context.Context
+ * @param payload *oauth.TokenRequest
+ * @return (*oauth.TokenResponse, error)
+ */
+func (o *OAuthService) Token(ctx context.Context, payload *oauth.TokenRequest) (result *oauth.TokenResponse, err error) {
+	client, err := o.authenticateClient(ctx, payload.ClientCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	switch payload.GrantType {
+	case oauth.GrantAuthorizationCode:
+		return o.exchangeCode(ctx, client, payload)
+	case oauth.GrantClientCredentials:
+		return o.clientCredentials(ctx, client, payload)
+	case "":
+		return nil, oauth.Error{Code: oauth.ErrorInvalidRequest, Description: "grant_type is required"}
+	default:
+		return nil, oauth.Error{Code: oauth.ErrorUnsupportedGrantType}
+	}
+}
+
+/**
+ * Introspect describes a token for a resource server. Only confidential
+ * clients may introspect tokens.
+ * @param ctx context.Context
+ * @param credentials oauth.ClientCredentials
+ * @param accessToken string
+ * @return (*oauth.IntrospectionResponse, error)
+ */
+func (o *OAuthService) Introspect(ctx context.Context, credentials oauth.ClientCredentials, accessToken string) (result *oauth.IntrospectionResponse, err error) {
+	client, err := o.authenticateClient(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if !client.Confidential {
+		return nil, errInvalidClient
+	}
+
+	inactive := &oauth.IntrospectionResponse{Active: false}
+	claims, record, err := o.lookupToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || !record.IsActive(time.Now()) {
+		return inactive, nil
+	}
+
+	return &oauth.IntrospectionResponse{
+		Active:    true,
+		Scope:     claims.Scope,
+		ClientID:  claims.ClientID,
+		Subject:   claims.Subject,
+		TokenType: tokenTypeBearer,
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+		Issuer:    claims.Issuer,
+		Audience:  claims.Audience,
+		JTI:       claims.ID.String(),
+	}, nil
+}
+
+/**
+ * Revoke revokes a token of the authenticated client. Unknown tokens and
+ * tokens of other clients are ignored as required by RFC 7009.
+ * @param ctx context.Context
+ * @param credentials oauth.ClientCredentials
+ * @param accessToken string
+ * @return error
+ */
+func (o *OAuthService) Revoke(ctx context.Context, credentials oauth.ClientCredentials, accessToken string) (err error) {
+	client, err := o.authenticateClient(ctx, credentials)
+	if err != nil {
+		return err
+	}
+
+	_, record, err := o.lookupToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if record == nil || record.ClientID != client.ID || record.RevokedAt != nil {
+		return nil
+	}
+
+	if err = o.tokens.Revoke(ctx, record.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke oauth token: %w", err)
+	}
+	return nil
+}
+
+func (o *OAuthService) Discovery() *oauth.DiscoveryDocument {
+	return &oauth.DiscoveryDocument{
+		Issuer:                            o.config.Issuer,
+		AuthorizationEndpoint:             o.config.Issuer + "/oauth/authorize",
+		TokenEndpoint:                     o.config.Issuer + "/oauth/token",
+		IntrospectionEndpoint:             o.config.Issuer + "/oauth/introspect",
+		RevocationEndpoint:                o.config.Issuer + "/oauth/revoke",
+		JWKSURI:                           o.config.Issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   []string{oauth.ScopeOpenID, oauth.ScopeProfile, oauth.ScopeEmail},
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{oauth.GrantAuthorizationCode, oauth.GrantClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{o.signer.Algorithm()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{oauth.CodeChallengeS256},
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "iat", "exp", "nonce", "name", "preferred_username", "email", "email_verified"},
+	}
+}
+
+func (o *OAuthService) JWKS() *oauth.JSONWebKeySet {
+	return &oauth.JSONWebKeySet{Keys: o.signer.PublicKeys()}
+}
+
+// prepare memvalidasi client dan redirect URI lebih dulu. Error sebelum
+// redirect URI tervalidasi dikembalikan langsung, bukan di-redirect, agar
+// service ini tidak bisa dipakai sebagai open redirector.
+func (o *OAuthService) prepare(ctx context.Context, userID identity.ID, payload *oauth.AuthorizeRequest) (*authorization, *oauth.AuthorizeResponse, error) {
+	clientID, err := identity.Parse(payload.ClientID)
+	if err != nil {
+		return nil, nil, oauth.Error{Code: oauth.ErrorInvalidRequest, Description: "client_id is invalid"}
+	}
+	client, err := o.clients.FindByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, nil, oauth.Error{Code: oauth.ErrorInvalidRequest, Description: "client_id is invalid"}
+		}
+		return nil, nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	redirectURI := payload.RedirectURI
+	if redirectURI == "" && len(client.RedirectURIs) == 1 {
+		redirectURI = client.RedirectURIs[0]
+	}
+	if !client.AllowsRedirect(redirectURI) {
+		return nil, nil, oauth.Error{Code: oauth.ErrorInvalidRequest, Description: "redirect_uri is not registered for this client"}
+	}
+
+	fail := func(code, description string) (*authorization, *oauth.AuthorizeResponse, error) {
+		return nil, redirectError(redirectURI, payload.State, code, description), nil
+	}
+
+	if payload.ResponseType != "code" {
+		return fail(oauth.ErrorUnsupportedResponseType, "only the code response type is supported")
+	}
+	if !client.AllowsGrant(oauth.GrantAuthorizationCode) {
+		return fail(oauth.ErrorUnauthorizedClient, "client may not use the authorization_code grant")
+	}
+	// PKCE wajib untuk semua client, termasuk client confidential
+	if payload.CodeChallenge == "" || payload.CodeChallengeMethod != oauth.CodeChallengeS256 {
+		return fail(oauth.ErrorInvalidRequest, "code_challenge with method S256 is required")
+	}
+
+	scopes := uniqueScopes(oauth.ParseScope(payload.Scope))
+	if !client.AllowsScopes(scopes) {
+		return fail(oauth.ErrorInvalidScope, "scope is not allowed for this client")
+	}
+
+	user, err := o.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, nil, errs.ErrUnauthorized
+		}
+		return nil, nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+	if !user.IsActive {
+		return fail(oauth.ErrorAccessDenied, "user is inactive")
+	}
+
+	return &authorization{client: client, redirectURI: redirectURI, scopes: scopes, request: payload}, nil, nil
+}
+
+func (o *OAuthService) issueCode(ctx context.Context, userID identity.ID, auth *authorization) (*oauth.AuthorizeResponse, error) {
+	code, plain, err := oauth.NewAuthorizationCode(auth.client.ID, userID, auth.redirectURI, auth.scopes, auth.request.CodeChallenge, auth.request.Nonce, o.config.CodeTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate authorization code: %w", errs.ErrInternal)
+	}
+	if err = o.codes.Create(ctx, code); err != nil {
+		return nil, fmt.Errorf("failed to store authorization code: %w", err)
+	}
+
+	params := url.Values{"code": {plain}}
+	if auth.request.State != "" {
+		params.Set("state", auth.request.State)
+	}
+	return &oauth.AuthorizeResponse{RedirectTo: withQuery(auth.redirectURI, params)}, nil
+}
+
+func (o *OAuthService) exchangeCode(ctx context.Context, client *oauth.Client, payload *oauth.TokenRequest) (*oauth.TokenResponse, error) {
+	if !client.AllowsGrant(oauth.GrantAuthorizationCode) {
+		return nil, oauth.Error{Code: oauth.ErrorUnauthorizedClient}
+	}
+	if payload.Code == "" {
+		return nil, oauth.Error{Code: oauth.ErrorInvalidRequest, Description: "code is required"}
+	}
+
+	invalidGrant := oauth.Error{Code: oauth.ErrorInvalidGrant, Description: "authorization code is invalid or has expired"}
+	code, err := o.codes.Consume(ctx, token.Hash(payload.Code))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, invalidGrant
+		}
+		return nil, fmt.Errorf("failed to get authorization code: %w", err)
+	}
+	if code.ClientID != client.ID || code.IsExpired(time.Now()) || code.RedirectURI != payload.RedirectURI {
+		return nil, invalidGrant
+	}
+	if !code.VerifyPKCE(payload.CodeVerifier) {
+		return nil, oauth.Error{Code: oauth.ErrorInvalidGrant, Description: "code_verifier does not match"}
+	}
+
+	user, err := o.users.GetByID(ctx, code.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, invalidGrant
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+	if !user.IsActive {
+		return nil, invalidGrant
+	}
+
+	scopes, err := o.userScopes(ctx, user, code.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := o.issueAccessToken(ctx, client, user.ID.String(), &user.ID, scopes)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(scopes, oauth.ScopeOpenID) {
+		if result.IDToken, err = o.issueIDToken(client, user, scopes, code.Nonce); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (o *OAuthService) clientCredentials(ctx context.Context, client *oauth.Client, payload *oauth.TokenRequest) (*oauth.TokenResponse, error) {
+	if !client.Confidential || !client.AllowsGrant(oauth.GrantClientCredentials) {
+		return nil, oauth.Error{Code: oauth.ErrorUnauthorizedClient}
+	}
+
+	scopes := uniqueScopes(oauth.ParseScope(payload.Scope))
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	if !client.AllowsScopes(scopes) {
+		return nil, oauth.Error{Code: oauth.ErrorInvalidScope}
+	}
+
+	// Scope OIDC menggambarkan user sehingga tidak berlaku tanpa user
+	scopes = slices.DeleteFunc(slices.Clone(scopes), oauth.IsOIDCScope)
+	return o.issueAccessToken(ctx, client, client.ID.String(), nil, scopes)
+}
+
+func (o *OAuthService) issueAccessToken(ctx context.Context, client *oauth.Client, subject string, userID *identity.ID, scopes []string) (*oauth.TokenResponse, error) {
+	now := time.Now()
+	record := &oauth.Token{
+		ID:        identity.New(),
+		ClientID:  client.ID,
+		UserID:    userID,
+		Scopes:    scopes,
+		ExpiresAt: now.Add(o.config.AccessTokenTTL),
+		CreatedAt: now,
+	}
+
+	accessToken, err := o.signer.Sign(accessTokenClaims{
+		Issuer:    o.config.Issuer,
+		Subject:   subject,
+		Audience:  client.ID.String(),
+		ClientID:  client.ID.String(),
+		Scope:     oauth.FormatScope(scopes),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: record.ExpiresAt.Unix(),
+		ID:        record.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	if err = o.tokens.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to store oauth token: %w", err)
+	}
+
+	return &oauth.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   tokenTypeBearer,
+		ExpiresIn:   int64(o.config.AccessTokenTTL.Seconds()),
+		Scope:       oauth.FormatScope(scopes),
+	}, nil
+}
+
+func (o *OAuthService) issueIDToken(client *oauth.Client, user *account.User, scopes []string, nonce string) (string, error) {
+	now := time.Now()
+	claims := idTokenClaims{
+		Issuer:    o.config.Issuer,
+		Subject:   user.ID.String(),
+		Audience:  client.ID.String(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(o.config.IDTokenTTL).Unix(),
+		Nonce:     nonce,
+	}
+	if slices.Contains(scopes, oauth.ScopeProfile) {
+		claims.Name = user.Fullname
+		claims.PreferredUsername = user.Username
+	}
+	if slices.Contains(scopes, oauth.ScopeEmail) {
+		verified := user.IsEmailVerified()
+		claims.Email = user.Email
+		claims.EmailVerified = &verified
+	}
+
+	idToken, err := o.signer.Sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign id token: %w", err)
+	}
+	return idToken, nil
+}
+
+// userScopes membatasi scope non-OIDC dengan permission role user saat ini
+func (o *OAuthService) userScopes(ctx context.Context, user *account.User, requested []string) ([]string, error) {
+	permissions, err := o.resolver.UserPermissions(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		if oauth.IsOIDCScope(scope) || slices.Contains(permissions, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+func (o *OAuthService) authenticateClient(ctx context.Context, credentials oauth.ClientCredentials) (*oauth.Client, error) {
+	clientID, err := identity.Parse(credentials.ClientID)
+	if err != nil {
+		return nil, errInvalidClient
+	}
+	client, err := o.clients.FindByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidClient
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	if client.Confidential {
+		if !client.VerifySecret(credentials.ClientSecret) {
+			return nil, errInvalidClient
+		}
+	} else if credentials.ClientSecret != "" {
+		return nil, errInvalidClient
+	}
+	return client, nil
+}
+
+// lookupToken mengembalikan record nil untuk token yang tidak dikenal
+func (o *OAuthService) lookupToken(ctx context.Context, accessToken string) (*accessTokenClaims, *oauth.Token, error) {
+	var claims accessTokenClaims
+	if err := o.signer.Parse(accessToken, &claims); err != nil {
+		return nil, nil, nil
+	}
+	if claims.Issuer != o.config.Issuer || claims.ClientID == "" || claims.ID.IsNil() {
+		return nil, nil, nil
+	}
+
+	record, err := o.tokens.FindByID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get oauth token: %w", err)
+	}
+	return &claims, record, nil
+}
+
+func redirectError(redirectURI, state, code, description string) *oauth.AuthorizeResponse {
+	params := url.Values{"error": {code}, "error_description": {description}}
+	if state != "" {
+		params.Set("state", state)
+	}
+	return &oauth.AuthorizeResponse{RedirectTo: withQuery(redirectURI, params)}
+}
+
+// withQuery menambahkan parameter tanpa menghapus query yang sudah terdaftar
+func withQuery(uri string, params url.Values) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	query := parsed.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+func uniqueScopes(scopes []string) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	accountInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+var errActorRequired = fmt.Errorf("authentication required: %w", errs.ErrUnauthorized)
+
+// access mengevaluasi policy dengan memperhitungkan role keanggotaan actor
+// pada organization dan team yang dikenai action
+type access struct {
+	members  interfaces.IMemberRepository
+	resolver accountInterfaces.IPermissionResolver
+	policies accountInterfaces.IPolicyEngine
+}
+
+// subject mengembalikan subject actor beserta role keanggotaannya. ok bernilai
+// false hanya untuk system actor, yaitu pemanggilan internal yang menandai
+// context-nya lewat requestctx.WithSystemActor. Tanpa actor selalu ditolak.
+func (a access) subject(ctx context.Context, organizationID identity.ID, team *organization.Team) (subject policy.Subject, ok bool, err error) {
+	subject, ok, err = a.resolver.ActorSubject(ctx)
+	if err != nil {
+		return subject, false, err
+	}
+	if !ok {
+		if requestctx.IsSystemActor(ctx) {
+			return subject, false, nil
+		}
+		return subject, false, errActorRequired
+	}
+
+	organizationRole, err := a.role(ctx, organizationID, subject.ID)
+	if err != nil {
+		return policy.Subject{}, true, err
+	}
+
+	var teamRole organization.MembershipRole
+	if team != nil {
+		member, err := a.members.FindTeamMember(ctx, team.ID, subject.ID)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return policy.Subject{}, true, err
+		}
+		if member != nil {
+			teamRole = member.Role
+		}
+	}
+
+	return organization.WithMembership(subject, organizationRole, teamRole), true, nil
+}
+
+// role mengembalikan role keanggotaan user, kosong bila bukan anggota
+func (a access) role(ctx context.Context, organizationID, userID identity.ID) (organization.MembershipRole, error) {
+	member, err := a.members.Find(ctx, organizationID, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get membership: %w", err)
+	}
+	return member.Role, nil
+}
+
+func (a access) authorize(ctx context.Context, action string, organizationID identity.ID, team *organization.Team, resource policy.Resource, fields ...string) error {
+	subject, ok, err := a.subject(ctx, organizationID, team)
+	if err != nil {
+		return err
+	}
+	// system actor tidak dibatasi policy
+	if !ok {
+		return nil
+	}
+
+	return a.policies.Authorize(policy.Request{Subject: subject, Action: action, Resource: resource, Fields: fields})
+}
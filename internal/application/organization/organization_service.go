@@ -0,0 +1,619 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	accountInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/mail"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type OrganizationConfig struct {
+	// InvitationURL adalah halaman frontend yang menerima query parameter token
+	InvitationURL string
+	InvitationTTL time.Duration
+}
+
+func DefaultOrganizationConfig() OrganizationConfig {
+	return OrganizationConfig{
+		InvitationTTL: 7 * 24 * time.Hour,
+	}
+}
+
+var errInvalidInvitationToken = errs.ValidationError{Field: "token", Message: "is invalid or has expired"}
+
+type OrganizationService struct {
+	organizations interfaces.IOrganizationRepository
+	teams         interfaces.ITeamRepository
+	members       interfaces.IMemberRepository
+	invitations   interfaces.IInvitationRepository
+	users         accountInterfaces.IUserRepository
+	mailer        mail.IMailer
+	auditor       auditInterfaces.IAuditService
+	access        access
+	committer     shared.Committer
+	config        OrganizationConfig
+}
+
+func NewOrganizationService(organizations interfaces.IOrganizationRepository, teams interfaces.ITeamRepository, members interfaces.IMemberRepository, invitations interfaces.IInvitationRepository, users accountInterfaces.IUserRepository, resolver accountInterfaces.IPermissionResolver, policies accountInterfaces.IPolicyEngine, mailer mail.IMailer, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher, config OrganizationConfig) *OrganizationService {
+	return &OrganizationService{
+		organizations: organizations,
+		teams:         teams,
+		members:       members,
+		invitations:   invitations,
+		users:         users,
+		mailer:        mailer,
+		auditor:       auditor,
+		access:        access{members: members, resolver: resolver, policies: policies},
+		committer:     shared.NewCommitter(tx, outbox, dispatcher),
+		config:        config,
+	}
+}
+
+/**
+ * Subscribe removes the memberships of a user once the user is deleted. The
+ * membership of an organization's last owner is kept so the organization is
+ * never left without an owner; a holder of organizations:write can hand the
+ * ownership over to another member.
+ * @param dispatcher *event.Dispatcher
+ */
+func (o *OrganizationService) Subscribe(dispatcher *event.Dispatcher) {
+	event.Subscribe(dispatcher, func(ctx context.Context, deleted account.UserDeleted) error {
+		return o.removeDeletedUser(ctx, deleted.UserID)
+	})
+}
+
+func (o *OrganizationService) removeDeletedUser(ctx context.Context, userID identity.ID) error {
+	return o.committer.WithinTransaction(ctx, func(ctx context.Context) error {
+		memberships, err := o.members.FindByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range memberships {
+			if member.Role == organization.MembershipOwner {
+				err := o.ensureAnotherOwner(ctx, member.OrganizationID, userID)
+				if errors.Is(err, errs.ErrConflict) {
+					slog.WarnContext(ctx, "kept the membership of a deleted user as the last organization owner",
+						slog.String("organization_id", member.OrganizationID.String()), slog.String("user_id", userID.String()))
+					continue
+				}
+				if err != nil {
+					return err
+				}
+			}
+			if err := o.members.Delete(ctx, member.OrganizationID, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (o *OrganizationService) Create(ctx context.Context, payload *organization.CreateOrganizationRequest) (result *organization.OrganizationResponse, err error) {
+	ownerID, ok := requestctx.ActorID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("creating an organization requires an authenticated user: %w", errs.ErrForbidden)
+	}
+
+	org, owner, err := organization.NewOrganization(payload.Name, payload.Description, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.committer.Commit(ctx, shared.Sources{org, owner}, func(ctx context.Context) error {
+		if err := o.organizations.Create(ctx, org); err != nil {
+			return err
+		}
+		if err := o.members.Create(ctx, owner); err != nil {
+			return err
+		}
+		return o.auditor.Record(ctx, audit.ActionCreate, audit.AggregateOrganization, org.ID.String(), audit.Diff(nil, org))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+	return org.ToOrganizationResponse(owner.Role), nil
+}
+
+func (o *OrganizationService) FindByID(ctx context.Context, id identity.ID) (result *organization.OrganizationResponse, err error) {
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, ok, err := o.access.subject(ctx, org.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return org.ToOrganizationResponse(""), nil
+	}
+
+	request := policy.Request{Subject: subject, Action: organization.PolicyOrganizationRead, Resource: org.PolicyResource(identity.ID{})}
+	if err := o.access.policies.Authorize(request); err != nil {
+		return nil, err
+	}
+	return org.ToOrganizationResponse(organization.MembershipRole(subject.Attributes[organization.AttributeOrganizationRole])), nil
+}
+
+func (o *OrganizationService) FindAll(ctx context.Context, filter *model.PaginationFilter) (result []*organization.OrganizationResponse, totalItems int64, err error) {
+	query := &organization.OrganizationFilter{PaginationFilter: *filter}
+
+	subject, ok, err := o.access.resolver.ActorSubject(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok && !requestctx.IsSystemActor(ctx) {
+		return nil, 0, errActorRequired
+	}
+	// Tanpa permission organizations:read user hanya melihat organization yang diikutinya
+	if ok && !slices.Contains(subject.Permissions, organization.PermissionOrganizationsRead) {
+		query.MemberID = subject.ID
+	}
+
+	organizations, totalItems, err := o.organizations.FindAll(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	result = make([]*organization.OrganizationResponse, 0, len(organizations))
+	for _, org := range organizations {
+		var membership organization.MembershipRole
+		if ok {
+			if membership, err = o.access.role(ctx, org.ID, subject.ID); err != nil {
+				return nil, 0, err
+			}
+		}
+		result = append(result, org.ToOrganizationResponse(membership))
+	}
+	return result, totalItems, nil
+}
+
+func (o *OrganizationService) Update(ctx context.Context, id identity.ID, payload *organization.UpdateOrganizationRequest) (result *organization.OrganizationResponse, err error) {
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Version != 0 && payload.Version != org.Version {
+		return nil, errs.VersionConflictError{Resource: "organization", ID: id, Expected: payload.Version, Actual: org.Version}
+	}
+
+	if err = o.access.authorize(ctx, organization.PolicyOrganizationUpdate, org.ID, nil, org.PolicyResource(identity.ID{})); err != nil {
+		return nil, err
+	}
+
+	before := *org
+	changed, err := org.Update(payload.Name, payload.Description)
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return o.FindByID(ctx, id)
+	}
+
+	err = o.committer.Commit(ctx, org, func(ctx context.Context) error {
+		if err := o.organizations.Update(ctx, org); err != nil {
+			return err
+		}
+		return o.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateOrganization, org.ID.String(), audit.Diff(&before, org))
+	})
+	if err != nil {
+		if errs.IsVersionConflict(err) || errors.Is(err, errs.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+	return o.FindByID(ctx, id)
+}
+
+func (o *OrganizationService) Delete(ctx context.Context, id identity.ID) (err error) {
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = o.access.authorize(ctx, organization.PolicyOrganizationDelete, org.ID, nil, org.PolicyResource(identity.ID{})); err != nil {
+		return err
+	}
+
+	org.Delete()
+	err = o.committer.Commit(ctx, org, func(ctx context.Context) error {
+		if err := o.organizations.Delete(ctx, org.ID); err != nil {
+			return err
+		}
+		return o.auditor.Record(ctx, audit.ActionDelete, audit.AggregateOrganization, org.ID.String(), audit.Diff(org, nil))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	return nil
+}
+
+func (o *OrganizationService) FindMembers(ctx context.Context, id identity.ID, filter *model.PaginationFilter) (result []*organization.MemberResponse, totalItems int64, err error) {
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = o.access.authorize(ctx, organization.PolicyOrganizationRead, org.ID, nil, org.PolicyResource(identity.ID{})); err != nil {
+		return nil, 0, err
+	}
+
+	members, totalItems, err := o.members.FindAll(ctx, org.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get organization members: %w", err)
+	}
+
+	result = make([]*organization.MemberResponse, 0, len(members))
+	for _, member := range members {
+		result = append(result, member.ToMemberResponse())
+	}
+	return result, totalItems, nil
+}
+
+func (o *OrganizationService) SetMemberRole(ctx context.Context, id identity.ID, userID identity.ID, payload *organization.SetMemberRequest) (result *organization.MemberResponse, err error) {
+	role, err := organization.ParseMembershipRole(string(payload.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	member, err := o.members.Find(ctx, org.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Memberi atau mencabut role owner hanya boleh dilakukan owner
+	var fields []string
+	if role == organization.MembershipOwner || member.Role == organization.MembershipOwner {
+		fields = append(fields, organization.FieldOwner)
+	}
+	if err = o.access.authorize(ctx, organization.PolicyMembersManage, org.ID, nil, org.PolicyResource(identity.ID{}), fields...); err != nil {
+		return nil, err
+	}
+
+	demoted := member.Role == organization.MembershipOwner && role != organization.MembershipOwner
+
+	before := *member
+	if !member.ChangeRole(role) {
+		return member.ToMemberResponse(), nil
+	}
+
+	err = o.committer.Commit(ctx, member, func(ctx context.Context) error {
+		if demoted {
+			if err := o.ensureAnotherOwner(ctx, org.ID, member.UserID); err != nil {
+				return err
+			}
+		}
+		if err := o.members.Update(ctx, member); err != nil {
+			return err
+		}
+		changes := append(audit.Diff(&before, member), audit.Change{Field: "user_id", After: member.UserID.String()})
+		return o.auditor.Record(ctx, audit.ActionChangeMember, audit.AggregateOrganization, org.ID.String(), changes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) || errors.Is(err, errs.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to change organization member role: %w", err)
+	}
+	return member.ToMemberResponse(), nil
+}
+
+func (o *OrganizationService) RemoveMember(ctx context.Context, id identity.ID, userID identity.ID) (err error) {
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	member, err := o.members.Find(ctx, org.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	if member.Role == organization.MembershipOwner {
+		fields = append(fields, organization.FieldOwner)
+	}
+	if err = o.access.authorize(ctx, organization.PolicyMemberRemove, org.ID, nil, org.PolicyResource(member.UserID), fields...); err != nil {
+		return err
+	}
+
+	member.Remove()
+	err = o.committer.Commit(ctx, member, func(ctx context.Context) error {
+		if member.Role == organization.MembershipOwner {
+			if err := o.ensureAnotherOwner(ctx, org.ID, member.UserID); err != nil {
+				return err
+			}
+		}
+		if err := o.members.Delete(ctx, org.ID, member.UserID); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "user_id", Before: member.UserID.String()}, {Field: "role", Before: string(member.Role)}}
+		return o.auditor.Record(ctx, audit.ActionRemoveMember, audit.AggregateOrganization, org.ID.String(), changes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) || errors.Is(err, errs.ErrConflict) {
+			return err
+		}
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+	return nil
+}
+
+func (o *OrganizationService) Invite(ctx context.Context, id identity.ID, payload *organization.CreateInvitationRequest) (result *organization.InvitationResponse, err error) {
+	role, err := organization.ParseMembershipRole(string(payload.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	if role == organization.MembershipOwner {
+		fields = append(fields, organization.FieldOwner)
+	}
+	if err = o.access.authorize(ctx, organization.PolicyMembersManage, org.ID, nil, org.PolicyResource(identity.ID{}), fields...); err != nil {
+		return nil, err
+	}
+
+	if !payload.TeamID.IsNil() {
+		team, err := o.teams.FindByID(ctx, payload.TeamID)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+		if team == nil || team.OrganizationID != org.ID {
+			return nil, errs.ValidationError{Field: "team_id", Message: "must be a team of the organization"}
+		}
+	}
+
+	invitedBy, _ := requestctx.ActorID(ctx)
+	invitation, plain, err := organization.NewInvitation(org.ID, payload.TeamID, payload.Email, role, invitedBy, o.config.InvitationTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", errs.ErrInternal)
+	}
+
+	err = o.committer.Commit(ctx, invitation, func(ctx context.Context) error {
+		if err := o.invitations.Create(ctx, invitation); err != nil {
+			return err
+		}
+		return o.auditor.Record(ctx, audit.ActionInvite, audit.AggregateInvitation, invitation.ID.String(), audit.Diff(nil, invitation))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	message := mail.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to join %s", org.Name),
+		Body: fmt.Sprintf("You have been invited to join %s as %s. The invitation expires in %s.\n\n%s",
+			org.Name, role, o.config.InvitationTTL, invitationLink(o.config.InvitationURL, plain)),
+	}
+	if err := o.mailer.Send(ctx, message); err != nil {
+		slog.ErrorContext(ctx, "failed to send organization invitation mail", slog.Any("error", err))
+	}
+	return invitation.ToInvitationResponse(), nil
+}
+
+func (o *OrganizationService) FindInvitations(ctx context.Context, id identity.ID, filter *organization.InvitationFilter) (result []*organization.InvitationResponse, totalItems int64, err error) {
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = o.access.authorize(ctx, organization.PolicyMembersManage, org.ID, nil, org.PolicyResource(identity.ID{})); err != nil {
+		return nil, 0, err
+	}
+
+	invitations, totalItems, err := o.invitations.FindAll(ctx, org.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	result = make([]*organization.InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		result = append(result, invitation.ToInvitationResponse())
+	}
+	return result, totalItems, nil
+}
+
+func (o *OrganizationService) RevokeInvitation(ctx context.Context, id identity.ID, invitationID identity.ID) (err error) {
+	invitation, err := o.invitations.FindByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.OrganizationID != id {
+		return fmt.Errorf("invitation with ID '%s' not found: %w", invitationID, errs.ErrNotFound)
+	}
+
+	org, err := o.organizations.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	if invitation.Role == organization.MembershipOwner {
+		fields = append(fields, organization.FieldOwner)
+	}
+	if err = o.access.authorize(ctx, organization.PolicyMembersManage, org.ID, nil, org.PolicyResource(identity.ID{}), fields...); err != nil {
+		return err
+	}
+
+	before := *invitation
+	if err = invitation.Revoke(time.Now()); err != nil {
+		return err
+	}
+
+	err = o.committer.Commit(ctx, invitation, func(ctx context.Context) error {
+		if err := o.invitations.Update(ctx, invitation); err != nil {
+			return err
+		}
+		return o.auditor.Record(ctx, audit.ActionRevoke, audit.AggregateInvitation, invitation.ID.String(), audit.Diff(&before, invitation))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return err
+		}
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	return nil
+}
+
+func (o *OrganizationService) AcceptInvitation(ctx context.Context, payload *organization.AcceptInvitationRequest) (result *organization.AcceptInvitationResponse, err error) {
+	userID, ok := requestctx.ActorID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("accepting an invitation requires an authenticated user: %w", errs.ErrForbidden)
+	}
+
+	invitation, err := o.invitations.FindByHash(ctx, token.Hash(payload.Token))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errInvalidInvitationToken
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	user, err := o.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+	// Email yang belum diverifikasi belum membuktikan kepemilikan inbox undangan
+	if !user.IsEmailVerified() {
+		return nil, fmt.Errorf("email must be verified before accepting an invitation: %w", errs.ErrForbidden)
+	}
+
+	before := *invitation
+	if err = invitation.Accept(user.ID, user.Email, time.Now()); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, errInvalidInvitationToken
+		}
+		return nil, err
+	}
+
+	org, err := o.organizations.FindByID(ctx, invitation.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates := shared.Sources{invitation}
+
+	// User yang sudah menjadi anggota tetap dengan role lamanya
+	member, err := o.members.Find(ctx, org.ID, user.ID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+	joined := member == nil
+	if joined {
+		member = organization.NewMember(org.ID, user.ID, invitation.Role, invitation.InvitedBy)
+		aggregates = append(aggregates, member)
+	}
+
+	var team *organization.Team
+	var teamMember *organization.TeamMember
+	if !invitation.TeamID.IsNil() {
+		// Team yang sudah dihapus setelah undangan dikirim dilewati saja
+		if team, err = o.teams.FindByID(ctx, invitation.TeamID); err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+		if team != nil {
+			existing, err := o.members.FindTeamMember(ctx, team.ID, user.ID)
+			if err != nil && !errors.Is(err, errs.ErrNotFound) {
+				return nil, err
+			}
+			if existing == nil {
+				teamMember = organization.NewTeamMember(team, user.ID, organization.MembershipMember, invitation.InvitedBy)
+				aggregates = append(aggregates, teamMember)
+			}
+		}
+	}
+
+	err = o.committer.Commit(ctx, aggregates, func(ctx context.Context) error {
+		if err := o.invitations.Update(ctx, invitation); err != nil {
+			return err
+		}
+		if joined {
+			if err := o.members.Create(ctx, member); err != nil {
+				return err
+			}
+		}
+		if teamMember != nil {
+			if err := o.members.CreateTeamMember(ctx, teamMember); err != nil {
+				return err
+			}
+		}
+		return o.auditor.Record(ctx, audit.ActionAccept, audit.AggregateInvitation, invitation.ID.String(), audit.Diff(&before, invitation))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, errInvalidInvitationToken
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	result = &organization.AcceptInvitationResponse{Organization: org.ToOrganizationResponse(member.Role)}
+	if team != nil {
+		result.Team = team.ToTeamResponse()
+	}
+	return result, nil
+}
+
+// ensureAnotherOwner mencegah organization kehilangan owner terakhirnya. Owner
+// dikunci sampai transaksi selesai sehingga wajib dipanggil di dalam transaksi,
+// dua perubahan bersamaan tidak bisa sama-sama lolos pemeriksaan.
+func (o *OrganizationService) ensureAnotherOwner(ctx context.Context, organizationID, userID identity.ID) error {
+	owners, err := o.members.LockByRole(ctx, organizationID, organization.MembershipOwner)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range owners {
+		if ownerID != userID {
+			return nil
+		}
+	}
+	return fmt.Errorf("organization must keep at least one owner: %w", errs.ErrConflict)
+}
+
+func invitationLink(base string, plain string) string {
+	link, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(plain)
+	}
+
+	query := link.Query()
+	query.Set("token", plain)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
@@ -0,0 +1,232 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// fakeResolver mengembalikan subject tetap, nil berarti tidak ada actor
+type fakeResolver struct {
+	subject *policy.Subject
+}
+
+func (f fakeResolver) UserPermissions(ctx context.Context, user *account.User) ([]string, error) {
+	return nil, nil
+}
+
+func (f fakeResolver) ActorSubject(ctx context.Context) (policy.Subject, bool, error) {
+	if f.subject == nil {
+		return policy.Subject{}, false, nil
+	}
+	return *f.subject, true, nil
+}
+
+type txKey struct{}
+
+// fakeTx menandai ctx agar repository palsu bisa memeriksa pemanggilan di dalam transaksi
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+type organizationStore struct {
+	interfaces.IOrganizationRepository
+	org *organization.Organization
+}
+
+func (o organizationStore) FindByID(ctx context.Context, id identity.ID) (*organization.Organization, error) {
+	if id != o.org.ID {
+		return nil, errs.ErrNotFound
+	}
+	return o.org, nil
+}
+
+func (o organizationStore) FindAll(ctx context.Context, filter *organization.OrganizationFilter) ([]*organization.Organization, int64, error) {
+	return []*organization.Organization{o.org}, 1, nil
+}
+
+// memberStore menyimpan keanggotaan di memori. LockByRole menolak dipanggil
+// di luar transaksi seperti SELECT ... FOR UPDATE tanpa transaksi yang tidak
+// mengunci apa pun.
+type memberStore struct {
+	interfaces.IMemberRepository
+	members []*organization.Member
+}
+
+func (m *memberStore) Find(ctx context.Context, organizationID, userID identity.ID) (*organization.Member, error) {
+	for _, member := range m.members {
+		if member.OrganizationID == organizationID && member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, errs.ErrNotFound
+}
+
+func (m *memberStore) FindByUser(ctx context.Context, userID identity.ID) (result []*organization.Member, err error) {
+	for _, member := range m.members {
+		if member.UserID == userID {
+			result = append(result, member)
+		}
+	}
+	return result, nil
+}
+
+func (m *memberStore) LockByRole(ctx context.Context, organizationID identity.ID, role organization.MembershipRole) (userIDs []identity.ID, err error) {
+	if ctx.Value(txKey{}) == nil {
+		return nil, errors.New("LockByRole called outside a transaction")
+	}
+	for _, member := range m.members {
+		if member.OrganizationID == organizationID && member.Role == role {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (m *memberStore) Delete(ctx context.Context, organizationID, userID identity.ID) error {
+	m.members = slices.DeleteFunc(m.members, func(member *organization.Member) bool {
+		return member.OrganizationID == organizationID && member.UserID == userID
+	})
+	return nil
+}
+
+func (m *memberStore) FindTeamMember(ctx context.Context, teamID, userID identity.ID) (*organization.TeamMember, error) {
+	return nil, errs.ErrNotFound
+}
+
+func newAccess(members interfaces.IMemberRepository, subject *policy.Subject) access {
+	return access{members: members, resolver: fakeResolver{subject: subject}, policies: policy.NewEngine(organization.Policies...)}
+}
+
+func TestAccessAuthorize(t *testing.T) {
+	org, owner, err := organization.NewOrganization("Acme", "", identity.New())
+	if err != nil {
+		t.Fatalf("NewOrganization() error = %v", err)
+	}
+	members := &memberStore{members: []*organization.Member{owner}}
+
+	tests := []struct {
+		name    string
+		system  bool
+		subject *policy.Subject
+		wantErr error
+	}{
+		{name: "no actor", wantErr: errs.ErrUnauthorized},
+		{name: "system actor", system: true},
+		{name: "owner", subject: &policy.Subject{ID: owner.UserID}},
+		{name: "non member", subject: &policy.Subject{ID: identity.New()}, wantErr: errs.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.system {
+				ctx = requestctx.WithSystemActor(ctx)
+			}
+			err := newAccess(members, tt.subject).authorize(ctx, organization.PolicyOrganizationUpdate, org.ID, nil, org.PolicyResource(identity.ID{}))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("authorize() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("authorize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrganizationServiceReadsRequireActor(t *testing.T) {
+	org, owner, err := organization.NewOrganization("Acme", "", identity.New())
+	if err != nil {
+		t.Fatalf("NewOrganization() error = %v", err)
+	}
+	members := &memberStore{members: []*organization.Member{owner}}
+	service := &OrganizationService{organizations: organizationStore{org: org}, members: members, access: newAccess(members, nil)}
+
+	if _, err := service.FindByID(context.Background(), org.ID); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("FindByID() error = %v, want ErrUnauthorized", err)
+	}
+	if _, _, err := service.FindAll(context.Background(), &model.PaginationFilter{Page: 1, Limit: 10}); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("FindAll() error = %v, want ErrUnauthorized", err)
+	}
+
+	ctx := requestctx.WithSystemActor(context.Background())
+	if _, err := service.FindByID(ctx, org.ID); err != nil {
+		t.Errorf("FindByID() as system actor error = %v", err)
+	}
+	if _, _, err := service.FindAll(ctx, &model.PaginationFilter{Page: 1, Limit: 10}); err != nil {
+		t.Errorf("FindAll() as system actor error = %v", err)
+	}
+}
+
+func TestEnsureAnotherOwner(t *testing.T) {
+	orgID, first, second := identity.New(), identity.New(), identity.New()
+
+	tests := []struct {
+		name    string
+		owners  []identity.ID
+		userID  identity.ID
+		wantErr error
+	}{
+		{name: "last owner", owners: []identity.ID{first}, userID: first, wantErr: errs.ErrConflict},
+		{name: "another owner", owners: []identity.ID{first, second}, userID: first},
+		{name: "target no longer owner", owners: []identity.ID{second}, userID: first},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := &memberStore{}
+			for _, ownerID := range tt.owners {
+				members.members = append(members.members, organization.NewMember(orgID, ownerID, organization.MembershipOwner, identity.ID{}))
+			}
+			service := &OrganizationService{members: members, committer: shared.NewCommitter(fakeTx{}, nil, nil)}
+
+			err := service.committer.WithinTransaction(context.Background(), func(ctx context.Context) error {
+				return service.ensureAnotherOwner(ctx, orgID, tt.userID)
+			})
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ensureAnotherOwner() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ensureAnotherOwner() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRemoveDeletedUserKeepsLastOwner(t *testing.T) {
+	userID, coOwner := identity.New(), identity.New()
+	soleOwned, coOwned, joined := identity.New(), identity.New(), identity.New()
+
+	members := &memberStore{members: []*organization.Member{
+		organization.NewMember(soleOwned, userID, organization.MembershipOwner, identity.ID{}),
+		organization.NewMember(coOwned, userID, organization.MembershipOwner, identity.ID{}),
+		organization.NewMember(coOwned, coOwner, organization.MembershipOwner, identity.ID{}),
+		organization.NewMember(joined, userID, organization.MembershipMember, identity.ID{}),
+	}}
+	service := &OrganizationService{members: members, committer: shared.NewCommitter(fakeTx{}, nil, nil)}
+
+	if err := service.removeDeletedUser(context.Background(), userID); err != nil {
+		t.Fatalf("removeDeletedUser() error = %v", err)
+	}
+
+	remaining, _ := members.FindByUser(context.Background(), userID)
+	if len(remaining) != 1 || remaining[0].OrganizationID != soleOwned {
+		t.Fatalf("remaining memberships = %+v, want only the sole owned organization", remaining)
+	}
+	if _, err := members.Find(context.Background(), coOwned, coOwner); err != nil {
+		t.Errorf("co-owner membership removed: %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/application/shared"
+	accountInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	auditInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization/interfaces"
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type TeamService struct {
+	organizations interfaces.IOrganizationRepository
+	teams         interfaces.ITeamRepository
+	members       interfaces.IMemberRepository
+	auditor       auditInterfaces.IAuditService
+	access        access
+	committer     shared.Committer
+}
+
+func NewTeamService(organizations interfaces.IOrganizationRepository, teams interfaces.ITeamRepository, members interfaces.IMemberRepository, resolver accountInterfaces.IPermissionResolver, policies accountInterfaces.IPolicyEngine, auditor auditInterfaces.IAuditService, tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher) *TeamService {
+	return &TeamService{
+		organizations: organizations,
+		teams:         teams,
+		members:       members,
+		auditor:       auditor,
+		access:        access{members: members, resolver: resolver, policies: policies},
+		committer:     shared.NewCommitter(tx, outbox, dispatcher),
+	}
+}
+
+func (t *TeamService) Create(ctx context.Context, organizationID identity.ID, payload *organization.CreateTeamRequest) (result *organization.TeamResponse, err error) {
+	org, err := t.organizations.FindByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.access.authorize(ctx, organization.PolicyTeamsManage, org.ID, nil, org.PolicyResource(identity.ID{})); err != nil {
+		return nil, err
+	}
+
+	team, err := organization.NewTeam(org.ID, payload.Name, payload.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.committer.Commit(ctx, team, func(ctx context.Context) error {
+		if err := t.teams.Create(ctx, team); err != nil {
+			return err
+		}
+		return t.auditor.Record(ctx, audit.ActionCreate, audit.AggregateTeam, team.ID.String(), audit.Diff(nil, team))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create team: %w", err)
+	}
+	return team.ToTeamResponse(), nil
+}
+
+func (t *TeamService) FindByID(ctx context.Context, id identity.ID) (result *organization.TeamResponse, err error) {
+	team, err := t.teams.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.access.authorize(ctx, organization.PolicyTeamRead, team.OrganizationID, team, team.PolicyResource(identity.ID{})); err != nil {
+		return nil, err
+	}
+	return team.ToTeamResponse(), nil
+}
+
+func (t *TeamService) FindAll(ctx context.Context, organizationID identity.ID, filter *model.PaginationFilter) (result []*organization.TeamResponse, totalItems int64, err error) {
+	org, err := t.organizations.FindByID(ctx, organizationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = t.access.authorize(ctx, organization.PolicyOrganizationRead, org.ID, nil, org.PolicyResource(identity.ID{})); err != nil {
+		return nil, 0, err
+	}
+
+	teams, totalItems, err := t.teams.FindAll(ctx, org.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	result = make([]*organization.TeamResponse, 0, len(teams))
+	for _, team := range teams {
+		result = append(result, team.ToTeamResponse())
+	}
+	return result, totalItems, nil
+}
+
+func (t *TeamService) Update(ctx context.Context, id identity.ID, payload *organization.UpdateTeamRequest) (result *organization.TeamResponse, err error) {
+	team, err := t.teams.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.access.authorize(ctx, organization.PolicyTeamsManage, team.OrganizationID, team, team.PolicyResource(identity.ID{})); err != nil {
+		return nil, err
+	}
+
+	before := *team
+	changed, err := team.Update(payload.Name, payload.Description)
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return team.ToTeamResponse(), nil
+	}
+
+	err = t.committer.Commit(ctx, team, func(ctx context.Context) error {
+		if err := t.teams.Update(ctx, team); err != nil {
+			return err
+		}
+		return t.auditor.Record(ctx, audit.ActionUpdate, audit.AggregateTeam, team.ID.String(), audit.Diff(&before, team))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) || errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update team: %w", err)
+	}
+	return team.ToTeamResponse(), nil
+}
+
+func (t *TeamService) Delete(ctx context.Context, id identity.ID) (err error) {
+	team, err := t.teams.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = t.access.authorize(ctx, organization.PolicyTeamsManage, team.OrganizationID, team, team.PolicyResource(identity.ID{})); err != nil {
+		return err
+	}
+
+	team.Delete()
+	err = t.committer.Commit(ctx, team, func(ctx context.Context) error {
+		if err := t.teams.Delete(ctx, team.ID); err != nil {
+			return err
+		}
+		return t.auditor.Record(ctx, audit.ActionDelete, audit.AggregateTeam, team.ID.String(), audit.Diff(team, nil))
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+	return nil
+}
+
+func (t *TeamService) FindMembers(ctx context.Context, id identity.ID, filter *model.PaginationFilter) (result []*organization.MemberResponse, totalItems int64, err error) {
+	team, err := t.teams.FindByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = t.access.authorize(ctx, organization.PolicyTeamRead, team.OrganizationID, team, team.PolicyResource(identity.ID{})); err != nil {
+		return nil, 0, err
+	}
+
+	members, totalItems, err := t.members.FindAllTeamMembers(ctx, team.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	result = make([]*organization.MemberResponse, 0, len(members))
+	for _, member := range members {
+		result = append(result, member.ToMemberResponse())
+	}
+	return result, totalItems, nil
+}
+
+func (t *TeamService) SetMember(ctx context.Context, id identity.ID, userID identity.ID, payload *organization.SetMemberRequest) (result *organization.MemberResponse, err error) {
+	role, err := organization.ParseMembershipRole(string(payload.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := t.teams.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := t.members.FindTeamMember(ctx, team.ID, userID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+
+	var fields []string
+	if role == organization.MembershipOwner || (member != nil && member.Role == organization.MembershipOwner) {
+		fields = append(fields, organization.FieldOwner)
+	}
+	if err = t.access.authorize(ctx, organization.PolicyTeamMembersManage, team.OrganizationID, team, team.PolicyResource(identity.ID{}), fields...); err != nil {
+		return nil, err
+	}
+
+	if member == nil {
+		// Hanya anggota organization pemilik team yang bisa ditambahkan
+		if _, err := t.members.Find(ctx, team.OrganizationID, userID); err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, errs.ValidationError{Field: "user_id", Message: "must be a member of the organization"}
+			}
+			return nil, err
+		}
+		return t.addMember(ctx, team, userID, role)
+	}
+
+	before := *member
+	if !member.ChangeRole(team, role) {
+		return member.ToMemberResponse(), nil
+	}
+
+	err = t.committer.Commit(ctx, member, func(ctx context.Context) error {
+		if err := t.members.UpdateTeamMember(ctx, member); err != nil {
+			return err
+		}
+		changes := append(audit.Diff(&before, member), audit.Change{Field: "user_id", After: member.UserID.String()})
+		return t.auditor.Record(ctx, audit.ActionChangeMember, audit.AggregateTeam, team.ID.String(), changes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to change team member role: %w", err)
+	}
+	return member.ToMemberResponse(), nil
+}
+
+func (t *TeamService) addMember(ctx context.Context, team *organization.Team, userID identity.ID, role organization.MembershipRole) (*organization.MemberResponse, error) {
+	addedBy, _ := requestctx.ActorID(ctx)
+	member := organization.NewTeamMember(team, userID, role, addedBy)
+
+	err := t.committer.Commit(ctx, member, func(ctx context.Context) error {
+		if err := t.members.CreateTeamMember(ctx, member); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "user_id", After: userID.String()}, {Field: "role", After: string(role)}}
+		return t.auditor.Record(ctx, audit.ActionAddMember, audit.AggregateTeam, team.ID.String(), changes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to add team member: %w", err)
+	}
+	return member.ToMemberResponse(), nil
+}
+
+func (t *TeamService) RemoveMember(ctx context.Context, id identity.ID, userID identity.ID) (err error) {
+	team, err := t.teams.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	member, err := t.members.FindTeamMember(ctx, team.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	if member.Role == organization.MembershipOwner {
+		fields = append(fields, organization.FieldOwner)
+	}
+	if err = t.access.authorize(ctx, organization.PolicyTeamMemberRemove, team.OrganizationID, team, team.PolicyResource(member.UserID), fields...); err != nil {
+		return err
+	}
+
+	member.Remove(team)
+	err = t.committer.Commit(ctx, member, func(ctx context.Context) error {
+		if err := t.members.DeleteTeamMember(ctx, team.ID, member.UserID); err != nil {
+			return err
+		}
+		changes := []audit.Change{{Field: "user_id", Before: member.UserID.String()}, {Field: "role", Before: string(member.Role)}}
+		return t.auditor.Record(ctx, audit.ActionRemoveMember, audit.AggregateTeam, team.ID.String(), changes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type teamStore struct {
+	interfaces.ITeamRepository
+	team *organization.Team
+}
+
+func (t teamStore) FindByID(ctx context.Context, id identity.ID) (*organization.Team, error) {
+	if id != t.team.ID {
+		return nil, errs.ErrNotFound
+	}
+	return t.team, nil
+}
+
+func TestTeamServiceRequiresActor(t *testing.T) {
+	org, owner, err := organization.NewOrganization("Acme", "", identity.New())
+	if err != nil {
+		t.Fatalf("NewOrganization() error = %v", err)
+	}
+	team, err := organization.NewTeam(org.ID, "Platform", "")
+	if err != nil {
+		t.Fatalf("NewTeam() error = %v", err)
+	}
+	members := &memberStore{members: []*organization.Member{owner}}
+	service := &TeamService{organizations: organizationStore{org: org}, teams: teamStore{team: team}, members: members, access: newAccess(members, nil)}
+
+	calls := map[string]func(ctx context.Context) error{
+		"create": func(ctx context.Context) error {
+			_, err := service.Create(ctx, org.ID, &organization.CreateTeamRequest{Name: "Data"})
+			return err
+		},
+		"find by id": func(ctx context.Context) error {
+			_, err := service.FindByID(ctx, team.ID)
+			return err
+		},
+		"find all": func(ctx context.Context) error {
+			_, _, err := service.FindAll(ctx, org.ID, &model.PaginationFilter{Page: 1, Limit: 10})
+			return err
+		},
+		"update": func(ctx context.Context) error {
+			_, err := service.Update(ctx, team.ID, &organization.UpdateTeamRequest{})
+			return err
+		},
+		"delete": func(ctx context.Context) error {
+			return service.Delete(ctx, team.ID)
+		},
+		"set member": func(ctx context.Context) error {
+			_, err := service.SetMember(ctx, team.ID, owner.UserID, &organization.SetMemberRequest{Role: organization.MembershipMember})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(context.Background()); !errors.Is(err, errs.ErrUnauthorized) {
+				t.Fatalf("error = %v, want ErrUnauthorized", err)
+			}
+		})
+	}
+}
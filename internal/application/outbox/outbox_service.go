@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+)
+
+type OutboxService struct {
+	repo interfaces.IOutboxRepository
+}
+
+func NewOutboxService(repo interfaces.IOutboxRepository) *OutboxService {
+	return &OutboxService{repo: repo}
+}
+
+/**
+ * Enqueue stores events as pending outbox messages. It must run inside the
+ * transaction that persists the aggregate so both commit or roll back together.
+ * @param ctx context.Context
+ * @param events ...event.Event
+ * @return error
+ */
+func (o *OutboxService) Enqueue(ctx context.Context, events ...event.Event) (err error) {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]*outbox.Message, 0, len(events))
+	for _, e := range events {
+		message, err := outbox.NewMessage(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s: %w", e.EventName(), err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err = o.repo.Create(ctx, messages); err != nil {
+		return fmt.Errorf("failed to enqueue outbox messages: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/retry"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type RelayConfig struct {
+	BatchSize    int
+	PollInterval time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease adalah lama sebuah batch disewa satu relay, sekaligus batas waktu
+	// publish seluruh batch. Pesan yang belum tercatat hasilnya dikirim ulang setelahnya.
+	Lease time.Duration
+}
+
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		BatchSize:    100,
+		PollInterval: time.Second,
+		MaxAttempts:  10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   10 * time.Minute,
+		Lease:        5 * time.Minute,
+	}
+}
+
+// Relay mengirim pesan outbox yang pending ke publisher. Pesan baru ditandai
+// published setelah publisher sukses, sehingga pengiriman bersifat at-least-once.
+// Publish dilakukan di luar transaksi agar publisher yang lambat tidak menahan
+// koneksi dan lock database.
+type Relay struct {
+	repo      interfaces.IOutboxRepository
+	publisher interfaces.IPublisher
+	tx        transaction.IManager
+	config    RelayConfig
+}
+
+func NewRelay(repo interfaces.IOutboxRepository, publisher interfaces.IPublisher, tx transaction.IManager, config RelayConfig) *Relay {
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		tx:        tx,
+		config:    config,
+	}
+}
+
+/**
+ * Run polls the outbox until ctx is cancelled.
+ * @param ctx context.Context
+ */
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.ProcessBatch(ctx); err != nil {
+			slog.ErrorContext(ctx, "outbox relay batch failed", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/**
+ * ProcessBatch claims one batch of due messages in a short transaction,
+ * publishes them outside of it and records each result.
+ * @param ctx context.Context
+ * @return (int, error) number of messages published
+ */
+func (r *Relay) ProcessBatch(ctx context.Context) (published int, err error) {
+	now := time.Now()
+	// Presisi timestamp database hanya mikrodetik, sewa dibandingkan dengan kesamaan nilai
+	leaseUntil := now.Add(r.config.Lease).Truncate(time.Microsecond)
+
+	var messages []*outbox.Message
+	err = r.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		messages, err = r.repo.Claim(ctx, r.config.BatchSize, now, leaseUntil)
+		return err
+	})
+	if err != nil || len(messages) == 0 {
+		return 0, err
+	}
+
+	publishCtx, cancel := context.WithDeadline(ctx, leaseUntil)
+	defer cancel()
+
+	for _, message := range messages {
+		if err := r.publisher.Publish(publishCtx, message); err != nil {
+			message.MarkFailed(err, time.Now().Add(retry.Backoff(message.Attempts+1, r.config.BaseBackoff, r.config.MaxBackoff)), r.config.MaxAttempts)
+			slog.WarnContext(ctx, "outbox publish failed",
+				slog.String("message_id", message.ID.String()),
+				slog.String("event", message.EventName),
+				slog.Int("attempts", message.Attempts),
+				slog.Any("error", err),
+			)
+		} else {
+			message.MarkPublished(time.Now())
+			published++
+		}
+
+		if err := r.repo.Update(ctx, message, leaseUntil); err != nil {
+			// Sewa habis: relay lain sudah mengambil alih dan akan mengirim ulang
+			if errors.Is(err, errs.ErrConflict) {
+				slog.WarnContext(ctx, "outbox lease expired before result was recorded", slog.String("message_id", message.ID.String()))
+				continue
+			}
+			return published, err
+		}
+	}
+	return published, nil
+}
+
+var _ interfaces.IPublisher = (*MultiPublisher)(nil)
+
+// MultiPublisher meneruskan pesan ke beberapa publisher sekaligus
+type MultiPublisher []interfaces.IPublisher
+
+func (m MultiPublisher) Publish(ctx context.Context, message *outbox.Message) error {
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type fakeTx struct {
+	active bool
+}
+
+func (f *fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.active = true
+	defer func() { f.active = false }()
+	return fn(ctx)
+}
+
+// fakeMessages meniru OutboxRepository, termasuk presisi mikrodetik kolom
+// next_attempt_at yang dipakai sebagai penanda sewa
+type fakeMessages struct {
+	rows map[identity.ID]outbox.Message
+}
+
+func (f *fakeMessages) Create(ctx context.Context, messages []*outbox.Message) error {
+	return nil
+}
+
+func (f *fakeMessages) Claim(ctx context.Context, limit int, now time.Time, leaseUntil time.Time) ([]*outbox.Message, error) {
+	result := make([]*outbox.Message, 0)
+	for id, row := range f.rows {
+		if row.Status != outbox.StatusPending || row.NextAttemptAt.After(now) || len(result) == limit {
+			continue
+		}
+		row.Claim(leaseUntil.Truncate(time.Microsecond))
+		f.rows[id] = row
+		claimed := row
+		result = append(result, &claimed)
+	}
+	return result, nil
+}
+
+func (f *fakeMessages) Update(ctx context.Context, message *outbox.Message, leaseUntil time.Time) error {
+	row := f.rows[message.ID]
+	if row.Status != outbox.StatusPending || !row.NextAttemptAt.Equal(leaseUntil) {
+		return errs.ErrConflict
+	}
+	f.rows[message.ID] = *message
+	return nil
+}
+
+// publisherFunc menjalankan fn untuk setiap pesan
+type publisherFunc func(ctx context.Context, message *outbox.Message) error
+
+func (p publisherFunc) Publish(ctx context.Context, message *outbox.Message) error {
+	return p(ctx, message)
+}
+
+func newPendingMessage() *outbox.Message {
+	return &outbox.Message{
+		ID:             identity.New(),
+		IdempotencyKey: identity.New().String(),
+		EventName:      "account.user.registered",
+		Payload:        json.RawMessage(`{}`),
+		Status:         outbox.StatusPending,
+		NextAttemptAt:  time.Now().Add(-time.Second),
+	}
+}
+
+func TestRelayProcessBatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		publishErr    error
+		maxAttempts   int
+		stealLease    bool
+		wantPublished int
+		wantStatus    outbox.Status
+		wantAttempts  int
+	}{
+		{name: "published", wantPublished: 1, wantStatus: outbox.StatusPublished},
+		{name: "failed publish is retried later", publishErr: errors.New("broker down"), maxAttempts: 3, wantStatus: outbox.StatusPending, wantAttempts: 1},
+		{name: "last attempt fails permanently", publishErr: errors.New("broker down"), maxAttempts: 1, wantStatus: outbox.StatusFailed, wantAttempts: 1},
+		{name: "lost lease leaves row to the new owner", stealLease: true, wantPublished: 1, wantStatus: outbox.StatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := newPendingMessage()
+			messages := &fakeMessages{rows: map[identity.ID]outbox.Message{message.ID: *message}}
+			tx := &fakeTx{}
+
+			publishes := 0
+			publisher := publisherFunc(func(ctx context.Context, message *outbox.Message) error {
+				// publish harus terjadi setelah transaksi claim selesai
+				if tx.active {
+					t.Error("message published while a transaction was open")
+				}
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("publish has no deadline bound to the lease")
+				}
+				publishes++
+				if tt.stealLease {
+					// relay lain mengambil alih setelah sewa habis
+					row := messages.rows[message.ID]
+					row.Claim(time.Now().Add(time.Hour).Truncate(time.Microsecond))
+					messages.rows[message.ID] = row
+				}
+				return tt.publishErr
+			})
+
+			config := DefaultRelayConfig()
+			config.MaxAttempts = tt.maxAttempts
+			relay := NewRelay(messages, publisher, tx, config)
+
+			published, err := relay.ProcessBatch(context.Background())
+			if err != nil {
+				t.Fatalf("ProcessBatch() error = %v", err)
+			}
+			if published != tt.wantPublished {
+				t.Errorf("published = %d, want %d", published, tt.wantPublished)
+			}
+
+			row := messages.rows[message.ID]
+			if row.Status != tt.wantStatus || row.Attempts != tt.wantAttempts {
+				t.Errorf("row = status %s attempts %d, want status %s attempts %d", row.Status, row.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if row.Status == outbox.StatusPending && !row.NextAttemptAt.After(time.Now()) {
+				t.Errorf("pending row is due again immediately at %s", row.NextAttemptAt)
+			}
+
+			// batch kedua tidak boleh mengambil pesan yang masih disewa atau dijadwalkan ulang
+			if _, err := relay.ProcessBatch(context.Background()); err != nil {
+				t.Fatalf("second ProcessBatch() error = %v", err)
+			}
+			if publishes != 1 {
+				t.Errorf("message published %d times, want 1", publishes)
+			}
+		})
+	}
+}
+
+func TestRelayRetriesAfterFailedPublish(t *testing.T) {
+	message := newPendingMessage()
+	messages := &fakeMessages{rows: map[identity.ID]outbox.Message{message.ID: *message}}
+
+	var keys []string
+	publisher := publisherFunc(func(ctx context.Context, message *outbox.Message) error {
+		keys = append(keys, message.IdempotencyKey)
+		if len(keys) == 1 {
+			return errors.New("broker down")
+		}
+		return nil
+	})
+
+	config := DefaultRelayConfig()
+	config.BaseBackoff = time.Millisecond
+	config.MaxBackoff = time.Millisecond
+	relay := NewRelay(messages, publisher, &fakeTx{}, config)
+
+	if published, err := relay.ProcessBatch(context.Background()); err != nil || published != 0 {
+		t.Fatalf("first ProcessBatch() = %d, %v, want 0 published", published, err)
+	}
+	wait := time.Until(messages.rows[message.ID].NextAttemptAt)
+	if wait > time.Second {
+		t.Fatalf("retry scheduled in %s, want the configured backoff", wait)
+	}
+	time.Sleep(wait + time.Millisecond)
+
+	if published, err := relay.ProcessBatch(context.Background()); err != nil || published != 1 {
+		t.Fatalf("retry ProcessBatch() = %d, %v, want 1 published", published, err)
+	}
+
+	row := messages.rows[message.ID]
+	if row.Status != outbox.StatusPublished || row.Attempts != 1 || row.LastError != "" {
+		t.Errorf("row = status %s attempts %d error %q, want published after 1 failed attempt", row.Status, row.Attempts, row.LastError)
+	}
+	// consumer membuang duplikat berdasarkan idempotency key yang sama
+	if len(keys) != 2 || keys[0] != keys[1] {
+		t.Errorf("published idempotency keys = %v, want the same key twice", keys)
+	}
+}
@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"context"
+
+	outboxInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+// EventSource adalah aggregate yang mencatat domain event
+type EventSource interface {
+	PullEvents() []event.Event
+}
+
+// Sources menggabungkan event beberapa aggregate yang disimpan dalam satu transaksi
+type Sources []EventSource
+
+func (s Sources) PullEvents() []event.Event {
+	var events []event.Event
+	for _, source := range s {
+		events = append(events, source.PullEvents()...)
+	}
+	return events
+}
+
+// Committer menyimpan perubahan aggregate bersama event-nya di outbox dalam
+// satu transaksi, lalu mengirim event ke subscriber in-process setelah commit
+type Committer struct {
+	tx         transaction.IManager
+	outbox     outboxInterfaces.IOutboxService
+	dispatcher event.IDispatcher
+}
+
+func NewCommitter(tx transaction.IManager, outbox outboxInterfaces.IOutboxService, dispatcher event.IDispatcher) Committer {
+	return Committer{
+		tx:         tx,
+		outbox:     outbox,
+		dispatcher: dispatcher,
+	}
+}
+
+/**
+ * Commit runs fn and enqueues the events of aggregate in one transaction,
+ * then dispatches the events to in-process subscribers after the commit.
+ * @param ctx context.Context
+ * @param aggregate EventSource
+ * @param fn func(ctx context.Context) error
+ * @return error
+ */
+func (c Committer) Commit(ctx context.Context, aggregate EventSource, fn func(ctx context.Context) error) error {
+	events := aggregate.PullEvents()
+
+	err := c.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		return c.outbox.Enqueue(ctx, events...)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.dispatcher.Dispatch(ctx, events...)
+	return nil
+}
+
+/**
+ * WithinTransaction runs fn in a transaction without recording events.
+ * @param ctx context.Context
+ * @param fn func(ctx context.Context) error
+ * @return error
+ */
+func (c Committer) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return c.tx.WithinTransaction(ctx, fn)
+}
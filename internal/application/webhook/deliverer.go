@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/retry"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/transaction"
+)
+
+type DelivererConfig struct {
+	BatchSize    int
+	PollInterval time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease adalah lama sebuah batch disewa satu worker, sekaligus batas waktu
+	// pengiriman seluruh batch. Delivery yang belum tercatat hasilnya dikirim ulang setelahnya.
+	Lease time.Duration
+}
+
+func DefaultDelivererConfig() DelivererConfig {
+	return DelivererConfig{
+		BatchSize:    50,
+		PollInterval: time.Second,
+		MaxAttempts:  8,
+		BaseBackoff:  10 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		Lease:        5 * time.Minute,
+	}
+}
+
+var errSubscriptionInactive = errors.New("webhook subscription is inactive")
+
+// Deliverer mengirim delivery yang jatuh tempo ke endpoint subscriber. Request
+// HTTP dikirim di luar transaksi agar endpoint yang lambat tidak menahan
+// koneksi dan lock database.
+type Deliverer struct {
+	subscriptions interfaces.ISubscriptionRepository
+	deliveries    interfaces.IDeliveryRepository
+	sender        interfaces.IWebhookSender
+	tx            transaction.IManager
+	config        DelivererConfig
+}
+
+func NewDeliverer(subscriptions interfaces.ISubscriptionRepository, deliveries interfaces.IDeliveryRepository, sender interfaces.IWebhookSender, tx transaction.IManager, config DelivererConfig) *Deliverer {
+	return &Deliverer{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		tx:            tx,
+		config:        config,
+	}
+}
+
+/**
+ * Run polls for due deliveries until ctx is cancelled.
+ * @param ctx context.Context
+ */
+func (d *Deliverer) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.ProcessBatch(ctx); err != nil {
+			slog.ErrorContext(ctx, "webhook delivery batch failed", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/**
+ * ProcessBatch claims one batch of due deliveries in a short transaction,
+ * sends them outside of it and records each result.
+ * @param ctx context.Context
+ * @return (int, error) number of successful deliveries
+ */
+func (d *Deliverer) ProcessBatch(ctx context.Context) (delivered int, err error) {
+	now := time.Now()
+	// Presisi timestamp database hanya mikrodetik, sewa dibandingkan dengan kesamaan nilai
+	leaseUntil := now.Add(d.config.Lease).Truncate(time.Microsecond)
+
+	var deliveries []*webhook.Delivery
+	err = d.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		deliveries, err = d.deliveries.Claim(ctx, d.config.BatchSize, now, leaseUntil)
+		return err
+	})
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	sendCtx, cancel := context.WithDeadline(ctx, leaseUntil)
+	defer cancel()
+
+	for _, delivery := range deliveries {
+		if d.deliver(sendCtx, delivery, time.Now()) {
+			delivered++
+		}
+
+		if err := d.deliveries.Update(ctx, delivery, leaseUntil); err != nil {
+			// Sewa habis: worker lain sudah mengambil alih dan akan mengirim ulang
+			if errors.Is(err, errs.ErrConflict) {
+				slog.WarnContext(ctx, "webhook lease expired before result was recorded", slog.String("delivery_id", delivery.ID.String()))
+				continue
+			}
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+func (d *Deliverer) deliver(ctx context.Context, delivery *webhook.Delivery, now time.Time) bool {
+	nextAttemptAt := now.Add(retry.Backoff(delivery.Attempts+1, d.config.BaseBackoff, d.config.MaxBackoff))
+
+	subscription, err := d.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			delivery.MarkFailed(nil, err, now, 1)
+		} else {
+			delivery.MarkFailed(nil, err, nextAttemptAt, d.config.MaxAttempts)
+		}
+		return false
+	}
+	if !subscription.IsActive {
+		delivery.MarkFailed(nil, errSubscriptionInactive, now, 1)
+		return false
+	}
+
+	result, err := d.sender.Send(ctx, subscription, delivery)
+	if err != nil {
+		delivery.MarkFailed(result, err, nextAttemptAt, d.config.MaxAttempts)
+		slog.WarnContext(ctx, "webhook delivery failed",
+			slog.String("delivery_id", delivery.ID.String()),
+			slog.String("url", subscription.URL),
+			slog.Int("attempts", delivery.Attempts),
+			slog.Any("error", err),
+		)
+		return false
+	}
+
+	delivery.MarkSucceeded(result, time.Now())
+	return true
+}
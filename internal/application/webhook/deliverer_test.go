@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/infrastructure/messaging"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type fakeTx struct {
+	active bool
+}
+
+func (f *fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.active = true
+	defer func() { f.active = false }()
+	return fn(ctx)
+}
+
+type fakeSubscriptions struct {
+	items map[identity.ID]*webhook.Subscription
+}
+
+func (f *fakeSubscriptions) Create(ctx context.Context, subscription *webhook.Subscription) error {
+	return nil
+}
+
+func (f *fakeSubscriptions) FindByID(ctx context.Context, id identity.ID) (*webhook.Subscription, error) {
+	if subscription, ok := f.items[id]; ok {
+		return subscription, nil
+	}
+	return nil, errs.ErrNotFound
+}
+
+func (f *fakeSubscriptions) FindAll(ctx context.Context, filter *model.PaginationFilter) ([]*webhook.Subscription, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeSubscriptions) FindActive(ctx context.Context) ([]*webhook.Subscription, error) {
+	return nil, nil
+}
+
+func (f *fakeSubscriptions) Update(ctx context.Context, subscription *webhook.Subscription) error {
+	return nil
+}
+
+func (f *fakeSubscriptions) Delete(ctx context.Context, id identity.ID) error {
+	return nil
+}
+
+// fakeDeliveries menyimpan salinan delivery seperti baris database
+type fakeDeliveries struct {
+	rows map[identity.ID]webhook.Delivery
+	// stealLease mensimulasikan worker lain yang mengambil alih setelah sewa habis
+	stealLease bool
+}
+
+func (f *fakeDeliveries) Create(ctx context.Context, deliveries []*webhook.Delivery) error {
+	return nil
+}
+
+func (f *fakeDeliveries) FindByID(ctx context.Context, id identity.ID) (*webhook.Delivery, error) {
+	if delivery, ok := f.rows[id]; ok {
+		return &delivery, nil
+	}
+	return nil, errs.ErrNotFound
+}
+
+func (f *fakeDeliveries) FindAll(ctx context.Context, filter *webhook.DeliveryFilter) ([]*webhook.Delivery, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeDeliveries) Claim(ctx context.Context, limit int, now time.Time, leaseUntil time.Time) ([]*webhook.Delivery, error) {
+	result := make([]*webhook.Delivery, 0)
+	for id, row := range f.rows {
+		if row.Status != webhook.DeliveryPending || row.NextAttemptAt.After(now) || len(result) == limit {
+			continue
+		}
+		row.Claim(leaseUntil)
+		f.rows[id] = row
+		claimed := row
+		result = append(result, &claimed)
+	}
+	return result, nil
+}
+
+func (f *fakeDeliveries) Update(ctx context.Context, delivery *webhook.Delivery, leaseUntil time.Time) error {
+	row := f.rows[delivery.ID]
+	if f.stealLease || row.Status != webhook.DeliveryPending || !row.NextAttemptAt.Equal(leaseUntil) {
+		return errs.ErrConflict
+	}
+	f.rows[delivery.ID] = *delivery
+	return nil
+}
+
+func TestDelivererProcessBatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		inactive      bool
+		stealLease    bool
+		wantDelivered int
+		wantStatus    webhook.DeliveryStatus
+		wantAttempts  int
+		wantRequests  int
+	}{
+		{name: "delivered", status: http.StatusOK, wantDelivered: 1, wantStatus: webhook.DeliverySucceeded, wantAttempts: 1, wantRequests: 1},
+		{name: "receiver error is retried later", status: http.StatusServiceUnavailable, wantStatus: webhook.DeliveryPending, wantAttempts: 1, wantRequests: 1},
+		{name: "inactive subscription fails permanently", status: http.StatusOK, inactive: true, wantStatus: webhook.DeliveryFailed, wantAttempts: 1},
+		{name: "lost lease leaves row to the new owner", status: http.StatusOK, stealLease: true, wantDelivered: 1, wantStatus: webhook.DeliveryPending, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTx{}
+			requests := 0
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// pengiriman harus terjadi setelah transaksi claim selesai
+				if tx.active {
+					t.Error("webhook sent while a transaction was open")
+				}
+				requests++
+				body, _ := io.ReadAll(r.Body)
+				timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+				if !webhook.Verify("whsec_test", timestamp, body, r.Header.Get(webhook.SignatureHeader)) {
+					t.Error("invalid webhook signature")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer receiver.Close()
+
+			subscription := &webhook.Subscription{ID: identity.New(), URL: receiver.URL, Secret: "whsec_test", IsActive: !tt.inactive}
+			delivery := webhook.NewDelivery(subscription.ID, identity.New().String(), "account.user.registered", json.RawMessage(`{}`))
+			delivery.NextAttemptAt = time.Now().Add(-time.Second)
+
+			deliveries := &fakeDeliveries{rows: map[identity.ID]webhook.Delivery{delivery.ID: *delivery}, stealLease: tt.stealLease}
+			subscriptions := &fakeSubscriptions{items: map[identity.ID]*webhook.Subscription{subscription.ID: subscription}}
+			config := DefaultDelivererConfig()
+			deliverer := NewDeliverer(subscriptions, deliveries, messaging.NewWebhookSender(receiver.Client()), tx, config)
+
+			delivered, err := deliverer.ProcessBatch(context.Background())
+			if err != nil {
+				t.Fatalf("ProcessBatch() error = %v", err)
+			}
+			if delivered != tt.wantDelivered {
+				t.Errorf("delivered = %d, want %d", delivered, tt.wantDelivered)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("receiver got %d requests, want %d", requests, tt.wantRequests)
+			}
+
+			row := deliveries.rows[delivery.ID]
+			if row.Status != tt.wantStatus || row.Attempts != tt.wantAttempts {
+				t.Errorf("row = status %s attempts %d, want status %s attempts %d", row.Status, row.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if row.Status == webhook.DeliveryPending && !row.NextAttemptAt.After(time.Now()) {
+				t.Errorf("pending row is due again immediately at %s", row.NextAttemptAt)
+			}
+
+			// batch kedua tidak boleh mengambil delivery yang masih disewa atau dijadwalkan ulang
+			if _, err := deliverer.ProcessBatch(context.Background()); err != nil {
+				t.Fatalf("second ProcessBatch() error = %v", err)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("receiver got %d requests after second batch, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	accountInterfaces "github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+const secretPrefix = "whsec_"
+
+var errActorRequired = fmt.Errorf("authentication required: %w", errs.ErrUnauthorized)
+
+type WebhookService struct {
+	subscriptions interfaces.ISubscriptionRepository
+	deliveries    interfaces.IDeliveryRepository
+	resolver      accountInterfaces.IPermissionResolver
+}
+
+func NewWebhookService(subscriptions interfaces.ISubscriptionRepository, deliveries interfaces.IDeliveryRepository, resolver accountInterfaces.IPermissionResolver) *WebhookService {
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		resolver:      resolver,
+	}
+}
+
+/**
+ * CreateSubscription registers an endpoint. The signing secret is only
+ * returned here. The actor needs PermissionWebhooksWrite.
+ * @param ctx context.Context
+ * @param payload *webhook.CreateSubscriptionRequest
+ * @return (*webhook.SubscriptionSecretResponse, error)
+ */
+func (w *WebhookService) CreateSubscription(ctx context.Context, payload *webhook.CreateSubscriptionRequest) (result *webhook.SubscriptionSecretResponse, err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksWrite); err != nil {
+		return nil, err
+	}
+	if err = validateURL(payload.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := token.Generate(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", errs.ErrInternal)
+	}
+
+	now := time.Now()
+	subscription := &webhook.Subscription{
+		ID:          identity.New(),
+		URL:         payload.URL,
+		EventTypes:  payload.EventTypes,
+		Secret:      secretPrefix + secret,
+		Description: payload.Description,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err = w.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return &webhook.SubscriptionSecretResponse{Subscription: *subscription, Secret: subscription.Secret}, nil
+}
+
+func (w *WebhookService) GetSubscription(ctx context.Context, id identity.ID) (result *webhook.Subscription, err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksRead); err != nil {
+		return nil, err
+	}
+	return w.subscriptions.FindByID(ctx, id)
+}
+
+func (w *WebhookService) ListSubscriptions(ctx context.Context, filter *model.PaginationFilter) (result []*webhook.Subscription, totalItems int64, err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksRead); err != nil {
+		return nil, 0, err
+	}
+	return w.subscriptions.FindAll(ctx, filter)
+}
+
+func (w *WebhookService) UpdateSubscription(ctx context.Context, id identity.ID, payload *webhook.UpdateSubscriptionRequest) (err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksWrite); err != nil {
+		return err
+	}
+
+	subscription, err := w.subscriptions.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if payload.URL != "" {
+		if err = validateURL(payload.URL); err != nil {
+			return err
+		}
+		subscription.URL = payload.URL
+	}
+	if payload.EventTypes != nil {
+		subscription.EventTypes = payload.EventTypes
+	}
+	if payload.Description != "" {
+		subscription.Description = payload.Description
+	}
+	if payload.IsActive != nil {
+		subscription.IsActive = *payload.IsActive
+	}
+	subscription.UpdatedAt = time.Now()
+
+	return w.subscriptions.Update(ctx, subscription)
+}
+
+func (w *WebhookService) DeleteSubscription(ctx context.Context, id identity.ID) (err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksWrite); err != nil {
+		return err
+	}
+	return w.subscriptions.Delete(ctx, id)
+}
+
+func (w *WebhookService) ListDeliveries(ctx context.Context, filter *webhook.DeliveryFilter) (result []*webhook.Delivery, totalItems int64, err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksRead); err != nil {
+		return nil, 0, err
+	}
+	return w.deliveries.FindAll(ctx, filter)
+}
+
+func (w *WebhookService) GetDelivery(ctx context.Context, id identity.ID) (result *webhook.Delivery, err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksRead); err != nil {
+		return nil, err
+	}
+	return w.deliveries.FindByID(ctx, id)
+}
+
+/**
+ * Redeliver queues a new attempt of an earlier delivery with the same event
+ * ID and payload. The original delivery is kept untouched in the history.
+ * The actor needs PermissionWebhooksWrite.
+ * @param ctx context.Context
+ * @param id identity.ID
+ * @return (*webhook.Delivery, error)
+ */
+func (w *WebhookService) Redeliver(ctx context.Context, id identity.ID) (result *webhook.Delivery, err error) {
+	if err = w.authorize(ctx, webhook.PermissionWebhooksWrite); err != nil {
+		return nil, err
+	}
+
+	delivery, err := w.deliveries.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	redelivery := delivery.Redeliver()
+	if err = w.deliveries.Create(ctx, []*webhook.Delivery{redelivery}); err != nil {
+		return nil, fmt.Errorf("failed to queue redelivery: %w", err)
+	}
+	return redelivery, nil
+}
+
+/**
+ * Publish implements the outbox publisher by fanning a message out into one
+ * pending delivery per matching active subscription. The relay calls it after
+ * its claim transaction has committed, while it holds a lease on the message,
+ * and marks the message published only after Publish returns. A failed
+ * publish or an expired lease makes the relay publish the message again, so
+ * delivery is at least once. Deliveries are keyed by subscription and event ID
+ * so a republished message does not create duplicates.
+ * @param ctx context.Context
+ * @param message *outbox.Message
+ * @return error
+ */
+func (w *WebhookService) Publish(ctx context.Context, message *outbox.Message) error {
+	subscriptions, err := w.subscriptions.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*webhook.Delivery, 0)
+	for _, subscription := range subscriptions {
+		if subscription.Matches(message.EventName) {
+			deliveries = append(deliveries, webhook.NewDelivery(subscription.ID, message.IdempotencyKey, message.EventName, message.Payload))
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return w.deliveries.Create(ctx, deliveries)
+}
+
+func (w *WebhookService) authorize(ctx context.Context, permission string) error {
+	subject, ok, err := w.resolver.ActorSubject(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errActorRequired
+	}
+	return requirePermission(subject, permission)
+}
+
+func requirePermission(subject policy.Subject, permission string) error {
+	if !subject.HasPermission(permission) {
+		return fmt.Errorf("missing permission '%s': %w", permission, errs.ErrForbidden)
+	}
+	return nil
+}
+
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errs.ValidationError{Field: "url", Message: "must be an absolute http(s) URL"}
+	}
+	return nil
+}
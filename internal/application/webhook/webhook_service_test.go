@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+)
+
+// fakeResolver mengembalikan subject tetap, nil berarti tidak ada actor
+type fakeResolver struct {
+	subject *policy.Subject
+}
+
+func (f fakeResolver) UserPermissions(ctx context.Context, user *account.User) ([]string, error) {
+	return nil, nil
+}
+
+func (f fakeResolver) ActorSubject(ctx context.Context) (policy.Subject, bool, error) {
+	if f.subject == nil {
+		return policy.Subject{}, false, nil
+	}
+	return *f.subject, true, nil
+}
+
+func TestWebhookServiceAuthorization(t *testing.T) {
+	subscriptionID, deliveryID := identity.New(), identity.New()
+	actor := func(permissions ...string) *policy.Subject {
+		return &policy.Subject{ID: identity.New(), Permissions: permissions}
+	}
+
+	calls := []struct {
+		name       string
+		permission string
+		call       func(ctx context.Context, service *WebhookService) error
+	}{
+		{name: "list subscriptions", permission: webhook.PermissionWebhooksRead, call: func(ctx context.Context, service *WebhookService) error {
+			_, _, err := service.ListSubscriptions(ctx, &model.PaginationFilter{})
+			return err
+		}},
+		{name: "get subscription", permission: webhook.PermissionWebhooksRead, call: func(ctx context.Context, service *WebhookService) error {
+			_, err := service.GetSubscription(ctx, subscriptionID)
+			return err
+		}},
+		{name: "list deliveries", permission: webhook.PermissionWebhooksRead, call: func(ctx context.Context, service *WebhookService) error {
+			_, _, err := service.ListDeliveries(ctx, &webhook.DeliveryFilter{SubscriptionID: subscriptionID.String()})
+			return err
+		}},
+		{name: "get delivery", permission: webhook.PermissionWebhooksRead, call: func(ctx context.Context, service *WebhookService) error {
+			_, err := service.GetDelivery(ctx, deliveryID)
+			return err
+		}},
+		{name: "create subscription", permission: webhook.PermissionWebhooksWrite, call: func(ctx context.Context, service *WebhookService) error {
+			_, err := service.CreateSubscription(ctx, &webhook.CreateSubscriptionRequest{URL: "https://example.com/hook"})
+			return err
+		}},
+		{name: "update subscription", permission: webhook.PermissionWebhooksWrite, call: func(ctx context.Context, service *WebhookService) error {
+			return service.UpdateSubscription(ctx, subscriptionID, &webhook.UpdateSubscriptionRequest{Description: "changed"})
+		}},
+		{name: "delete subscription", permission: webhook.PermissionWebhooksWrite, call: func(ctx context.Context, service *WebhookService) error {
+			return service.DeleteSubscription(ctx, subscriptionID)
+		}},
+		{name: "redeliver", permission: webhook.PermissionWebhooksWrite, call: func(ctx context.Context, service *WebhookService) error {
+			_, err := service.Redeliver(ctx, deliveryID)
+			return err
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			subscriptions := &fakeSubscriptions{items: map[identity.ID]*webhook.Subscription{subscriptionID: {ID: subscriptionID}}}
+			deliveries := &fakeDeliveries{rows: map[identity.ID]webhook.Delivery{deliveryID: {ID: deliveryID, SubscriptionID: subscriptionID}}}
+			newService := func(subject *policy.Subject) *WebhookService {
+				return NewWebhookService(subscriptions, deliveries, fakeResolver{subject: subject})
+			}
+
+			if err := tt.call(context.Background(), newService(nil)); !errors.Is(err, errs.ErrUnauthorized) {
+				t.Errorf("without actor error = %v, want ErrUnauthorized", err)
+			}
+			if err := tt.call(context.Background(), newService(actor())); !errors.Is(err, errs.ErrForbidden) {
+				t.Errorf("without %s error = %v, want ErrForbidden", tt.permission, err)
+			}
+			if err := tt.call(context.Background(), newService(actor(tt.permission))); err != nil {
+				t.Errorf("with %s error = %v, want nil", tt.permission, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package account
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+const (
+	// APIKeyPrefix menandai API key sehingga mudah dikenali oleh secret scanner
+	APIKeyPrefix = "ak_"
+
+	apiKeyPublicLength = 8
+	apiKeySecretSize   = 32
+)
+
+// APIKey adalah kredensial non-interaktif milik user. Key lengkap hanya
+// ditampilkan sekali; yang disimpan hanya hash dan prefix untuk identifikasi.
+type APIKey struct {
+	ID         identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	TenantID   identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	UserID     identity.ID `json:"user_id" gorm:"column:user_id;type:uuid;index"`
+	Name       string      `json:"name" gorm:"column:name"`
+	Prefix     string      `json:"prefix" gorm:"column:prefix"`
+	KeyHash    string      `json:"-" gorm:"column:key_hash;uniqueIndex"`
+	Scopes     []string    `json:"scopes" gorm:"column:scopes;type:jsonb;serializer:json"`
+	ExpiresAt  *time.Time  `json:"expires_at" gorm:"column:expires_at"`
+	LastUsedAt *time.Time  `json:"last_used_at" gorm:"column:last_used_at"`
+	RevokedAt  *time.Time  `json:"revoked_at" gorm:"column:revoked_at"`
+	CreatedAt  time.Time   `json:"created_at" gorm:"column:created_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// NewAPIKey mengembalikan key beserta nilai plain berformat ak_<prefix>_<secret>
+func NewAPIKey(userID identity.ID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	public, err := token.Generate(apiKeyPublicLength)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := token.Generate(apiKeySecretSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// token.Generate memakai base64url yang bisa mengandung "_", ganti agar
+	// pemisah prefix tetap jelas
+	prefix := APIKeyPrefix + strings.ReplaceAll(public, "_", "x")[:apiKeyPublicLength]
+	plain := prefix + "_" + secret
+
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &APIKey{
+		ID:        identity.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   token.Hash(plain),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, plain, nil
+}
+
+func IsAPIKey(value string) bool {
+	return strings.HasPrefix(value, APIKeyPrefix)
+}
+
+func (k *APIKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// EffectiveScopes membatasi scope key dengan permission role saat ini, sehingga
+// permission yang dicabut dari role ikut hilang dari key yang sudah ada
+func (k *APIKey) EffectiveScopes(permissions []string) []string {
+	result := make([]string, 0, len(k.Scopes))
+	for _, scope := range k.Scopes {
+		if slices.Contains(permissions, scope) {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
+func (k *APIKey) ToAPIKeyResponse() *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
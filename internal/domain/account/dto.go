@@ -0,0 +1,230 @@
+package account
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// DTO untuk API. Entity di user.go dan role.go adalah model persistence dan
+// tidak dikirim langsung ke client.
+type (
+	UserResponse struct {
+		ID              identity.ID       `json:"id"`
+		Username        string            `json:"username"`
+		Fullname        string            `json:"fullname"`
+		Email           string            `json:"email"`
+		EmailVerifiedAt *time.Time        `json:"email_verified_at"`
+		Name            string            `json:"name"`
+		IsActive        bool              `json:"is_active"`
+		Attributes      map[string]string `json:"attributes"`
+		Role            *RoleResponse     `json:"role"`
+		Version         int64             `json:"version"`
+		CreatedAt       time.Time         `json:"created_at,omitempty"`
+		UpdatedAt       time.Time         `json:"updated_at,omitempty"`
+
+		// fields membatasi properti yang di-serialize, kosong berarti semua
+		fields []string
+	}
+
+	CreateUserRequest struct {
+		Name     string `json:"name" validate:"required"`
+		Fullname string `json:"fullname" validate:"required"`
+		Username string `json:"username" validate:"required"`
+		Email    string `json:"email" validate:"required,email"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	LoginRequest struct {
+		// Identifier berisi username atau email
+		Identifier string `json:"identifier" validate:"required"`
+		Password   string `json:"password" validate:"required"`
+	}
+
+	// LoginResponse berisi access token, atau challenge token jika user
+	// mengaktifkan MFA dan harus melanjutkan ke VerifyMFA. Akun admin tanpa MFA
+	// mendapat challenge dengan MFAEnrollmentRequired untuk mendaftar lebih dulu.
+	LoginResponse struct {
+		MFARequired           bool          `json:"mfa_required"`
+		MFAEnrollmentRequired bool          `json:"mfa_enrollment_required"`
+		ChallengeToken        string        `json:"challenge_token,omitempty"`
+		AccessToken           string        `json:"access_token,omitempty"`
+		TokenType             string        `json:"token_type,omitempty"`
+		ExpiresAt             time.Time     `json:"expires_at"`
+		User                  *UserResponse `json:"user,omitempty"`
+	}
+
+	// VerifyMFARequest diisi salah satu dari Code atau RecoveryCode
+	VerifyMFARequest struct {
+		ChallengeToken string `json:"challenge_token" validate:"required"`
+		Code           string `json:"code"`
+		RecoveryCode   string `json:"recovery_code"`
+	}
+
+	MFACodeRequest struct {
+		Code string `json:"code" validate:"required"`
+	}
+
+	// MFAEnrollmentRequest memakai challenge token dari login yang menuntut
+	// pendaftaran MFA. Code hanya diisi saat aktivasi.
+	MFAEnrollmentRequest struct {
+		ChallengeToken string `json:"challenge_token" validate:"required"`
+		Code           string `json:"code"`
+	}
+
+	TOTPEnrollmentResponse struct {
+		Secret string `json:"secret"`
+		URI    string `json:"otpauth_uri"`
+		// QRCode adalah gambar PNG, di-encode base64 oleh encoding/json
+		QRCode []byte `json:"qr_code_png"`
+	}
+
+	RecoveryCodesResponse struct {
+		Codes []string `json:"recovery_codes"`
+	}
+
+	UpdateUserRequest struct {
+		Name     string      `json:"name" validate:"name"`
+		Fullname string      `json:"fullname" validate:"fullname"`
+		Username string      `json:"username" validate:"username"`
+		Email    string      `json:"email" validate:"email"`
+		Password string      `json:"password" validate:"omitempty"`
+		Role     identity.ID `json:"role_id"`
+		Version  int64       `json:"version"`
+		// Attributes nil berarti tidak diubah, selain itu menggantikan seluruh atribut
+		Attributes map[string]string `json:"attributes"`
+	}
+
+	RoleResponse struct {
+		ID          identity.ID `json:"id"`
+		Name        string      `json:"name"`
+		ParentID    identity.ID `json:"parent_id"`
+		Permissions []string    `json:"permissions"`
+		Version     int64       `json:"version"`
+		CreatedAt   time.Time   `json:"created_at,omitempty"`
+		UpdatedAt   time.Time   `json:"updated_at,omitempty"`
+	}
+
+	CreateRoleRequest struct {
+		Name        string      `json:"name" validate:"required"`
+		ParentID    identity.ID `json:"parent_id"`
+		Permissions []string    `json:"permissions"`
+	}
+
+	UpdateRoleRequest struct {
+		Name string `json:"name"`
+		// Permissions nil berarti tidak diubah, slice kosong menghapus semua
+		Permissions []string `json:"permissions"`
+		// ParentID nil berarti tidak diubah, ID kosong melepas parent
+		ParentID *identity.ID `json:"parent_id"`
+		Version  int64        `json:"version"`
+	}
+
+	AssignRoleRequest struct {
+		// ExpiresAt nil berarti assignment tidak kedaluwarsa
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	UserRoleResponse struct {
+		Role       *RoleResponse `json:"role"`
+		Primary    bool          `json:"primary"`
+		AssignedBy identity.ID   `json:"assigned_by,omitempty"`
+		AssignedAt time.Time     `json:"assigned_at,omitempty"`
+		ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	}
+
+	RequestRoleElevationRequest struct {
+		RoleID          identity.ID `json:"role_id" validate:"required"`
+		DurationSeconds int64       `json:"duration_seconds" validate:"required"`
+		Reason          string      `json:"reason" validate:"required"`
+		// RequireApproval meminta persetujuan admin kedua walaupun tidak diwajibkan konfigurasi
+		RequireApproval bool `json:"require_approval"`
+	}
+
+	RoleElevationResponse struct {
+		ID              identity.ID     `json:"id"`
+		UserID          identity.ID     `json:"user_id"`
+		RoleID          identity.ID     `json:"role_id"`
+		RequestedBy     identity.ID     `json:"requested_by"`
+		Reason          string          `json:"reason"`
+		DurationSeconds int64           `json:"duration_seconds"`
+		Status          ElevationStatus `json:"status"`
+		DecidedBy       identity.ID     `json:"decided_by,omitempty"`
+		DecidedAt       *time.Time      `json:"decided_at,omitempty"`
+		ExpiresAt       *time.Time      `json:"expires_at,omitempty"`
+		CreatedAt       time.Time       `json:"created_at"`
+	}
+
+	EffectivePermissionsResponse struct {
+		RoleID      identity.ID    `json:"role_id"`
+		Permissions []string       `json:"permissions"`
+		Ancestors   []RoleResponse `json:"ancestors"`
+	}
+
+	RequestPasswordResetRequest struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+
+	ConfirmPasswordResetRequest struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	ConfirmEmailRequest struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	ResendVerificationRequest struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+
+	CreateAPIKeyRequest struct {
+		Name      string     `json:"name" validate:"required"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	APIKeyResponse struct {
+		ID         identity.ID `json:"id"`
+		Name       string      `json:"name"`
+		Prefix     string      `json:"prefix"`
+		Scopes     []string    `json:"scopes"`
+		ExpiresAt  *time.Time  `json:"expires_at"`
+		LastUsedAt *time.Time  `json:"last_used_at"`
+		RevokedAt  *time.Time  `json:"revoked_at"`
+		CreatedAt  time.Time   `json:"created_at"`
+	}
+
+	// CreatedAPIKeyResponse memuat key lengkap yang hanya ditampilkan sekali
+	CreatedAPIKeyResponse struct {
+		APIKeyResponse
+		Key string `json:"key"`
+	}
+
+	SessionResponse struct {
+		ID         identity.ID `json:"id"`
+		Device     string      `json:"device"`
+		UserAgent  string      `json:"user_agent"`
+		IP         string      `json:"ip"`
+		Current    bool        `json:"current"`
+		CreatedAt  time.Time   `json:"created_at"`
+		LastSeenAt time.Time   `json:"last_seen_at"`
+		ExpiresAt  time.Time   `json:"expires_at"`
+	}
+
+	// Principal adalah identitas hasil autentikasi sebuah request. Scopes nil
+	// berarti tidak dibatasi scope (login interaktif).
+	Principal struct {
+		UserID    identity.ID
+		Method    string
+		APIKeyID  identity.ID
+		SessionID identity.ID
+		TenantID  identity.ID
+		Scopes    []string
+	}
+)
+
+const (
+	AuthMethodAccessToken = "access_token"
+	AuthMethodAPIKey      = "api_key"
+)
@@ -0,0 +1,189 @@
+package account
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type (
+	UserRegistered struct {
+		event.Base
+		UserID   identity.ID `json:"user_id"`
+		Username string      `json:"username"`
+		Email    string      `json:"email"`
+	}
+
+	UserUpdated struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+		Fields []string    `json:"fields"`
+	}
+
+	UserEmailChanged struct {
+		event.Base
+		UserID   identity.ID `json:"user_id"`
+		OldEmail string      `json:"old_email"`
+		NewEmail string      `json:"new_email"`
+	}
+
+	UserEmailVerified struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+		Email  string      `json:"email"`
+	}
+
+	UserIdentityLinked struct {
+		event.Base
+		UserID   identity.ID `json:"user_id"`
+		Provider string      `json:"provider"`
+	}
+
+	UserPasswordChanged struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+	}
+
+	UserMFAEnabled struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+	}
+
+	UserMFADisabled struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+	}
+
+	UserDeactivated struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+	}
+
+	UserDeleted struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+	}
+
+	RoleCreated struct {
+		event.Base
+		RoleID      identity.ID `json:"role_id"`
+		Name        string      `json:"name"`
+		ParentID    identity.ID `json:"parent_id"`
+		Permissions []string    `json:"permissions"`
+	}
+
+	RolePermissionsChanged struct {
+		event.Base
+		RoleID  identity.ID `json:"role_id"`
+		Added   []string    `json:"added"`
+		Removed []string    `json:"removed"`
+	}
+
+	RoleParentChanged struct {
+		event.Base
+		RoleID           identity.ID `json:"role_id"`
+		ParentID         identity.ID `json:"parent_id"`
+		PreviousParentID identity.ID `json:"previous_parent_id"`
+	}
+
+	RoleDeleted struct {
+		event.Base
+		RoleID identity.ID `json:"role_id"`
+	}
+
+	RoleAssigned struct {
+		event.Base
+		UserID         identity.ID `json:"user_id"`
+		RoleID         identity.ID `json:"role_id"`
+		PreviousRoleID identity.ID `json:"previous_role_id"`
+	}
+
+	RoleUnassigned struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+		RoleID identity.ID `json:"role_id"`
+	}
+
+	RoleAssignmentExpired struct {
+		event.Base
+		UserID identity.ID `json:"user_id"`
+		RoleID identity.ID `json:"role_id"`
+	}
+
+	RoleElevationRequested struct {
+		event.Base
+		ElevationID identity.ID `json:"elevation_id"`
+		UserID      identity.ID `json:"user_id"`
+		RoleID      identity.ID `json:"role_id"`
+		RequestedBy identity.ID `json:"requested_by"`
+	}
+
+	RoleElevationApproved struct {
+		event.Base
+		ElevationID identity.ID `json:"elevation_id"`
+		UserID      identity.ID `json:"user_id"`
+		RoleID      identity.ID `json:"role_id"`
+		ApprovedBy  identity.ID `json:"approved_by"`
+		ExpiresAt   time.Time   `json:"expires_at"`
+	}
+
+	RoleElevationRejected struct {
+		event.Base
+		ElevationID identity.ID `json:"elevation_id"`
+		UserID      identity.ID `json:"user_id"`
+		RoleID      identity.ID `json:"role_id"`
+		RejectedBy  identity.ID `json:"rejected_by"`
+	}
+
+	RoleElevationExpired struct {
+		event.Base
+		ElevationID identity.ID `json:"elevation_id"`
+		UserID      identity.ID `json:"user_id"`
+		RoleID      identity.ID `json:"role_id"`
+	}
+)
+
+func (UserRegistered) EventName() string         { return "account.user.registered" }
+func (UserUpdated) EventName() string            { return "account.user.updated" }
+func (UserEmailChanged) EventName() string       { return "account.user.email_changed" }
+func (UserEmailVerified) EventName() string      { return "account.user.email_verified" }
+func (UserIdentityLinked) EventName() string     { return "account.user.identity_linked" }
+func (UserPasswordChanged) EventName() string    { return "account.user.password_changed" }
+func (UserMFAEnabled) EventName() string         { return "account.user.mfa_enabled" }
+func (UserMFADisabled) EventName() string        { return "account.user.mfa_disabled" }
+func (UserDeactivated) EventName() string        { return "account.user.deactivated" }
+func (UserDeleted) EventName() string            { return "account.user.deleted" }
+func (RoleCreated) EventName() string            { return "account.role.created" }
+func (RolePermissionsChanged) EventName() string { return "account.role.permissions_changed" }
+func (RoleParentChanged) EventName() string      { return "account.role.parent_changed" }
+func (RoleDeleted) EventName() string            { return "account.role.deleted" }
+func (RoleAssigned) EventName() string           { return "account.role.assigned" }
+func (RoleUnassigned) EventName() string         { return "account.role.unassigned" }
+func (RoleAssignmentExpired) EventName() string  { return "account.role.assignment_expired" }
+func (RoleElevationRequested) EventName() string { return "account.role_elevation.requested" }
+func (RoleElevationApproved) EventName() string  { return "account.role_elevation.approved" }
+func (RoleElevationRejected) EventName() string  { return "account.role_elevation.rejected" }
+func (RoleElevationExpired) EventName() string   { return "account.role_elevation.expired" }
+
+func (e UserRegistered) AggregateID() string         { return e.UserID.String() }
+func (e UserUpdated) AggregateID() string            { return e.UserID.String() }
+func (e UserEmailChanged) AggregateID() string       { return e.UserID.String() }
+func (e UserEmailVerified) AggregateID() string      { return e.UserID.String() }
+func (e UserIdentityLinked) AggregateID() string     { return e.UserID.String() }
+func (e UserPasswordChanged) AggregateID() string    { return e.UserID.String() }
+func (e UserMFAEnabled) AggregateID() string         { return e.UserID.String() }
+func (e UserMFADisabled) AggregateID() string        { return e.UserID.String() }
+func (e UserDeactivated) AggregateID() string        { return e.UserID.String() }
+func (e UserDeleted) AggregateID() string            { return e.UserID.String() }
+func (e RoleCreated) AggregateID() string            { return e.RoleID.String() }
+func (e RolePermissionsChanged) AggregateID() string { return e.RoleID.String() }
+func (e RoleParentChanged) AggregateID() string      { return e.RoleID.String() }
+func (e RoleDeleted) AggregateID() string            { return e.RoleID.String() }
+func (e RoleAssigned) AggregateID() string           { return e.UserID.String() }
+func (e RoleUnassigned) AggregateID() string         { return e.UserID.String() }
+func (e RoleAssignmentExpired) AggregateID() string  { return e.UserID.String() }
+func (e RoleElevationRequested) AggregateID() string { return e.ElevationID.String() }
+func (e RoleElevationApproved) AggregateID() string  { return e.ElevationID.String() }
+func (e RoleElevationRejected) AggregateID() string  { return e.ElevationID.String() }
+func (e RoleElevationExpired) AggregateID() string   { return e.ElevationID.String() }
@@ -0,0 +1,97 @@
+package account
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+// ExternalIdentity menghubungkan akun di identity provider eksternal (OIDC)
+// dengan user lokal. Satu user boleh memiliki beberapa identity.
+type ExternalIdentity struct {
+	ID          identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	UserID      identity.ID `json:"user_id" gorm:"column:user_id;type:uuid;index"`
+	Provider    string      `json:"provider" gorm:"column:provider;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject     string      `json:"subject" gorm:"column:subject;uniqueIndex:idx_user_identities_provider_subject"`
+	Email       string      `json:"email" gorm:"column:email"`
+	LastLoginAt *time.Time  `json:"last_login_at" gorm:"column:last_login_at"`
+	CreatedAt   time.Time   `json:"created_at" gorm:"column:created_at"`
+}
+
+func (ExternalIdentity) TableName() string {
+	return "user_identities"
+}
+
+func NewExternalIdentity(userID identity.ID, provider string, claims *ExternalClaims) *ExternalIdentity {
+	return &ExternalIdentity{
+		ID:        identity.New(),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ExternalClaims adalah klaim ID token yang sudah diverifikasi provider
+type ExternalClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string
+}
+
+// OIDCState menyimpan state, nonce dan PKCE verifier selama user berada di
+// halaman login provider. Hanya hash state yang disimpan.
+type OIDCState struct {
+	StateHash    string    `gorm:"column:state_hash;primaryKey"`
+	Provider     string    `gorm:"column:provider"`
+	Nonce        string    `gorm:"column:nonce"`
+	CodeVerifier string    `gorm:"column:code_verifier"`
+	ExpiresAt    time.Time `gorm:"column:expires_at"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+func (OIDCState) TableName() string {
+	return "oidc_states"
+}
+
+// NewOIDCState mengembalikan state beserta nilai plain yang dikirim ke provider
+func NewOIDCState(provider string, ttl time.Duration) (*OIDCState, string, error) {
+	plain, err := token.Generate(32)
+	if err != nil {
+		return nil, "", err
+	}
+	nonce, err := token.Generate(32)
+	if err != nil {
+		return nil, "", err
+	}
+	verifier, err := token.Generate(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &OIDCState{
+		StateHash:    token.Hash(plain),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	}, plain, nil
+}
+
+func (s *OIDCState) IsUsable(provider string, now time.Time) bool {
+	return s.Provider == provider && now.Before(s.ExpiresAt)
+}
+
+// CodeChallenge adalah PKCE challenge metode S256 dari CodeVerifier
+func (s *OIDCState) CodeChallenge() string {
+	sum := sha256.Sum256([]byte(s.CodeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IAccessTokenIssuer interface {
+	/**
+	 * Issue creates a signed access token for an authenticated user, bound
+	 * to the given session.
+	 * @param ctx context.Context
+	 * @param user *account.User
+	 * @param sessionID identity.ID
+	 * @return (string, time.Time, error)
+	 */
+	Issue(ctx context.Context, user *account.User, sessionID identity.ID) (token string, expiresAt time.Time, err error)
+}
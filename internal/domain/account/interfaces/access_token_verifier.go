@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+)
+
+type IAccessTokenVerifier interface {
+	/**
+	 * Verify checks the signature and expiry of an access token.
+	 * @param ctx context.Context
+	 * @param token string
+	 * @return (*account.Principal, error)
+	 */
+	Verify(ctx context.Context, token string) (result *account.Principal, err error)
+}
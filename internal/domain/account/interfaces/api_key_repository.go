@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IAPIKeyRepository interface {
+	Create(ctx context.Context, key *account.APIKey) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *account.APIKey, err error)
+	FindByHash(ctx context.Context, hash string) (result *account.APIKey, err error)
+	FindByUser(ctx context.Context, userID identity.ID) (result []*account.APIKey, err error)
+
+	/**
+	 * Revoke marks a key as revoked. It fails with ErrNotFound when the key
+	 * does not exist or is already revoked.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @param at time.Time
+	 * @return error
+	 */
+	Revoke(ctx context.Context, id identity.ID, at time.Time) (err error)
+
+	TouchLastUsed(ctx context.Context, id identity.ID, at time.Time) (err error)
+}
@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IAPIKeyService interface {
+	/**
+	 * Create issues a new API key for the actor, or for another user when the
+	 * actor has api_keys:write. Scopes must be a subset of the permissions of
+	 * the user's role and of the actor's current scopes.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param payload *account.CreateAPIKeyRequest
+	 * @return (*account.CreatedAPIKeyResponse, error)
+	 */
+	Create(ctx context.Context, userID identity.ID, payload *account.CreateAPIKeyRequest) (result *account.CreatedAPIKeyResponse, err error)
+
+	List(ctx context.Context, userID identity.ID) (result []*account.APIKeyResponse, err error)
+	Revoke(ctx context.Context, userID identity.ID, keyID identity.ID) (err error)
+
+	/**
+	 * Authenticate resolves the principal of a plain API key.
+	 * @param ctx context.Context
+	 * @param plain string
+	 * @return (*account.Principal, error)
+	 */
+	Authenticate(ctx context.Context, plain string) (result *account.Principal, err error)
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+)
+
+type IAuthService interface {
+	/**
+	 * Login checks the credentials of a user. When the user has MFA enabled
+	 * the response carries a challenge token instead of an access token.
+	 * @param ctx context.Context
+	 * @param payload *account.LoginRequest
+	 * @return (*account.LoginResponse, error)
+	 */
+	Login(ctx context.Context, payload *account.LoginRequest) (result *account.LoginResponse, err error)
+
+	/**
+	 * VerifyMFA completes a login with a TOTP or recovery code.
+	 * @param ctx context.Context
+	 * @param payload *account.VerifyMFARequest
+	 * @return (*account.LoginResponse, error)
+	 */
+	VerifyMFA(ctx context.Context, payload *account.VerifyMFARequest) (result *account.LoginResponse, err error)
+}
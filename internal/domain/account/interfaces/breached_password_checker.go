@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+)
+
+type IBreachedPasswordChecker interface {
+	/**
+	 * IsBreached reports whether the password appears in a known breach.
+	 * @param ctx context.Context
+	 * @param password string
+	 * @return (bool, error)
+	 */
+	IsBreached(ctx context.Context, password string) (breached bool, err error)
+}
@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IEmailVerificationService interface {
+	/**
+	 * SendVerification issues a new verification token for the user's current
+	 * email and mails the link. Earlier unused tokens are invalidated.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @return error
+	 */
+	SendVerification(ctx context.Context, userID identity.ID) (err error)
+
+	/**
+	 * Confirm marks the email as verified using a verification token.
+	 * @param ctx context.Context
+	 * @param token string
+	 * @return error
+	 */
+	Confirm(ctx context.Context, token string) (err error)
+
+	/**
+	 * Resend sends a new verification link, throttled per email and client IP.
+	 * Unknown and already verified emails are throttled and answered the same
+	 * way so callers cannot probe for accounts.
+	 * @param ctx context.Context
+	 * @param email string
+	 * @return error
+	 */
+	Resend(ctx context.Context, email string) (err error)
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IExternalIdentityRepository interface {
+	Create(ctx context.Context, external *account.ExternalIdentity) (err error)
+	FindByProviderSubject(ctx context.Context, provider string, subject string) (result *account.ExternalIdentity, err error)
+	TouchLastLogin(ctx context.Context, id identity.ID, at time.Time) (err error)
+}
+
+type IOIDCStateRepository interface {
+	Create(ctx context.Context, state *account.OIDCState) (err error)
+
+	/**
+	 * Consume deletes the state and returns it, so a state can only be used
+	 * once. It fails with ErrNotFound when the state does not exist.
+	 * @param ctx context.Context
+	 * @param stateHash string
+	 * @return (*account.OIDCState, error)
+	 */
+	Consume(ctx context.Context, stateHash string) (result *account.OIDCState, err error)
+}
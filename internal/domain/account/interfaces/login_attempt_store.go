@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+)
+
+type ILoginAttemptStore interface {
+	/**
+	 * Get retrieves the attempt counter of a key.
+	 * @param ctx context.Context
+	 * @param key string
+	 * @return (*account.LoginAttempt, error)
+	 */
+	Get(ctx context.Context, key string) (result *account.LoginAttempt, err error)
+
+	/**
+	 * RecordFailure atomically increments the failure counter. The counter
+	 * starts over when the previous failure is older than resetAfter.
+	 * @param ctx context.Context
+	 * @param key string
+	 * @param now time.Time
+	 * @param resetAfter time.Duration
+	 * @return (*account.LoginAttempt, error)
+	 */
+	RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (result *account.LoginAttempt, err error)
+
+	/**
+	 * Release atomically takes back one failure recorded by RecordFailure,
+	 * used when an attempt counted up front turns out to be successful.
+	 * @param ctx context.Context
+	 * @param key string
+	 * @return error
+	 */
+	Release(ctx context.Context, key string) (err error)
+
+	/**
+	 * Lock blocks further attempts of a key until the given time.
+	 * @param ctx context.Context
+	 * @param key string
+	 * @param until time.Time
+	 * @return error
+	 */
+	Lock(ctx context.Context, key string, until time.Time) (err error)
+
+	/**
+	 * Reset clears the counter and any lock of a key.
+	 * @param ctx context.Context
+	 * @param key string
+	 * @return error
+	 */
+	Reset(ctx context.Context, key string) (err error)
+}
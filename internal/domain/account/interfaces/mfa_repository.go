@@ -0,0 +1,59 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IMFARepository interface {
+	FindByUserID(ctx context.Context, userID identity.ID) (result *account.UserMFA, err error)
+
+	/**
+	 * Save inserts the MFA settings or replaces the existing row of the user.
+	 * @param ctx context.Context
+	 * @param mfa *account.UserMFA
+	 * @return error
+	 */
+	Save(ctx context.Context, mfa *account.UserMFA) (err error)
+
+	/**
+	 * Delete removes the MFA settings and the recovery codes of a user.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @return error
+	 */
+	Delete(ctx context.Context, userID identity.ID) (err error)
+
+	/**
+	 * ConsumeStep records a used TOTP time step. It fails with ErrNotFound when
+	 * the step is not newer than the last used one, so a code cannot be
+	 * replayed even by concurrent requests.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param step int64
+	 * @return error
+	 */
+	ConsumeStep(ctx context.Context, userID identity.ID, step int64) (err error)
+
+	/**
+	 * ReplaceRecoveryCodes deletes every recovery code of the user and stores
+	 * the given ones.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param codes []account.RecoveryCode
+	 * @return error
+	 */
+	ReplaceRecoveryCodes(ctx context.Context, userID identity.ID, codes []account.RecoveryCode) (err error)
+
+	/**
+	 * ConsumeRecoveryCode marks an unused recovery code as used. It fails with
+	 * ErrNotFound when no unused code matches.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param hash string
+	 * @return error
+	 */
+	ConsumeRecoveryCode(ctx context.Context, userID identity.ID, hash string) (err error)
+}
@@ -0,0 +1,67 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IMFAService interface {
+	/**
+	 * EnrollTOTP generates a new TOTP secret for the actor that stays inactive
+	 * until it is confirmed with ActivateTOTP.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @return (*account.TOTPEnrollmentResponse, error)
+	 */
+	EnrollTOTP(ctx context.Context, userID identity.ID) (result *account.TOTPEnrollmentResponse, err error)
+
+	/**
+	 * ActivateTOTP confirms the enrollment with a first code and returns the
+	 * recovery codes. The codes are shown only once.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param code string
+	 * @return (*account.RecoveryCodesResponse, error)
+	 */
+	ActivateTOTP(ctx context.Context, userID identity.ID, code string) (result *account.RecoveryCodesResponse, err error)
+
+	/**
+	 * EnrollTOTPWithChallenge starts the enrollment that login demands from
+	 * admin accounts without MFA, using the enrollment challenge token.
+	 * @param ctx context.Context
+	 * @param challengeToken string
+	 * @return (*account.TOTPEnrollmentResponse, error)
+	 */
+	EnrollTOTPWithChallenge(ctx context.Context, challengeToken string) (result *account.TOTPEnrollmentResponse, err error)
+
+	/**
+	 * ActivateTOTPWithChallenge confirms that enrollment and consumes the
+	 * challenge token. The user logs in again afterwards.
+	 * @param ctx context.Context
+	 * @param challengeToken string
+	 * @param code string
+	 * @return (*account.RecoveryCodesResponse, error)
+	 */
+	ActivateTOTPWithChallenge(ctx context.Context, challengeToken string, code string) (result *account.RecoveryCodesResponse, err error)
+
+	/**
+	 * DisableTOTP turns MFA off after checking a current code.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param code string
+	 * @return error
+	 */
+	DisableTOTP(ctx context.Context, userID identity.ID, code string) (err error)
+
+	/**
+	 * RegenerateRecoveryCodes replaces all recovery codes after checking a
+	 * current code.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param code string
+	 * @return (*account.RecoveryCodesResponse, error)
+	 */
+	RegenerateRecoveryCodes(ctx context.Context, userID identity.ID, code string) (result *account.RecoveryCodesResponse, err error)
+}
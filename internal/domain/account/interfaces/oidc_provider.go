@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+)
+
+type IOIDCProvider interface {
+	Name() string
+
+	/**
+	 * AuthCodeURL builds the authorization URL of the provider using the
+	 * authorization code flow with a S256 PKCE challenge.
+	 * @param state string
+	 * @param nonce string
+	 * @param codeChallenge string
+	 * @return string
+	 */
+	AuthCodeURL(state string, nonce string, codeChallenge string) string
+
+	/**
+	 * Exchange redeems the authorization code and verifies the returned ID
+	 * token. The nonce is returned unchecked for the caller to compare.
+	 * @param ctx context.Context
+	 * @param code string
+	 * @param codeVerifier string
+	 * @return (*account.ExternalClaims, error)
+	 */
+	Exchange(ctx context.Context, code string, codeVerifier string) (result *account.ExternalClaims, err error)
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+)
+
+type IOIDCService interface {
+	/**
+	 * Start begins a login with an external provider.
+	 * @param ctx context.Context
+	 * @param provider string
+	 * @return (string, error) the authorization URL to redirect to
+	 */
+	Start(ctx context.Context, provider string) (authURL string, err error)
+
+	/**
+	 * Callback completes the login, linking or creating the local user.
+	 * @param ctx context.Context
+	 * @param provider string
+	 * @param state string
+	 * @param code string
+	 * @return (*account.LoginResponse, error)
+	 */
+	Callback(ctx context.Context, provider string, state string, code string) (result *account.LoginResponse, err error)
+}
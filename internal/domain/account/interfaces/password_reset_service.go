@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+)
+
+type IPasswordResetService interface {
+	/**
+	 * RequestReset emails a reset link in the background. It returns nil at the
+	 * same speed whether or not the email belongs to a user so callers cannot
+	 * probe for accounts.
+	 * @param ctx context.Context
+	 * @param email string
+	 * @return error
+	 */
+	RequestReset(ctx context.Context, email string) (err error)
+
+	/**
+	 * ConfirmReset sets a new password using a reset token.
+	 * @param ctx context.Context
+	 * @param token string
+	 * @param password string
+	 * @return error
+	 */
+	ConfirmReset(ctx context.Context, token string, password string) (err error)
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+)
+
+type IPermissionCatalog interface {
+	/**
+	 * All returns every registered permission ordered by group and key.
+	 * @return []permission.Definition
+	 */
+	All() []permission.Definition
+
+	/**
+	 * Validate rejects permission keys missing from the catalog.
+	 * @param keys []string
+	 * @return error
+	 */
+	Validate(keys []string) error
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+)
+
+type IPermissionResolver interface {
+	/**
+	 * UserPermissions merges the effective permissions of the user's primary
+	 * role and every unexpired role assignment, including inherited ones.
+	 * @param ctx context.Context
+	 * @param user *account.User
+	 * @return ([]string, error)
+	 */
+	UserPermissions(ctx context.Context, user *account.User) (result []string, err error)
+
+	/**
+	 * ActorSubject builds the policy subject of the actor in ctx, limited to
+	 * the credential's scopes. ok is false when there is no actor.
+	 * @param ctx context.Context
+	 * @return (policy.Subject, bool, error)
+	 */
+	ActorSubject(ctx context.Context) (subject policy.Subject, ok bool, err error)
+}
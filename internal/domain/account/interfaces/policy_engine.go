@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+)
+
+type IPolicyEngine interface {
+	/**
+	 * Authorize evaluates the request against the registered rules and
+	 * returns errs.ErrForbidden when it is not allowed.
+	 * @param request policy.Request
+	 * @return error
+	 */
+	Authorize(request policy.Request) error
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IRoleElevationRepository interface {
+	Create(ctx context.Context, elevation *account.RoleElevation) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *account.RoleElevation, err error)
+	FindAll(ctx context.Context, filter *account.RoleElevationFilter) (result []*account.RoleElevation, totalItems int64, err error)
+	Update(ctx context.Context, elevation *account.RoleElevation) (err error)
+
+	/**
+	 * FindExpired returns active elevations whose expiry has passed, locking
+	 * them so concurrent sweepers skip the same rows.
+	 * @param ctx context.Context
+	 * @param now time.Time
+	 * @param limit int
+	 * @return ([]*account.RoleElevation, error)
+	 */
+	FindExpired(ctx context.Context, now time.Time, limit int) (result []*account.RoleElevation, err error)
+}
@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IRoleElevationService interface {
+	/**
+	 * Request grants a role to a user for a limited time. When approval is
+	 * required the elevation stays pending until a second admin approves it.
+	 * Requires an actor with PermissionRolesElevate.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param payload *account.RequestRoleElevationRequest
+	 * @return (*account.RoleElevationResponse, error)
+	 */
+	Request(ctx context.Context, userID identity.ID, payload *account.RequestRoleElevationRequest) (result *account.RoleElevationResponse, err error)
+
+	/**
+	 * Approve activates a pending elevation; the expiry starts now. Requires
+	 * PermissionRolesApprove and an actor other than the requester or grantee.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @return (*account.RoleElevationResponse, error)
+	 */
+	Approve(ctx context.Context, id identity.ID) (result *account.RoleElevationResponse, err error)
+
+	/**
+	 * Reject declines a pending elevation. Requires PermissionRolesApprove.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @return (*account.RoleElevationResponse, error)
+	 */
+	Reject(ctx context.Context, id identity.ID) (result *account.RoleElevationResponse, err error)
+
+	FindByID(ctx context.Context, id identity.ID) (result *account.RoleElevationResponse, err error)
+	FindAll(ctx context.Context, filter *account.RoleElevationFilter) (result []*account.RoleElevationResponse, totalItems int64, err error)
+}
@@ -2,19 +2,34 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
-	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type IRoleRepository interface {
 	Create(ctx context.Context, role *account.Role) (err error)
 	FindById(ctx context.Context, id string) (result *account.Role, err error)
-	FindManyByID(ctx context.Context, ids []bson.ObjectID) (result *[]account.Role, err error)
+	FindChain(ctx context.Context, id string) (result []*account.Role, err error)
+	CountChildren(ctx context.Context, id string) (total int64, err error)
+
+	/**
+	 * DescendantDepth returns how many levels of child roles hang below the
+	 * role, 0 for a leaf or an unknown role. The walk stops at MaxRoleDepth.
+	 * @param ctx context.Context
+	 * @param id string
+	 * @return (int, error)
+	 */
+	DescendantDepth(ctx context.Context, id string) (depth int, err error)
+	FindManyByID(ctx context.Context, ids []identity.ID) (result *[]account.Role, err error)
 	FindAll(ctx context.Context, filter *model.PaginationFilter) (result *[]account.Role, totalItems int64, err error)
 	Update(ctx context.Context, id string, role *account.Role) (err error)
 	Delete(ctx context.Context, id string) (err error)
-	AssignUser(ctx context.Context, userId string, roleId string) (err error)
+	AssignUser(ctx context.Context, assignment *account.UserRole) (err error)
 	UnassignUser(ctx context.Context, userId string, roleId string) (err error)
+	FindUserRoles(ctx context.Context, userId string, now time.Time) (result []*account.UserRole, err error)
+	FindExpiredAssignments(ctx context.Context, now time.Time, limit int) (result []*account.UserRole, err error)
+	DeleteExpiredAssignment(ctx context.Context, assignment *account.UserRole, now time.Time) (err error)
 }
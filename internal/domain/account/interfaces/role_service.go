@@ -2,19 +2,78 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
-	"go.mongodb.org/mongo-driver/v2/bson"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
 )
 
 type IRoleService interface {
-	Create(ctx context.Context, role *account.Role) (err error)
-	FindById(ctx context.Context, id string) (result *account.Role, err error)
-	FindManyByID(ctx context.Context, ids []bson.ObjectID) (result *[]account.Role, err error)
-	FindAll(ctx context.Context, filter *model.PaginationFilter) (result *[]account.Role, totalItems int64, err error)
-	Update(ctx context.Context, id string, role *account.Role) (err error)
+	/**
+	 * Create creates a new role. The actor needs roles:write.
+	 * @param ctx context.Context
+	 * @param role *CreateRoleRequest
+	 * @return error
+	 */
+	Create(ctx context.Context, role *account.CreateRoleRequest) (err error)
+
+	FindById(ctx context.Context, id string) (result *account.RoleResponse, err error)
+	FindManyByID(ctx context.Context, ids []identity.ID) (result *[]account.RoleResponse, err error)
+	FindAll(ctx context.Context, filter *model.PaginationFilter) (result *[]account.RoleResponse, totalItems int64, err error)
+
+	/**
+	 * Update updates a role. The actor needs roles:write.
+	 * @param ctx context.Context
+	 * @param id string
+	 * @param role *UpdateRoleRequest
+	 * @return error
+	 */
+	Update(ctx context.Context, id string, role *account.UpdateRoleRequest) (err error)
+
+	/**
+	 * Delete deletes a role without children. The actor needs roles:delete.
+	 * @param ctx context.Context
+	 * @param id string
+	 * @return error
+	 */
 	Delete(ctx context.Context, id string) (err error)
-	AssignUser(ctx context.Context, userId string, roleId string) (err error)
+
+	Catalog(ctx context.Context) (result []permission.Definition, err error)
+	EffectivePermissions(ctx context.Context, id string) (result *account.EffectivePermissionsResponse, err error)
+
+	/**
+	 * AssignUser assigns a role to a user. The actor needs roles:assign.
+	 * @param ctx context.Context
+	 * @param userId string
+	 * @param roleId string
+	 * @param request *AssignRoleRequest
+	 * @return error
+	 */
+	AssignUser(ctx context.Context, userId string, roleId string, request *account.AssignRoleRequest) (err error)
+
+	/**
+	 * GrantUser assigns a role to a user without checking the actor's
+	 * permissions. It is meant for internal flows that authorize on their own,
+	 * such as an approved role elevation, and must not be exposed over HTTP.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param roleID identity.ID
+	 * @param expiresAt *time.Time
+	 * @return error
+	 */
+	GrantUser(ctx context.Context, userID identity.ID, roleID identity.ID, expiresAt *time.Time) (err error)
+
+	/**
+	 * UnassignUser removes a role assignment from a user. The actor needs
+	 * roles:assign.
+	 * @param ctx context.Context
+	 * @param userId string
+	 * @param roleId string
+	 * @return error
+	 */
 	UnassignUser(ctx context.Context, userId string, roleId string) (err error)
+
+	FindUserRoles(ctx context.Context, userID identity.ID) (result []*account.UserRoleResponse, err error)
 }
@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type ISessionRepository interface {
+	Create(ctx context.Context, session *account.Session) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *account.Session, err error)
+	FindActiveByUser(ctx context.Context, userID identity.ID, now time.Time) (result []*account.Session, err error)
+
+	/**
+	 * Revoke revokes one session. It fails with ErrNotFound when the session
+	 * does not exist or is already revoked.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @param at time.Time
+	 * @return error
+	 */
+	Revoke(ctx context.Context, id identity.ID, at time.Time) (err error)
+
+	/**
+	 * RevokeAllForUser revokes every active session of a user.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param at time.Time
+	 * @return (int64, error) number of revoked sessions
+	 */
+	RevokeAllForUser(ctx context.Context, userID identity.ID, at time.Time) (revoked int64, err error)
+
+	TouchLastSeen(ctx context.Context, id identity.ID, at time.Time) (err error)
+}
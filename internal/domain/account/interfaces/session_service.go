@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type ISessionService interface {
+	/**
+	 * List returns the active sessions of a user. The session of the current
+	 * request is flagged as current. Listing another user's sessions requires
+	 * PermissionSessionsRead.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @return ([]*account.SessionResponse, error)
+	 */
+	List(ctx context.Context, userID identity.ID) (result []*account.SessionResponse, err error)
+
+	Revoke(ctx context.Context, userID identity.ID, sessionID identity.ID) (err error)
+
+	/**
+	 * RevokeAll signs a user out everywhere. Revoking another user's sessions
+	 * requires PermissionSessionsRevoke.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @return error
+	 */
+	RevokeAll(ctx context.Context, userID identity.ID) (err error)
+}
@@ -19,6 +19,19 @@ type IUserRepository interface {
 	 */
 	GetAll(ctx context.Context, search string, limit int, page int, sort string) (result []*account.User, totalItems int64, err error)
 
+	/**
+	 * GetAllByRole retrieves users holding a role, either as their primary
+	 * role or through an unexpired user_roles assignment.
+	 * @param ctx context.Context
+	 * @param roleID identity.ID
+	 * @param search string
+	 * @param limit int
+	 * @param page int
+	 * @param sort string
+	 * @return ([]*User, int64, error)
+	 */
+	GetAllByRole(ctx context.Context, roleID identity.ID, search string, limit int, page int, sort string) (result []*account.User, totalItems int64, err error)
+
 	/**
 	 * GetByID retrieves a user by their ID.
 	 * @param ctx context.Context
@@ -59,6 +72,16 @@ type IUserRepository interface {
 	 */
 	Update(ctx context.Context, user *account.User) (err error)
 
+	/**
+	 * UpdatePassword stores a new password hash without touching the version,
+	 * used when a hash is upgraded on login.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @param password PasswordHash
+	 * @return error
+	 */
+	UpdatePassword(ctx context.Context, id identity.ID, password account.PasswordHash) (err error)
+
 	/**
 	 * Delete deletes a user.
 	 * @param ctx context.Context
@@ -9,42 +9,63 @@ import (
 
 type IUserService interface {
 	/**
-	 * GetALl retrieves a user by filter.
+	 * GetALl retrieves a user by filter. The actor needs users:read.
 	 * @param ctx context.Context
 	 * @param limit int
 	 * @param page int
 	 * @param sort string
 	 * @param search string
+	 * @param fields []string
 	 * @return (*UserResponse, error)
 	 */
-	GetAll(ctx context.Context, search string, limit int, page int, sort string) (result []*account.UserResponse, totalItems int64, err error)
+	GetAll(ctx context.Context, search string, limit int, page int, sort string, fields []string) (result []*account.UserResponse, totalItems int64, err error)
 
 	/**
-	 * GetByID retrieves a user by their ID.
+	 * GetAllByRole retrieves users holding a role as primary role or through
+	 * an unexpired assignment. The actor needs users:read.
+	 * @param ctx context.Context
+	 * @param roleID identity.ID
+	 * @param search string
+	 * @param limit int
+	 * @param page int
+	 * @param sort string
+	 * @param fields []string
+	 * @return ([]*UserResponse, int64, error)
+	 */
+	GetAllByRole(ctx context.Context, roleID identity.ID, search string, limit int, page int, sort string, fields []string) (result []*account.UserResponse, totalItems int64, err error)
+
+	/**
+	 * GetByID retrieves a user by their ID. fields limits the response to the
+	 * given JSON properties; an empty list returns every property. Users can
+	 * read themselves, other users need users:read.
 	 * @param ctx context.Context
 	 * @param id identity.ID
+	 * @param fields []string
 	 * @return (*UserResponse, error)
 	 */
-	GetByID(ctx context.Context, id identity.ID) (result *account.UserResponse, err error)
+	GetByID(ctx context.Context, id identity.ID, fields []string) (result *account.UserResponse, err error)
 
 	/**
-	 * GetByEmail retrieves a user by their email.
+	 * GetByEmail retrieves a user by their email. The actor needs users:read.
 	 * @param ctx context.Context
 	 * @param email string
+	 * @param fields []string
 	 * @return (*UserResponse, error)
 	 */
-	GetByEmail(ctx context.Context, email string) (result *account.UserResponse, err error)
+	GetByEmail(ctx context.Context, email string, fields []string) (result *account.UserResponse, err error)
 
 	/**
-	 * GetByUsername retrieves a user by their username.
+	 * GetByUsername retrieves a user by their username. The actor needs
+	 * users:read.
 	 * @param ctx context.Context
 	 * @param username string
+	 * @param fields []string
 	 * @return (*UserResponse, error)
 	 */
-	GetByUsername(ctx context.Context, username string) (result *account.UserResponse, err error)
+	GetByUsername(ctx context.Context, username string, fields []string) (result *account.UserResponse, err error)
 
 	/**
-	 * Create creates a new user.
+	 * Create creates a new user. The actor needs users:write.
 	 * @param ctx context.Context
 	 * @param user *CreateUserRequest
 	 * @return error
@@ -61,7 +82,24 @@ type IUserService interface {
 	Update(ctx context.Context, id identity.ID, user *account.UpdateUserRequest) (err error)
 
 	/**
-	 * Delete deletes a user.
+	 * Deactivate deactivates a user. The actor needs users:delete.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @return error
+	 */
+	Deactivate(ctx context.Context, id identity.ID) (err error)
+
+	/**
+	 * Unlock clears the failed login counter and lockout of a user. The actor
+	 * needs users:unlock.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @return error
+	 */
+	Unlock(ctx context.Context, id identity.ID) (err error)
+
+	/**
+	 * Delete deletes a user. The actor needs users:delete.
 	 * @param ctx context.Context
 	 * @param id identity.ID
 	 * @return error
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IUserTokenRepository interface {
+	Create(ctx context.Context, token *account.UserToken) (err error)
+	FindByHash(ctx context.Context, hash string, purpose account.TokenPurpose) (result *account.UserToken, err error)
+
+	/**
+	 * MarkUsed consumes a token. It fails with ErrNotFound when the token was
+	 * already used, so concurrent confirmations cannot both succeed.
+	 * @param ctx context.Context
+	 * @param token *account.UserToken
+	 * @return error
+	 */
+	MarkUsed(ctx context.Context, token *account.UserToken) (err error)
+
+	/**
+	 * InvalidateForUser marks every unused token of a purpose as used.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param purpose account.TokenPurpose
+	 * @return error
+	 */
+	InvalidateForUser(ctx context.Context, userID identity.ID, purpose account.TokenPurpose) (err error)
+}
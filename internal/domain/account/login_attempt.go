@@ -0,0 +1,106 @@
+package account
+
+import (
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// LoginAttempt menghitung kegagalan login berturut-turut untuk satu kunci,
+// yaitu satu akun atau satu alamat IP
+type LoginAttempt struct {
+	Key          string     `json:"key" gorm:"column:key;primaryKey"`
+	Failures     int        `json:"failures" gorm:"column:failures;not null;default:0"`
+	LastFailedAt time.Time  `json:"last_failed_at" gorm:"column:last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until" gorm:"column:locked_until"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// AccountAttemptKey dipakai saat akun ditemukan, IdentifierAttemptKey saat
+// tidak, agar identifier yang tidak terdaftar tetap dibatasi dengan cara yang sama
+func AccountAttemptKey(userID identity.ID) string {
+	return "user:" + userID.String()
+}
+
+func IdentifierAttemptKey(identifier string) string {
+	return "identifier:" + strings.ToLower(strings.TrimSpace(identifier))
+}
+
+func IPAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+// ResendEmailKey dan ResendIPKey membatasi kirim ulang email verifikasi
+// tanpa bergantung pada ada tidaknya akun
+func ResendEmailKey(email string) string {
+	return "resend:email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ResendIPKey(ip string) string {
+	return "resend:ip:" + ip
+}
+
+// LockoutPolicy mengatur jeda progresif dan penguncian sementara
+type LockoutPolicy struct {
+	// FreeAttempts adalah jumlah kegagalan sebelum jeda mulai berlaku
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+
+	// MaxFailures mengunci kunci selama LockoutDuration
+	MaxFailures     int
+	LockoutDuration time.Duration
+
+	// ResetAfter mengosongkan hitungan jika tidak ada kegagalan selama durasi ini
+	ResetAfter time.Duration
+}
+
+var DefaultAccountLockoutPolicy = LockoutPolicy{
+	FreeAttempts:    3,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Minute,
+	MaxFailures:     10,
+	LockoutDuration: 15 * time.Minute,
+	ResetAfter:      time.Hour,
+}
+
+// DefaultIPLockoutPolicy lebih longgar karena banyak user bisa berbagi satu IP
+var DefaultIPLockoutPolicy = LockoutPolicy{
+	FreeAttempts:    20,
+	BaseDelay:       time.Second,
+	MaxDelay:        30 * time.Second,
+	MaxFailures:     100,
+	LockoutDuration: 15 * time.Minute,
+	ResetAfter:      time.Hour,
+}
+
+// LockUntil menghitung batas waktu percobaan berikutnya setelah sejumlah
+// kegagalan, nil berarti percobaan berikutnya boleh langsung dilakukan
+func (p LockoutPolicy) LockUntil(failures int, now time.Time) *time.Time {
+	if p.MaxFailures > 0 && failures >= p.MaxFailures {
+		until := now.Add(p.LockoutDuration)
+		return &until
+	}
+	if failures <= p.FreeAttempts || p.BaseDelay <= 0 {
+		return nil
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	until := now.Add(delay)
+	return &until
+}
@@ -0,0 +1,156 @@
+package account
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/otp"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+const (
+	// TOTPSkew menerima satu langkah sebelum dan sesudah waktu server
+	TOTPSkew = 1
+
+	RecoveryCodeCount = 10
+
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength   = 10
+)
+
+// UserMFA menyimpan secret TOTP milik user dalam bentuk terenkripsi. Secret
+// yang belum diaktifkan (EnabledAt nil) adalah pendaftaran yang menunggu
+// konfirmasi kode pertama.
+type UserMFA struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	UserID       identity.ID `json:"user_id" gorm:"column:user_id;type:uuid;primaryKey"`
+	TenantID     identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	Secret       string      `json:"-" gorm:"column:secret" audit:"redact"`
+	EnabledAt    *time.Time  `json:"enabled_at" gorm:"column:enabled_at"`
+	LastUsedStep int64       `json:"-" gorm:"column:last_used_step" audit:"-"`
+	CreatedAt    time.Time   `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt    time.Time   `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+// RecoveryCode adalah kode cadangan sekali pakai, hanya hash-nya yang disimpan
+type RecoveryCode struct {
+	ID        identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	TenantID  identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	UserID    identity.ID `json:"user_id" gorm:"column:user_id;type:uuid;index"`
+	CodeHash  string      `json:"-" gorm:"column:code_hash;uniqueIndex"`
+	UsedAt    *time.Time  `json:"used_at" gorm:"column:used_at"`
+	CreatedAt time.Time   `json:"created_at" gorm:"column:created_at"`
+}
+
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+// NewUserMFA membuat pendaftaran TOTP baru yang belum aktif. Secret plain
+// dikembalikan terpisah untuk ditampilkan sekali ke user.
+func NewUserMFA(userID identity.ID, cipher ISecretCipher) (*UserMFA, string, error) {
+	secret, err := otp.GenerateSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	encrypted, err := cipher.Encrypt(secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &UserMFA{
+		UserID:    userID,
+		Secret:    encrypted,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, secret, nil
+}
+
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// Check mencocokkan kode dan menolak langkah yang sudah pernah dipakai. Error
+// hanya dikembalikan bila secret gagal didekripsi.
+func (m *UserMFA) Check(cipher ISecretCipher, code string, now time.Time) (step int64, ok bool, err error) {
+	secret, err := cipher.Decrypt(m.Secret)
+	if err != nil {
+		return 0, false, err
+	}
+
+	step, ok = otp.Validate(secret, strings.TrimSpace(code), now, TOTPSkew)
+	if !ok || step <= m.LastUsedStep {
+		return 0, false, nil
+	}
+	return step, true, nil
+}
+
+func (m *UserMFA) Enable(step int64) {
+	if m.IsEnabled() {
+		return
+	}
+
+	now := time.Now()
+	m.EnabledAt = &now
+	m.LastUsedStep = step
+	m.UpdatedAt = now
+	m.Record(UserMFAEnabled{Base: event.NewBase(), UserID: m.UserID})
+}
+
+func (m *UserMFA) MarkDisabled() {
+	m.Record(UserMFADisabled{Base: event.NewBase(), UserID: m.UserID})
+}
+
+// NewRecoveryCodes membuat kode cadangan baru beserta nilai plain untuk user
+func NewRecoveryCodes(userID identity.ID) ([]RecoveryCode, []string, error) {
+	codes := make([]RecoveryCode, 0, RecoveryCodeCount)
+	plain := make([]string, 0, RecoveryCodeCount)
+	now := time.Now()
+
+	for range RecoveryCodeCount {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		plain = append(plain, code)
+		codes = append(codes, RecoveryCode{
+			ID:        identity.New(),
+			UserID:    userID,
+			CodeHash:  HashRecoveryCode(code),
+			CreatedAt: now,
+		})
+	}
+	return codes, plain, nil
+}
+
+// HashRecoveryCode menormalkan input user sebelum di-hash
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return token.Hash(normalized)
+}
+
+func randomRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	var code strings.Builder
+	for i, b := range buf {
+		if i == recoveryCodeLength/2 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+	}
+	return code.String(), nil
+}
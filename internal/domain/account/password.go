@@ -0,0 +1,71 @@
+package account
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+)
+
+const redactedPassword = "[REDACTED]"
+
+// PasswordHash membungkus hash password yang tersimpan. Nilainya tidak pernah
+// ikut ter-serialize ke JSON, log, maupun fmt; gunakan Encoded untuk
+// mengambil hash secara eksplisit.
+type PasswordHash struct {
+	encoded string
+}
+
+func NewPasswordHash(encoded string) PasswordHash {
+	return PasswordHash{encoded: encoded}
+}
+
+func (p PasswordHash) Encoded() string {
+	return p.encoded
+}
+
+func (p PasswordHash) IsZero() bool {
+	return p.encoded == ""
+}
+
+func (p PasswordHash) String() string {
+	return redactedPassword
+}
+
+func (p PasswordHash) GoString() string {
+	return redactedPassword
+}
+
+// Format menangani semua verb termasuk %+v dan %#v pada struct induknya
+func (p PasswordHash) Format(f fmt.State, verb rune) {
+	_, _ = f.Write([]byte(redactedPassword))
+}
+
+func (p PasswordHash) LogValue() slog.Value {
+	return slog.StringValue(redactedPassword)
+}
+
+func (p PasswordHash) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redactedPassword + `"`), nil
+}
+
+func (p PasswordHash) MarshalText() ([]byte, error) {
+	return []byte(redactedPassword), nil
+}
+
+func (p PasswordHash) Value() (driver.Value, error) {
+	return p.encoded, nil
+}
+
+func (p *PasswordHash) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		p.encoded = ""
+	case string:
+		p.encoded = value
+	case []byte:
+		p.encoded = string(value)
+	default:
+		return fmt.Errorf("cannot scan %T into PasswordHash", src)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package account
+
+// IPasswordHasher meng-encode password ke string yang bisa disimpan dan
+// memverifikasinya kembali. Hash yang dihasilkan memuat algoritma dan
+// parameternya sehingga hash lama tetap bisa diverifikasi setelah konfigurasi
+// berubah.
+type IPasswordHasher interface {
+	Hash(password string) (encoded string, err error)
+	Verify(password string, encoded string) (ok bool, err error)
+
+	// NeedsRehash bernilai true jika hash memakai algoritma atau parameter lama
+	NeedsRehash(encoded string) bool
+}
@@ -0,0 +1,94 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+// bcryptMaxBytes adalah batas input bcrypt, byte setelahnya diabaikan
+const bcryptMaxBytes = 72
+
+// minIdentityLength mencegah potongan identitas yang terlalu pendek ikut dicek
+const minIdentityLength = 3
+
+// PasswordPolicy berisi aturan password yang berlaku untuk semua user
+type PasswordPolicy struct {
+	MinLength     int
+	MaxBytes      int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// DisallowIdentity menolak password yang memuat username atau bagian lokal email
+	DisallowIdentity bool
+}
+
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:        10,
+	MaxBytes:         bcryptMaxBytes,
+	RequireUpper:     true,
+	RequireLower:     true,
+	RequireDigit:     true,
+	DisallowIdentity: true,
+}
+
+// Validate memeriksa password terhadap policy dan identitas user
+func (p PasswordPolicy) Validate(password, username, email string) error {
+	if length := len([]rune(password)); length < p.MinLength {
+		return passwordError(fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+
+	maxBytes := p.MaxBytes
+	if maxBytes <= 0 || maxBytes > bcryptMaxBytes {
+		maxBytes = bcryptMaxBytes
+	}
+	if len(password) > maxBytes {
+		return passwordError(fmt.Sprintf("must be at most %d bytes", maxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return passwordError("must contain an uppercase letter")
+	case p.RequireLower && !hasLower:
+		return passwordError("must contain a lowercase letter")
+	case p.RequireDigit && !hasDigit:
+		return passwordError("must contain a digit")
+	case p.RequireSymbol && !hasSymbol:
+		return passwordError("must contain a symbol")
+	}
+
+	if p.DisallowIdentity {
+		lowered := strings.ToLower(password)
+		localPart, _, _ := strings.Cut(email, "@")
+		for _, identity := range []string{username, localPart} {
+			identity = strings.ToLower(identity)
+			if len(identity) >= minIdentityLength && strings.Contains(lowered, identity) {
+				return passwordError("must not contain your username or email")
+			}
+		}
+	}
+
+	return nil
+}
+
+func passwordError(message string) error {
+	return errs.ValidationError{Field: "password", Message: message}
+}
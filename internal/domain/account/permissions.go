@@ -0,0 +1,40 @@
+package account
+
+import "github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+
+const (
+	PermissionUsersRead      = "users:read"
+	PermissionUsersWrite     = "users:write"
+	PermissionUsersDelete    = "users:delete"
+	PermissionUsersUnlock    = "users:unlock"
+	PermissionUsersManage    = "users:manage_department"
+	PermissionRolesRead      = "roles:read"
+	PermissionRolesWrite     = "roles:write"
+	PermissionRolesDelete    = "roles:delete"
+	PermissionRolesAssign    = "roles:assign"
+	PermissionRolesElevate   = "roles:elevate"
+	PermissionRolesApprove   = "roles:approve_elevation"
+	PermissionSessionsRead   = "sessions:read"
+	PermissionSessionsRevoke = "sessions:revoke"
+	PermissionAPIKeysRead    = "api_keys:read"
+	PermissionAPIKeysWrite   = "api_keys:write"
+)
+
+// Permissions adalah permission yang dideklarasikan modul account
+var Permissions = []permission.Definition{
+	{Key: PermissionUsersRead, Description: "View users", Group: "users"},
+	{Key: PermissionUsersWrite, Description: "Create and update users", Group: "users"},
+	{Key: PermissionUsersDelete, Description: "Delete and deactivate users", Group: "users"},
+	{Key: PermissionUsersManage, Description: "Update the profile of users in the same department as the manager", Group: "users"},
+	{Key: PermissionUsersUnlock, Description: "Unlock users locked out by failed logins", Group: "users"},
+	{Key: PermissionRolesRead, Description: "View roles and their permissions", Group: "roles"},
+	{Key: PermissionRolesWrite, Description: "Create and update roles", Group: "roles"},
+	{Key: PermissionRolesDelete, Description: "Delete roles", Group: "roles"},
+	{Key: PermissionRolesAssign, Description: "Assign and unassign roles to users", Group: "roles"},
+	{Key: PermissionRolesElevate, Description: "Grant roles temporarily or request a temporary grant", Group: "roles"},
+	{Key: PermissionRolesApprove, Description: "Approve or reject temporary role grants", Group: "roles"},
+	{Key: PermissionSessionsRead, Description: "View user sessions", Group: "sessions"},
+	{Key: PermissionSessionsRevoke, Description: "Revoke user sessions", Group: "sessions"},
+	{Key: PermissionAPIKeysRead, Description: "View API keys", Group: "api_keys"},
+	{Key: PermissionAPIKeysWrite, Description: "Create and revoke API keys", Group: "api_keys"},
+}
@@ -0,0 +1,72 @@
+package account
+
+import "github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+
+const (
+	PolicyResourceUser = "user"
+	PolicyUserUpdate   = "user:update"
+
+	// AttributeDepartment adalah atribut user yang dipakai policy manager department
+	AttributeDepartment = "department"
+)
+
+// PolicyResource mengubah user menjadi resource policy; user adalah pemilik dirinya sendiri
+func (u *User) PolicyResource() policy.Resource {
+	return policy.Resource{
+		Type:       PolicyResourceUser,
+		ID:         u.ID,
+		OwnerID:    u.ID,
+		Attributes: u.Attributes,
+	}
+}
+
+// UserPolicies adalah rule bawaan modul account untuk resource user
+var UserPolicies = []policy.Rule{
+	{
+		Name:         "users-write",
+		Effect:       policy.Allow,
+		Actions:      []string{PolicyUserUpdate},
+		ResourceType: PolicyResourceUser,
+		Condition:    policy.HasPermission(PermissionUsersWrite),
+	},
+	{
+		Name:         "users-update-self",
+		Effect:       policy.Allow,
+		Actions:      []string{PolicyUserUpdate},
+		ResourceType: PolicyResourceUser,
+		Condition:    policy.IsOwner(),
+	},
+	{
+		Name:         "users-manage-department",
+		Effect:       policy.Allow,
+		Actions:      []string{PolicyUserUpdate},
+		ResourceType: PolicyResourceUser,
+		Condition:    policy.All(policy.HasPermission(PermissionUsersManage), policy.SameAttribute(AttributeDepartment)),
+	},
+	{
+		Name:         "users-role-requires-assign",
+		Effect:       policy.Deny,
+		Actions:      []string{PolicyUserUpdate},
+		ResourceType: PolicyResourceUser,
+		Fields:       []string{"role_id"},
+		Condition:    policy.Not(policy.HasPermission(PermissionRolesAssign)),
+	},
+	// email dan username ikut dikunci karena keduanya dipakai login dan reset
+	// password, manager department hanya boleh mengubah data profil
+	{
+		Name:         "users-credentials-self-or-write",
+		Effect:       policy.Deny,
+		Actions:      []string{PolicyUserUpdate},
+		ResourceType: PolicyResourceUser,
+		Fields:       []string{"password", "email", "username"},
+		Condition:    policy.Not(policy.AnyOf(policy.IsOwner(), policy.HasPermission(PermissionUsersWrite))),
+	},
+	{
+		Name:         "users-attributes-require-write",
+		Effect:       policy.Deny,
+		Actions:      []string{PolicyUserUpdate},
+		ResourceType: PolicyResourceUser,
+		Fields:       []string{"attributes"},
+		Condition:    policy.Not(policy.HasPermission(PermissionUsersWrite)),
+	},
+}
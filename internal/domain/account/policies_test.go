@@ -0,0 +1,60 @@
+package account
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+)
+
+func TestUserPolicies(t *testing.T) {
+	engine := policy.NewEngine(UserPolicies...)
+	target := &User{ID: identity.New(), Attributes: map[string]string{AttributeDepartment: "sales"}}
+
+	subject := func(permissions ...string) policy.Subject {
+		return policy.Subject{ID: identity.New(), Permissions: permissions}
+	}
+	self := policy.Subject{ID: target.ID}
+	manager := policy.Subject{ID: identity.New(), Permissions: []string{PermissionUsersManage}, Attributes: map[string]string{AttributeDepartment: "sales"}}
+	otherManager := policy.Subject{ID: identity.New(), Permissions: []string{PermissionUsersManage}, Attributes: map[string]string{AttributeDepartment: "finance"}}
+
+	tests := []struct {
+		name    string
+		subject policy.Subject
+		fields  []string
+		allowed bool
+	}{
+		{name: "self profile", subject: self, fields: []string{"name", "email"}, allowed: true},
+		{name: "self password", subject: self, fields: []string{"password"}, allowed: true},
+		{name: "self username", subject: self, fields: []string{"username"}, allowed: true},
+		{name: "self role", subject: self, fields: []string{"role_id"}},
+		{name: "self attributes", subject: self, fields: []string{"attributes"}},
+		{name: "other without permission", subject: subject(), fields: []string{"name"}},
+		{name: "writer profile", subject: subject(PermissionUsersWrite), fields: []string{"name", "password", "attributes"}, allowed: true},
+		{name: "writer credentials", subject: subject(PermissionUsersWrite), fields: []string{"email", "username"}, allowed: true},
+		{name: "writer role without assign", subject: subject(PermissionUsersWrite), fields: []string{"role_id"}},
+		{name: "writer role with assign", subject: subject(PermissionUsersWrite, PermissionRolesAssign), fields: []string{"role_id"}, allowed: true},
+		{name: "manager same department", subject: manager, fields: []string{"name"}, allowed: true},
+		{name: "manager profile", subject: manager, fields: []string{"name", "fullname"}, allowed: true},
+		{name: "manager password", subject: manager, fields: []string{"password"}},
+		{name: "manager email", subject: manager, fields: []string{"email"}},
+		{name: "manager username", subject: manager, fields: []string{"username"}},
+		{name: "manager name with email", subject: manager, fields: []string{"name", "email"}},
+		{name: "manager attributes", subject: manager, fields: []string{"attributes"}},
+		{name: "manager other department", subject: otherManager, fields: []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := engine.Authorize(policy.Request{Subject: tt.subject, Action: PolicyUserUpdate, Resource: target.PolicyResource(), Fields: tt.fields})
+			if tt.allowed && err != nil {
+				t.Fatalf("Authorize() error = %v, want nil", err)
+			}
+			if !tt.allowed && !errors.Is(err, errs.ErrForbidden) {
+				t.Fatalf("Authorize() error = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
@@ -1,19 +1,130 @@
 package account
 
 import (
+	"slices"
+	"sort"
 	"time"
 
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
 )
 
 type Role struct {
+	event.Recorder `json:"-" gorm:"-"`
+
 	ID          identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
-	Name        string      `json:"name" gorm:"column:name"`
-	Permissions []string    `json:"permissions" gorm:"column:permissions"`
-	CreatedAt   time.Time   `json:"created_at,omitempty" gorm:"column:created_at,omitempty"`
-	UpdatedAt   time.Time   `json:"column:updated_at,omitempty" gorm:"column:updated_at,omitempty"`
+	TenantID    identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;uniqueIndex:idx_roles_tenant_name"`
+	Name        string      `json:"name" gorm:"column:name;uniqueIndex:idx_roles_tenant_name"`
+	ParentID    identity.ID `json:"parent_id" gorm:"column:parent_id;type:uuid"`
+	Permissions []string    `json:"permissions" gorm:"column:permissions;type:jsonb;serializer:json"`
+	Version     int64       `json:"version" gorm:"column:version;not null;default:1"`
+	CreatedAt   time.Time   `json:"created_at,omitempty" gorm:"column:created_at"`
+	UpdatedAt   time.Time   `json:"updated_at,omitempty" gorm:"column:updated_at" audit:"-"`
 }
 
+// MaxRoleDepth membatasi panjang rantai parent yang ditelusuri
+const MaxRoleDepth = 8
+
 func (Role) TableName() string {
 	return "roles"
 }
+
+// NewRole membuat role baru dan mencatat RoleCreated
+func NewRole(name string, parentID identity.ID, permissions []string) *Role {
+	now := time.Now()
+	role := &Role{
+		ID:          identity.New(),
+		Name:        name,
+		ParentID:    parentID,
+		Permissions: permissions,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	role.Record(RoleCreated{Base: event.NewBase(), RoleID: role.ID, Name: name, ParentID: parentID, Permissions: permissions})
+	return role
+}
+
+// SetPermissions mengganti seluruh permission dan mencatat selisihnya
+func (r *Role) SetPermissions(permissions []string) {
+	added := difference(permissions, r.Permissions)
+	removed := difference(r.Permissions, permissions)
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	r.Permissions = permissions
+	r.Record(RolePermissionsChanged{Base: event.NewBase(), RoleID: r.ID, Added: added, Removed: removed})
+}
+
+// SetParent mengganti parent role; ID kosong berarti role tanpa parent
+func (r *Role) SetParent(parentID identity.ID) {
+	if parentID == r.ParentID {
+		return
+	}
+
+	previous := r.ParentID
+	r.ParentID = parentID
+	r.Record(RoleParentChanged{Base: event.NewBase(), RoleID: r.ID, ParentID: parentID, PreviousParentID: previous})
+}
+
+func (r *Role) AssignTo(userID identity.ID) {
+	r.Record(RoleAssigned{Base: event.NewBase(), UserID: userID, RoleID: r.ID})
+}
+
+func (r *Role) UnassignFrom(userID identity.ID) {
+	r.Record(RoleUnassigned{Base: event.NewBase(), UserID: userID, RoleID: r.ID})
+}
+
+func (r *Role) MarkDeleted() {
+	r.Record(RoleDeleted{Base: event.NewBase(), RoleID: r.ID})
+}
+
+func (r *Role) ToRoleResponse() *RoleResponse {
+	return &RoleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		ParentID:    r.ParentID,
+		Permissions: r.Permissions,
+		Version:     r.Version,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// AddedPermissions mengembalikan permission pada next yang belum ada pada current
+func AddedPermissions(current, next []string) []string {
+	return difference(next, current)
+}
+
+// EffectivePermissions menggabungkan permission dari rantai role (role itu sendiri diikuti leluhurnya)
+func EffectivePermissions(chain []*Role) []string {
+	seen := make(map[string]struct{})
+	result := make([]string, 0)
+	for _, role := range chain {
+		for _, permission := range role.Permissions {
+			if _, ok := seen[permission]; ok {
+				continue
+			}
+			seen[permission] = struct{}{}
+			result = append(result, permission)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ContainsRole memeriksa apakah rantai role memuat ID tertentu, dipakai untuk mendeteksi siklus
+func ContainsRole(chain []*Role, id identity.ID) bool {
+	return slices.ContainsFunc(chain, func(role *Role) bool { return role.ID == id })
+}
+
+func difference(left, right []string) []string {
+	result := make([]string, 0)
+	for _, item := range left {
+		if !slices.Contains(right, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
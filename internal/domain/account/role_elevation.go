@@ -0,0 +1,130 @@
+package account
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type ElevationStatus string
+
+const (
+	ElevationPending  ElevationStatus = "pending"
+	ElevationActive   ElevationStatus = "active"
+	ElevationRejected ElevationStatus = "rejected"
+	ElevationExpired  ElevationStatus = "expired"
+)
+
+// RoleElevation adalah pemberian role sementara (just-in-time). Masa berlaku
+// dihitung sejak disetujui, bukan sejak diminta.
+type RoleElevation struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	ID              identity.ID     `json:"id" gorm:"column:id;type:uuid;primaryKey"`
+	TenantID        identity.ID     `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	UserID          identity.ID     `json:"user_id" gorm:"column:user_id;type:uuid;index"`
+	RoleID          identity.ID     `json:"role_id" gorm:"column:role_id;type:uuid"`
+	RequestedBy     identity.ID     `json:"requested_by" gorm:"column:requested_by;type:uuid"`
+	Reason          string          `json:"reason" gorm:"column:reason"`
+	DurationSeconds int64           `json:"duration_seconds" gorm:"column:duration_seconds"`
+	Status          ElevationStatus `json:"status" gorm:"column:status;index"`
+	DecidedBy       identity.ID     `json:"decided_by" gorm:"column:decided_by;type:uuid"`
+	DecidedAt       *time.Time      `json:"decided_at" gorm:"column:decided_at"`
+	ExpiresAt       *time.Time      `json:"expires_at" gorm:"column:expires_at;index"`
+	CreatedAt       time.Time       `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time       `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+func (RoleElevation) TableName() string {
+	return "role_elevations"
+}
+
+func NewRoleElevation(userID, roleID, requestedBy identity.ID, duration time.Duration, reason string) *RoleElevation {
+	now := time.Now()
+	elevation := &RoleElevation{
+		ID:              identity.New(),
+		UserID:          userID,
+		RoleID:          roleID,
+		RequestedBy:     requestedBy,
+		Reason:          reason,
+		DurationSeconds: int64(duration / time.Second),
+		Status:          ElevationPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	elevation.Record(RoleElevationRequested{Base: event.NewBase(), ElevationID: elevation.ID, UserID: userID, RoleID: roleID, RequestedBy: requestedBy})
+	return elevation
+}
+
+func (r *RoleElevation) Duration() time.Duration {
+	return time.Duration(r.DurationSeconds) * time.Second
+}
+
+// Approve mengaktifkan elevation. Approver harus admin kedua, bukan peminta
+// maupun penerima role; approval otomatis memakai ID kosong.
+func (r *RoleElevation) Approve(approverID identity.ID, now time.Time) error {
+	if r.Status != ElevationPending {
+		return fmt.Errorf("role elevation is %s: %w", r.Status, errs.ErrConflict)
+	}
+	if !approverID.IsNil() && (approverID == r.RequestedBy || approverID == r.UserID) {
+		return fmt.Errorf("role elevation must be approved by a second admin: %w", errs.ErrForbidden)
+	}
+
+	expiresAt := now.Add(r.Duration())
+	r.Status = ElevationActive
+	r.DecidedBy = approverID
+	r.DecidedAt = &now
+	r.ExpiresAt = &expiresAt
+	r.UpdatedAt = now
+	r.Record(RoleElevationApproved{Base: event.NewBase(), ElevationID: r.ID, UserID: r.UserID, RoleID: r.RoleID, ApprovedBy: approverID, ExpiresAt: expiresAt})
+	return nil
+}
+
+func (r *RoleElevation) Reject(approverID identity.ID, now time.Time) error {
+	if r.Status != ElevationPending {
+		return fmt.Errorf("role elevation is %s: %w", r.Status, errs.ErrConflict)
+	}
+
+	r.Status = ElevationRejected
+	r.DecidedBy = approverID
+	r.DecidedAt = &now
+	r.UpdatedAt = now
+	r.Record(RoleElevationRejected{Base: event.NewBase(), ElevationID: r.ID, UserID: r.UserID, RoleID: r.RoleID, RejectedBy: approverID})
+	return nil
+}
+
+func (r *RoleElevation) Expire(now time.Time) {
+	if r.Status != ElevationActive {
+		return
+	}
+
+	r.Status = ElevationExpired
+	r.UpdatedAt = now
+	r.Record(RoleElevationExpired{Base: event.NewBase(), ElevationID: r.ID, UserID: r.UserID, RoleID: r.RoleID})
+}
+
+func (r *RoleElevation) ToRoleElevationResponse() *RoleElevationResponse {
+	return &RoleElevationResponse{
+		ID:              r.ID,
+		UserID:          r.UserID,
+		RoleID:          r.RoleID,
+		RequestedBy:     r.RequestedBy,
+		Reason:          r.Reason,
+		DurationSeconds: r.DurationSeconds,
+		Status:          r.Status,
+		DecidedBy:       r.DecidedBy,
+		DecidedAt:       r.DecidedAt,
+		ExpiresAt:       r.ExpiresAt,
+		CreatedAt:       r.CreatedAt,
+	}
+}
+
+type RoleElevationFilter struct {
+	model.PaginationFilter
+	UserID string          `form:"user_id" json:"user_id" query:"user_id"`
+	Status ElevationStatus `form:"status" json:"status" query:"status"`
+}
@@ -0,0 +1,69 @@
+package account
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+func TestAddedPermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		next    []string
+		want    []string
+	}{
+		{name: "nothing changes", current: []string{"users:read"}, next: []string{"users:read"}, want: []string{}},
+		{name: "new permission", current: []string{"users:read"}, next: []string{"users:read", "users:write"}, want: []string{"users:write"}},
+		{name: "removal adds nothing", current: []string{"users:read", "legacy:thing"}, next: []string{"users:read"}, want: []string{}},
+		{name: "replace", current: []string{"legacy:thing"}, next: []string{"roles:read"}, want: []string{"roles:read"}},
+		{name: "from empty", next: []string{"users:read", "roles:read"}, want: []string{"users:read", "roles:read"}},
+		{name: "clear", current: []string{"users:read"}, next: []string{}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddedPermissions(tt.current, tt.next); !slices.Equal(got, tt.want) {
+				t.Errorf("AddedPermissions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleSetPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		next        []string
+		wantAdded   []string
+		wantRemoved []string
+		wantEvent   bool
+	}{
+		{name: "same permissions in another order", next: []string{"roles:read", "users:read"}},
+		{name: "add and remove", next: []string{"users:read", "users:write"}, wantAdded: []string{"users:write"}, wantRemoved: []string{"roles:read"}, wantEvent: true},
+		{name: "clear", next: []string{}, wantAdded: []string{}, wantRemoved: []string{"users:read", "roles:read"}, wantEvent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := &Role{ID: identity.New(), Permissions: []string{"users:read", "roles:read"}}
+
+			role.SetPermissions(tt.next)
+
+			events := role.PullEvents()
+			if tt.wantEvent != (len(events) == 1) {
+				t.Fatalf("events = %v, want event %v", events, tt.wantEvent)
+			}
+			if !tt.wantEvent {
+				return
+			}
+			if !slices.Equal(role.Permissions, tt.next) {
+				t.Errorf("Permissions = %v, want %v", role.Permissions, tt.next)
+			}
+			changed := events[0].(RolePermissionsChanged)
+			if !slices.Equal(changed.Added, tt.wantAdded) || !slices.Equal(changed.Removed, tt.wantRemoved) {
+				t.Errorf("event = added %v removed %v, want added %v removed %v", changed.Added, changed.Removed, tt.wantAdded, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package account
+
+// ISecretCipher mengenkripsi secret yang harus bisa dibaca kembali, misalnya
+// secret TOTP, sebelum disimpan ke database
+type ISecretCipher interface {
+	Encrypt(plain string) (encrypted string, err error)
+	Decrypt(encrypted string) (plain string, err error)
+}
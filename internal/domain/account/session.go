@@ -0,0 +1,107 @@
+package account
+
+import (
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// Session adalah satu login user pada satu perangkat. Access token membawa
+// ID session sebagai klaim sid sehingga mencabut session langsung membuat
+// token-nya tidak berlaku.
+type Session struct {
+	ID         identity.ID `json:"id" gorm:"column:id;type:uuid;primaryKey"`
+	TenantID   identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	UserID     identity.ID `json:"user_id" gorm:"column:user_id;type:uuid;index"`
+	UserAgent  string      `json:"user_agent" gorm:"column:user_agent"`
+	Device     string      `json:"device" gorm:"column:device"`
+	IP         string      `json:"ip" gorm:"column:ip"`
+	ExpiresAt  time.Time   `json:"expires_at" gorm:"column:expires_at"`
+	LastSeenAt time.Time   `json:"last_seen_at" gorm:"column:last_seen_at"`
+	RevokedAt  *time.Time  `json:"revoked_at" gorm:"column:revoked_at"`
+	CreatedAt  time.Time   `json:"created_at" gorm:"column:created_at"`
+}
+
+func (Session) TableName() string {
+	return "user_sessions"
+}
+
+func NewSession(id, userID identity.ID, userAgent, ip string, expiresAt time.Time) *Session {
+	now := time.Now()
+	return &Session{
+		ID:         id,
+		UserID:     userID,
+		UserAgent:  userAgent,
+		Device:     DescribeDevice(userAgent),
+		IP:         ip,
+		ExpiresAt:  expiresAt,
+		LastSeenAt: now,
+		CreatedAt:  now,
+	}
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+func (s *Session) ToSessionResponse(currentID identity.ID) *SessionResponse {
+	return &SessionResponse{
+		ID:         s.ID,
+		Device:     s.Device,
+		UserAgent:  s.UserAgent,
+		IP:         s.IP,
+		Current:    s.ID == currentID,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+	}
+}
+
+// DescribeDevice membuat label singkat seperti "Chrome on Windows" dari
+// User-Agent. Hanya untuk ditampilkan, bukan untuk keputusan keamanan.
+func DescribeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := firstMatch(userAgent, [][2]string{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	})
+	platform := firstMatch(userAgent, [][2]string{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	})
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
+
+// firstMatch mengembalikan label pertama yang penandanya ada di userAgent.
+// Urutan penting karena Chrome juga menulis "Safari/" dan Edge menulis "Chrome/".
+func firstMatch(userAgent string, candidates [][2]string) string {
+	for _, candidate := range candidates {
+		if strings.Contains(userAgent, candidate[0]) {
+			return candidate[1]
+		}
+	}
+	return ""
+}
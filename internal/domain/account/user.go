@@ -1,80 +1,174 @@
 package account
 
 import (
+	"maps"
 	"time"
 
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type User struct {
-	ID        identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
-	Name      string      `json:"name" gorm:"column:name"`
-	Fullname  string      `json:"fullname" gorm:"column:fullname"`
-	Username  string      `json:"username" gorm:"column:username;unique"`
-	Email     string      `json:"email" gorm:"column:email"`
-	Password  string      `json:"password" gorm:"column:password"`
-	Role      identity.ID `json:"role_id" gorm:"column:role_id;type:uuid;"`
-	RoleData  Role        `json:"role_data" gorm:"-"`
-	IsActive  bool        `json:"is_active" gorm:"column:is_active;default:true"`
-	CreatedAt time.Time   `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt time.Time   `json:"updated_at" gorm:"column:updated_at"`
-}
-
-type (
-	UserResponse struct {
-		ID        identity.ID `json:"id"`
-		Email     string      `json:"email"`
-		Name      string      `json:"name"`
-		Role      Role        `json:"role"`
-		CreatedAt time.Time   `json:"created_at,omitempty"`
-		UpdatedAt time.Time   `json:"updated_at,omitempty"`
+	event.Recorder `json:"-" gorm:"-"`
+
+	ID              identity.ID       `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	TenantID        identity.ID       `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;uniqueIndex:idx_users_tenant_username;uniqueIndex:idx_users_tenant_email"`
+	Name            string            `json:"name" gorm:"column:name"`
+	Fullname        string            `json:"fullname" gorm:"column:fullname"`
+	Username        string            `json:"username" gorm:"column:username;uniqueIndex:idx_users_tenant_username"`
+	Email           string            `json:"email" gorm:"column:email;uniqueIndex:idx_users_tenant_email"`
+	EmailVerifiedAt *time.Time        `json:"email_verified_at" gorm:"column:email_verified_at"`
+	Password        PasswordHash      `json:"-" gorm:"column:password;type:text" audit:"redact"`
+	Role            identity.ID       `json:"role_id" gorm:"column:role_id;type:uuid;"`
+	RoleData        Role              `json:"-" gorm:"-"`
+	IsActive        bool              `json:"is_active" gorm:"column:is_active;default:true"`
+	Attributes      map[string]string `json:"attributes" gorm:"column:attributes;type:jsonb;serializer:json"`
+	Version         int64             `json:"version" gorm:"column:version;not null;default:1"`
+	CreatedAt       time.Time         `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time         `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+func (User) TableName() string {
+	return "users"
+}
+
+// NewUser membuat user aktif baru dan mencatat UserRegistered
+func NewUser(name, fullname, username, email string) *User {
+	user := &User{
+		ID:       identity.New(),
+		Name:     name,
+		Fullname: fullname,
+		Username: username,
+		Email:    email,
+		IsActive: true,
+		Version:  1,
 	}
+	user.Record(UserRegistered{Base: event.NewBase(), UserID: user.ID, Username: username, Email: email})
+	return user
+}
 
-	CreateUserRequest struct {
-		Name     string `json:"name" validate:"required"`
-		Fullname string `json:"fullname" validate:"required"`
-		Username string `json:"username" validate:"required"`
-		Email    string `json:"email" validate:"required,email"`
-		Password string `json:"password" validate:"required,min=6"`
+func (u *User) ChangeEmail(email string) {
+	if email == u.Email {
+		return
 	}
 
-	UpdateUserRequest struct {
-		Name     string      `json:"name" validate:"name"`
-		Fullname string      `json:"fullname" validate:"fullname"`
-		Username string      `json:"username" validate:"username"`
-		Email    string      `json:"email" validate:"email"`
-		Password string      `json:"password" validate:"password"`
-		Role     identity.ID `json:"role_id" gorm:"column:role_id;type:uuid;"`
+	oldEmail := u.Email
+	u.Email = email
+	u.EmailVerifiedAt = nil
+	u.Record(UserEmailChanged{Base: event.NewBase(), UserID: u.ID, OldEmail: oldEmail, NewEmail: email})
+}
+
+// SetAttributes menggantikan seluruh atribut dan mengembalikan true bila berubah
+func (u *User) SetAttributes(attributes map[string]string) bool {
+	if maps.Equal(attributes, u.Attributes) {
+		return false
 	}
-)
+	u.Attributes = maps.Clone(attributes)
+	return true
+}
 
-func (User) TableName() string {
-	return "users"
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) VerifyEmail(at time.Time) {
+	if u.IsEmailVerified() {
+		return
+	}
+
+	u.EmailVerifiedAt = &at
+	u.Record(UserEmailVerified{Base: event.NewBase(), UserID: u.ID, Email: u.Email})
+}
+
+// LinkIdentity mencatat bahwa user kini bisa login melalui provider eksternal
+func (u *User) LinkIdentity(provider string) {
+	u.Record(UserIdentityLinked{Base: event.NewBase(), UserID: u.ID, Provider: provider})
+}
+
+func (u *User) ChangePassword(hasher IPasswordHasher, password string) error {
+	if err := u.EncryptPassword(hasher, password); err != nil {
+		return err
+	}
+
+	u.Record(UserPasswordChanged{Base: event.NewBase(), UserID: u.ID})
+	return nil
+}
+
+func (u *User) AssignRole(roleID identity.ID) {
+	if roleID == u.Role {
+		return
+	}
+
+	previous := u.Role
+	u.Role = roleID
+	u.Record(RoleAssigned{Base: event.NewBase(), UserID: u.ID, RoleID: roleID, PreviousRoleID: previous})
+}
+
+func (u *User) Deactivate() {
+	if !u.IsActive {
+		return
+	}
+
+	u.IsActive = false
+	u.Record(UserDeactivated{Base: event.NewBase(), UserID: u.ID})
+}
+
+// MarkUpdated mencatat perubahan profil yang tidak memiliki event khusus
+func (u *User) MarkUpdated(fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	u.Record(UserUpdated{Base: event.NewBase(), UserID: u.ID, Fields: fields})
+}
+
+func (u *User) MarkDeleted() {
+	u.Record(UserDeleted{Base: event.NewBase(), UserID: u.ID})
 }
 
-func (u *User) EncryptPassword(password string) error {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+func (u *User) EncryptPassword(hasher IPasswordHasher, password string) error {
+	hash, err := hasher.Hash(password)
 	if err != nil {
 		return err
 	}
 
-	u.Password = string(hash)
+	u.Password = NewPasswordHash(hash)
 	return nil
 }
 
-func (u *User) VerifyPassword(plainPassword string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(plainPassword))
-	return err == nil
+// VerifyPassword mencocokkan password dan meng-hash ulang jika hash tersimpan
+// memakai algoritma atau parameter lama. rehashed bernilai true jika
+// u.Password berubah dan perlu disimpan.
+func (u *User) VerifyPassword(hasher IPasswordHasher, plainPassword string) (ok bool, rehashed bool) {
+	ok, err := hasher.Verify(plainPassword, u.Password.Encoded())
+	if err != nil || !ok {
+		return false, false
+	}
+
+	if !hasher.NeedsRehash(u.Password.Encoded()) {
+		return true, false
+	}
+	if err := u.EncryptPassword(hasher, plainPassword); err != nil {
+		return true, false
+	}
+	return true, true
 }
 
 func (u *User) ToUserResponse() *UserResponse {
-	return &UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		Role:      u.RoleData,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+	response := &UserResponse{
+		ID:              u.ID,
+		Username:        u.Username,
+		Fullname:        u.Fullname,
+		Email:           u.Email,
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		Name:            u.Name,
+		IsActive:        u.IsActive,
+		Attributes:      u.Attributes,
+		Version:         u.Version,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+	}
+	if !u.RoleData.ID.IsNil() {
+		response.Role = u.RoleData.ToRoleResponse()
 	}
+	return response
 }
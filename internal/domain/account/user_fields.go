@@ -0,0 +1,64 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+// UserFieldRole adalah field yang membutuhkan pemuatan role
+const UserFieldRole = "role"
+
+// userResponseFields adalah nama JSON yang boleh dipilih lewat parameter fields
+var userResponseFields = []string{
+	"id", "username", "fullname", "email", "email_verified_at", "name",
+	"is_active", "attributes", UserFieldRole, "version", "created_at", "updated_at",
+}
+
+// ValidateUserFields memastikan semua field yang diminta dikenal
+func ValidateUserFields(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(userResponseFields, field) {
+			return errs.ValidationError{
+				Field:   "fields",
+				Message: fmt.Sprintf("unknown field '%s', allowed: %s", field, strings.Join(userResponseFields, ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+// WantsUserField bernilai true jika field ikut dipilih, fields kosong memilih semua
+func WantsUserField(fields []string, field string) bool {
+	return len(fields) == 0 || slices.Contains(fields, field)
+}
+
+// Only membatasi response ke field yang dipilih. id selalu disertakan.
+func (r *UserResponse) Only(fields []string) *UserResponse {
+	r.fields = fields
+	return r
+}
+
+func (r UserResponse) MarshalJSON() ([]byte, error) {
+	type plain UserResponse
+	data, err := json.Marshal(plain(r))
+	if err != nil || len(r.fields) == 0 {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]json.RawMessage{"id": all["id"]}
+	for _, field := range r.fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+	return json.Marshal(selected)
+}
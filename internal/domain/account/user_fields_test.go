@@ -0,0 +1,103 @@
+package account
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+func TestValidateUserFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		wantErr bool
+	}{
+		{name: "no fields"},
+		{name: "known fields", fields: []string{"username", "is_active", UserFieldRole}},
+		{name: "unknown field", fields: []string{"username", "password"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUserFields(tt.fields)
+			if tt.wantErr != errs.IsValidationError(err) {
+				t.Errorf("ValidateUserFields() error = %v, want validation error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserResponseOnly(t *testing.T) {
+	role := Role{ID: identity.New(), Name: "admin", Permissions: []string{PermissionUsersRead}}
+	user := &User{ID: identity.New(), Username: "jane", Fullname: "Jane Doe", Email: "jane@example.com", IsActive: true, Role: role.ID, RoleData: role}
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   []string
+	}{
+		{
+			name: "every field",
+			want: []string{"id", "username", "fullname", "email", "email_verified_at", "name", "is_active", "attributes", "role", "version", "created_at", "updated_at"},
+		},
+		{name: "selected fields keep id", fields: []string{"username", "is_active"}, want: []string{"id", "username", "is_active"}},
+		{name: "role only", fields: []string{UserFieldRole}, want: []string{"id", "role"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(user.ToUserResponse().Only(tt.fields))
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			var got map[string]json.RawMessage
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			for _, key := range tt.want {
+				if _, ok := got[key]; !ok {
+					t.Errorf("response %s is missing %s", data, key)
+				}
+			}
+			for key := range got {
+				if !slices.Contains(tt.want, key) {
+					t.Errorf("response %s has unexpected %s", data, key)
+				}
+			}
+		})
+	}
+}
+
+func TestUserToUserResponseResolvesRole(t *testing.T) {
+	role := Role{ID: identity.New(), Name: "admin", Permissions: []string{PermissionUsersRead}}
+
+	tests := []struct {
+		name     string
+		roleData Role
+		wantRole bool
+	}{
+		{name: "loaded role", roleData: role, wantRole: true},
+		{name: "role not loaded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: identity.New(), Username: "jane", Fullname: "Jane Doe", IsActive: true, Role: role.ID, RoleData: tt.roleData}
+
+			response := user.ToUserResponse()
+			if response.Username != "jane" || response.Fullname != "Jane Doe" || !response.IsActive {
+				t.Errorf("response = %+v, want username, fullname and active status", response)
+			}
+			if (response.Role != nil) != tt.wantRole {
+				t.Fatalf("role = %+v, want resolved %v", response.Role, tt.wantRole)
+			}
+			if tt.wantRole && (response.Role.Name != role.Name || !slices.Equal(response.Role.Permissions, role.Permissions)) {
+				t.Errorf("role = %+v, want %s with its permissions", response.Role, role.Name)
+			}
+		})
+	}
+}
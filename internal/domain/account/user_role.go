@@ -0,0 +1,54 @@
+package account
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// UserRole adalah role tambahan milik user di luar role utama (User.Role)
+type UserRole struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	UserID     identity.ID `json:"user_id" gorm:"column:user_id;type:uuid;primaryKey"`
+	RoleID     identity.ID `json:"role_id" gorm:"column:role_id;type:uuid;primaryKey"`
+	AssignedBy identity.ID `json:"assigned_by" gorm:"column:assigned_by;type:uuid"`
+	AssignedAt time.Time   `json:"assigned_at" gorm:"column:assigned_at"`
+	ExpiresAt  *time.Time  `json:"expires_at,omitempty" gorm:"column:expires_at"`
+	// TenantID diambil dari user lewat join dan tidak disimpan di user_roles
+	TenantID identity.ID `json:"-" gorm:"column:tenant_id;->;-:migration"`
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
+
+func NewUserRole(userID identity.ID, roleID identity.ID, assignedBy identity.ID, expiresAt *time.Time) *UserRole {
+	return &UserRole{
+		UserID:     userID,
+		RoleID:     roleID,
+		AssignedBy: assignedBy,
+		AssignedAt: time.Now(),
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// IsActive bernilai false setelah masa berlaku assignment habis
+func (u *UserRole) IsActive(now time.Time) bool {
+	return u.ExpiresAt == nil || now.Before(*u.ExpiresAt)
+}
+
+// Expire mencatat bahwa assignment dicabut karena masa berlakunya habis
+func (u *UserRole) Expire() {
+	u.Record(RoleAssignmentExpired{Base: event.NewBase(), UserID: u.UserID, RoleID: u.RoleID})
+}
+
+func (u *UserRole) ToUserRoleResponse(role *Role) *UserRoleResponse {
+	return &UserRoleResponse{
+		Role:       role.ToRoleResponse(),
+		AssignedBy: u.AssignedBy,
+		AssignedAt: u.AssignedAt,
+		ExpiresAt:  u.ExpiresAt,
+	}
+}
@@ -0,0 +1,47 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+func TestUserRoleIsActive(t *testing.T) {
+	now := time.Now()
+	future, past := now.Add(time.Hour), now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		want      bool
+	}{
+		{name: "no expiry", want: true},
+		{name: "expires later", expiresAt: &future, want: true},
+		{name: "expires now", expiresAt: &now},
+		{name: "expired", expiresAt: &past},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignment := NewUserRole(identity.New(), identity.New(), identity.New(), tt.expiresAt)
+			if got := assignment.IsActive(now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserRoleToUserRoleResponse(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	role := &Role{ID: identity.New(), Name: "auditor", Permissions: []string{PermissionUsersRead}}
+	assignment := NewUserRole(identity.New(), role.ID, identity.New(), &expiresAt)
+
+	response := assignment.ToUserRoleResponse(role)
+	if response.Role.ID != role.ID || response.Primary {
+		t.Errorf("response role = %+v primary %v, want %s as a non-primary assignment", response.Role, response.Primary, role.Name)
+	}
+	if response.AssignedBy != assignment.AssignedBy || !response.AssignedAt.Equal(assignment.AssignedAt) || response.ExpiresAt != &expiresAt {
+		t.Errorf("response = %+v, want the assignment metadata", response)
+	}
+}
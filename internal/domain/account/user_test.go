@@ -0,0 +1,78 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+func TestUserChangeEmail(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name         string
+		email        string
+		wantVerified bool
+		wantEvent    bool
+	}{
+		{name: "same email keeps verification", email: "jane@example.com", wantVerified: true},
+		{name: "new email needs verification again", email: "jane@example.org", wantEvent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: identity.New(), Email: "jane@example.com", EmailVerifiedAt: &verifiedAt}
+
+			user.ChangeEmail(tt.email)
+
+			if user.IsEmailVerified() != tt.wantVerified {
+				t.Errorf("IsEmailVerified() = %v, want %v", user.IsEmailVerified(), tt.wantVerified)
+			}
+			events := user.PullEvents()
+			if tt.wantEvent != (len(events) == 1) {
+				t.Fatalf("events = %v, want event %v", events, tt.wantEvent)
+			}
+			if tt.wantEvent {
+				changed, ok := events[0].(UserEmailChanged)
+				if !ok || changed.OldEmail != "jane@example.com" || changed.NewEmail != tt.email {
+					t.Errorf("event = %+v, want UserEmailChanged from the old to the new email", events[0])
+				}
+			}
+		})
+	}
+}
+
+func TestUserVerifyEmail(t *testing.T) {
+	first := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		verified  *time.Time
+		wantAt    time.Time
+		wantEvent bool
+	}{
+		{name: "unverified", wantEvent: true},
+		{name: "already verified", verified: &first, wantAt: first},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			user := &User{ID: identity.New(), Email: "jane@example.com", EmailVerifiedAt: tt.verified}
+
+			user.VerifyEmail(now)
+
+			want := tt.wantAt
+			if want.IsZero() {
+				want = now
+			}
+			if !user.IsEmailVerified() || !user.EmailVerifiedAt.Equal(want) {
+				t.Errorf("EmailVerifiedAt = %v, want %v", user.EmailVerifiedAt, want)
+			}
+			if got := len(user.PullEvents()) == 1; got != tt.wantEvent {
+				t.Errorf("recorded event = %v, want %v", got, tt.wantEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package account
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+type TokenPurpose string
+
+const (
+	TokenPasswordReset     TokenPurpose = "password_reset"
+	TokenEmailVerification TokenPurpose = "email_verification"
+	TokenMFAChallenge      TokenPurpose = "mfa_challenge"
+	TokenMFAEnrollment     TokenPurpose = "mfa_enrollment"
+)
+
+// UserToken adalah token sekali pakai milik user. Hanya hash token yang
+// disimpan, nilai aslinya dikirim ke user dan tidak bisa dipulihkan.
+type UserToken struct {
+	ID        identity.ID  `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	UserID    identity.ID  `json:"user_id" gorm:"column:user_id;type:uuid;index"`
+	Purpose   TokenPurpose `json:"purpose" gorm:"column:purpose"`
+	TokenHash string       `json:"-" gorm:"column:token_hash;uniqueIndex"`
+	ExpiresAt time.Time    `json:"expires_at" gorm:"column:expires_at"`
+	UsedAt    *time.Time   `json:"used_at" gorm:"column:used_at"`
+	CreatedAt time.Time    `json:"created_at" gorm:"column:created_at"`
+}
+
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// NewUserToken mengembalikan token beserta nilai plain yang harus dikirim ke user
+func NewUserToken(userID identity.ID, purpose TokenPurpose, ttl time.Duration) (*UserToken, string, error) {
+	plain, err := token.Generate(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &UserToken{
+		ID:        identity.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: token.Hash(plain),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, plain, nil
+}
+
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
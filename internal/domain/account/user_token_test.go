@@ -0,0 +1,51 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+func TestNewUserTokenStoresOnlyHash(t *testing.T) {
+	userToken, plain, err := NewUserToken(identity.New(), TokenPasswordReset, time.Hour)
+	if err != nil {
+		t.Fatalf("NewUserToken() error = %v", err)
+	}
+	if plain == "" || userToken.TokenHash == plain {
+		t.Fatalf("token hash %q must not be the plain token", userToken.TokenHash)
+	}
+	if userToken.TokenHash != token.Hash(plain) {
+		t.Errorf("token hash does not match the plain token")
+	}
+
+	_, other, _ := NewUserToken(identity.New(), TokenPasswordReset, time.Hour)
+	if other == plain {
+		t.Error("two tokens share the same plain value")
+	}
+}
+
+func TestUserTokenIsUsable(t *testing.T) {
+	now := time.Now()
+	used := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		token UserToken
+		want  bool
+	}{
+		{name: "fresh", token: UserToken{ExpiresAt: now.Add(time.Minute)}, want: true},
+		{name: "expired", token: UserToken{ExpiresAt: now.Add(-time.Minute)}},
+		{name: "expires now", token: UserToken{ExpiresAt: now}},
+		{name: "already used", token: UserToken{ExpiresAt: now.Add(time.Minute), UsedAt: &used}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.IsUsable(now); got != tt.want {
+				t.Errorf("IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
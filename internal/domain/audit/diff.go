@@ -0,0 +1,149 @@
+package audit
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+)
+
+// Redacted menggantikan nilai field yang ditandai `audit:"redact"`
+const Redacted = "[REDACTED]"
+
+type field struct {
+	name  string
+	value interface{}
+	// raw adalah nilai asli field redact. Nilai ini dibandingkan sebelum output
+	// disamarkan karena MarshalText tipe rahasia bisa selalu sama, misalnya PasswordHash.
+	raw    interface{}
+	redact bool
+}
+
+// Diff membandingkan dua snapshot struct dan mengembalikan field yang berubah.
+// before bernilai nil untuk create, after bernilai nil untuk delete.
+// Field dengan tag `gorm:"-"` atau `audit:"-"` diabaikan.
+func Diff(before, after interface{}) []Change {
+	beforeFields := collect(before)
+	afterFields := collect(after)
+
+	order := afterFields
+	if len(order) == 0 {
+		order = beforeFields
+	}
+
+	changes := make([]Change, 0)
+	for _, f := range order {
+		if f.redact {
+			if reflect.DeepEqual(lookupRaw(beforeFields, f.name), lookupRaw(afterFields, f.name)) {
+				continue
+			}
+			changes = append(changes, redactedChange(f.name, lookup(beforeFields, f.name), lookup(afterFields, f.name)))
+			continue
+		}
+
+		b, a := lookup(beforeFields, f.name), lookup(afterFields, f.name)
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, Change{Field: f.name, Before: b, After: a})
+	}
+
+	return changes
+}
+
+// redactedChange hanya mencatat bahwa nilai ada dan berubah, tanpa isinya
+func redactedChange(name string, before, after interface{}) Change {
+	if before != nil {
+		before = Redacted
+	}
+	if after != nil {
+		after = Redacted
+	}
+	return Change{Field: name, Before: before, After: after}
+}
+
+func collect(v interface{}) []field {
+	if v == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]field, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		if !structField.IsExported() || structField.Tag.Get("gorm") == "-" {
+			continue
+		}
+
+		auditTag := structField.Tag.Get("audit")
+		if auditTag == "-" {
+			continue
+		}
+
+		f := field{
+			name:   fieldName(structField),
+			value:  normalize(value.Field(i)),
+			redact: auditTag == "redact",
+		}
+		if f.redact {
+			f.raw = value.Field(i).Interface()
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func lookup(fields []field, name string) interface{} {
+	for _, f := range fields {
+		if f.name == name {
+			return f.value
+		}
+	}
+	return nil
+}
+
+func lookupRaw(fields []field, name string) interface{} {
+	for _, f := range fields {
+		if f.name == name {
+			return f.raw
+		}
+	}
+	return nil
+}
+
+func fieldName(structField reflect.StructField) string {
+	name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return structField.Name
+	}
+	return name
+}
+
+func normalize(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if nillable, ok := value.Interface().(interface{ IsNil() bool }); ok && nillable.IsNil() {
+		return nil
+	}
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err == nil {
+			return string(text)
+		}
+	}
+	return value.Interface()
+}
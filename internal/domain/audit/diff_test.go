@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+// secret meniru PasswordHash: MarshalText selalu sama apa pun isinya
+type secret struct {
+	encoded string
+}
+
+func (s secret) MarshalText() ([]byte, error) {
+	return []byte("********"), nil
+}
+
+type diffSubject struct {
+	Name     string `json:"name"`
+	Password secret `json:"-" audit:"redact"`
+	Token    string `json:"token" audit:"redact"`
+	Internal string `json:"internal" audit:"-"`
+}
+
+func TestDiff(t *testing.T) {
+	base := diffSubject{Name: "alice", Password: secret{encoded: "hash-1"}, Token: "t1", Internal: "x"}
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   []Change
+	}{
+		{
+			name:   "unchanged",
+			before: &base,
+			after:  &diffSubject{Name: "alice", Password: secret{encoded: "hash-1"}, Token: "t1", Internal: "y"},
+			want:   []Change{},
+		},
+		{
+			name:   "plain field",
+			before: &base,
+			after:  &diffSubject{Name: "bob", Password: secret{encoded: "hash-1"}, Token: "t1"},
+			want:   []Change{{Field: "name", Before: "alice", After: "bob"}},
+		},
+		{
+			name:   "password change with constant text is redacted",
+			before: &base,
+			after:  &diffSubject{Name: "alice", Password: secret{encoded: "hash-2"}, Token: "t1"},
+			want:   []Change{{Field: "Password", Before: Redacted, After: Redacted}},
+		},
+		{
+			name:   "redacted string",
+			before: &base,
+			after:  &diffSubject{Name: "alice", Password: secret{encoded: "hash-1"}, Token: "t2"},
+			want:   []Change{{Field: "token", Before: Redacted, After: Redacted}},
+		},
+		{
+			name:   "create",
+			before: nil,
+			after:  &base,
+			want: []Change{
+				{Field: "name", Before: nil, After: "alice"},
+				{Field: "Password", Before: nil, After: Redacted},
+				{Field: "token", Before: nil, After: Redacted},
+			},
+		},
+		{
+			name:   "delete",
+			before: &base,
+			after:  nil,
+			want: []Change{
+				{Field: "name", Before: "alice", After: nil},
+				{Field: "Password", Before: Redacted, After: nil},
+				{Field: "token", Before: Redacted, After: nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
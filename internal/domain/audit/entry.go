@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type Action string
+
+const (
+	ActionCreate       Action = "create"
+	ActionUpdate       Action = "update"
+	ActionDelete       Action = "delete"
+	ActionAssignRole   Action = "assign_role"
+	ActionUnassignRole Action = "unassign_role"
+	ActionExpireRole   Action = "expire_role"
+	ActionRequest      Action = "request"
+	ActionApprove      Action = "approve"
+	ActionReject       Action = "reject"
+	ActionExpire       Action = "expire"
+	ActionAddMember    Action = "add_member"
+	ActionChangeMember Action = "change_member_role"
+	ActionRemoveMember Action = "remove_member"
+	ActionInvite       Action = "invite"
+	ActionAccept       Action = "accept"
+	ActionRevoke       Action = "revoke"
+)
+
+const (
+	AggregateUser         = "user"
+	AggregateRole         = "role"
+	AggregateAPIKey       = "api_key"
+	AggregateOAuthClient  = "oauth_client"
+	AggregateElevation    = "role_elevation"
+	AggregateOrganization = "organization"
+	AggregateTeam         = "team"
+	AggregateInvitation   = "organization_invitation"
+)
+
+type Entry struct {
+	ID            identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	TenantID      identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	ActorID       identity.ID `json:"actor_id" gorm:"column:actor_id;type:uuid"`
+	Action        Action      `json:"action" gorm:"column:action"`
+	AggregateType string      `json:"aggregate_type" gorm:"column:aggregate_type"`
+	AggregateID   string      `json:"aggregate_id" gorm:"column:aggregate_id"`
+	Changes       []Change    `json:"changes" gorm:"column:changes;type:jsonb;serializer:json"`
+	RequestID     string      `json:"request_id" gorm:"column:request_id"`
+	CreatedAt     time.Time   `json:"created_at" gorm:"column:created_at"`
+}
+
+type Change struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type Filter struct {
+	model.PaginationFilter
+	ActorID       string     `form:"actor_id" json:"actor_id" query:"actor_id"`
+	Action        Action     `form:"action" json:"action" query:"action"`
+	AggregateType string     `form:"aggregate_type" json:"aggregate_type" query:"aggregate_type"`
+	AggregateID   string     `form:"aggregate_id" json:"aggregate_id" query:"aggregate_id"`
+	From          *time.Time `form:"from" json:"from" query:"from"`
+	To            *time.Time `form:"to" json:"to" query:"to"`
+}
+
+func (Entry) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+)
+
+// IAuditRepository bersifat append-only, entry tidak pernah diubah atau dihapus
+type IAuditRepository interface {
+	Create(ctx context.Context, entry *audit.Entry) (err error)
+	FindAll(ctx context.Context, filter *audit.Filter) (result []*audit.Entry, totalItems int64, err error)
+}
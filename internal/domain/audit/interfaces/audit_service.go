@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+)
+
+type IAuditService interface {
+	Record(ctx context.Context, action audit.Action, aggregateType string, aggregateID string, changes []audit.Change) (err error)
+	FindAll(ctx context.Context, filter *audit.Filter) (result []*audit.Entry, totalItems int64, err error)
+}
@@ -0,0 +1,10 @@
+package audit
+
+import "github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+
+const PermissionAuditRead = "audit:read"
+
+// Permissions adalah permission yang dideklarasikan modul audit
+var Permissions = []permission.Definition{
+	{Key: PermissionAuditRead, Description: "View the audit log", Group: "audit"},
+}
@@ -0,0 +1,67 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+const CodeChallengeS256 = "S256"
+
+// AuthorizationCode adalah code sekali pakai hasil endpoint authorize. Hanya
+// hash code yang disimpan.
+type AuthorizationCode struct {
+	CodeHash      string      `gorm:"column:code_hash;primaryKey"`
+	TenantID      identity.ID `gorm:"column:tenant_id;type:uuid;not null;index"`
+	ClientID      identity.ID `gorm:"column:client_id;type:uuid"`
+	UserID        identity.ID `gorm:"column:user_id;type:uuid"`
+	RedirectURI   string      `gorm:"column:redirect_uri"`
+	Scopes        []string    `gorm:"column:scopes;type:jsonb;serializer:json"`
+	CodeChallenge string      `gorm:"column:code_challenge"`
+	Nonce         string      `gorm:"column:nonce"`
+	ExpiresAt     time.Time   `gorm:"column:expires_at"`
+	CreatedAt     time.Time   `gorm:"column:created_at"`
+}
+
+func (AuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+// NewAuthorizationCode mengembalikan code beserta nilai plain untuk client
+func NewAuthorizationCode(clientID, userID identity.ID, redirectURI string, scopes []string, codeChallenge, nonce string, ttl time.Duration) (*AuthorizationCode, string, error) {
+	plain, err := token.Generate(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &AuthorizationCode{
+		CodeHash:      token.Hash(plain),
+		ClientID:      clientID,
+		UserID:        userID,
+		RedirectURI:   redirectURI,
+		Scopes:        scopes,
+		CodeChallenge: codeChallenge,
+		Nonce:         nonce,
+		ExpiresAt:     now.Add(ttl),
+		CreatedAt:     now,
+	}, plain, nil
+}
+
+func (c *AuthorizationCode) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// VerifyPKCE mencocokkan code_verifier dengan challenge metode S256
+func (c *AuthorizationCode) VerifyPKCE(verifier string) bool {
+	if verifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(c.CodeChallenge)) == 1
+}
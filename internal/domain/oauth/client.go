@@ -0,0 +1,94 @@
+package oauth
+
+import (
+	"crypto/subtle"
+	"slices"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+
+	// ClientSecretPrefix menandai secret client agar mudah dikenali secret scanner
+	ClientSecretPrefix = "ocs_"
+)
+
+// Client adalah aplikasi yang memakai service ini sebagai identity provider.
+// Client confidential memiliki secret, client public (SPA, mobile) tidak dan
+// hanya bisa memakai authorization code dengan PKCE.
+type Client struct {
+	ID           identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	TenantID     identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	Name         string      `json:"name" gorm:"column:name"`
+	SecretHash   string      `json:"-" gorm:"column:secret_hash" audit:"redact"`
+	RedirectURIs []string    `json:"redirect_uris" gorm:"column:redirect_uris;type:jsonb;serializer:json"`
+	GrantTypes   []string    `json:"grant_types" gorm:"column:grant_types;type:jsonb;serializer:json"`
+	Scopes       []string    `json:"scopes" gorm:"column:scopes;type:jsonb;serializer:json"`
+	Confidential bool        `json:"confidential" gorm:"column:confidential"`
+	// FirstParty adalah aplikasi milik sendiri sehingga tidak perlu consent
+	FirstParty bool      `json:"first_party" gorm:"column:first_party"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type (
+	CreateClientRequest struct {
+		Name         string   `json:"name" validate:"required"`
+		RedirectURIs []string `json:"redirect_uris"`
+		GrantTypes   []string `json:"grant_types" validate:"required"`
+		Scopes       []string `json:"scopes"`
+		Confidential bool     `json:"confidential"`
+		FirstParty   bool     `json:"first_party"`
+	}
+
+	// ClientSecretResponse hanya dikembalikan saat client dibuat
+	ClientSecretResponse struct {
+		Client
+		Secret string `json:"secret,omitempty"`
+	}
+)
+
+func (Client) TableName() string {
+	return "oauth_clients"
+}
+
+// GenerateSecret membuat secret baru dan menyimpan hash-nya ke client
+func (c *Client) GenerateSecret() (string, error) {
+	secret, err := token.Generate(32)
+	if err != nil {
+		return "", err
+	}
+
+	plain := ClientSecretPrefix + secret
+	c.SecretHash = token.Hash(plain)
+	return plain, nil
+}
+
+func (c *Client) VerifySecret(plain string) bool {
+	if !c.Confidential || c.SecretHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.SecretHash), []byte(token.Hash(plain))) == 1
+}
+
+// AllowsRedirect mencocokkan redirect URI secara persis, tanpa wildcard
+func (c *Client) AllowsRedirect(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+func (c *Client) AllowsGrant(grant string) bool {
+	return slices.Contains(c.GrantTypes, grant)
+}
+
+func (c *Client) AllowsScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package oauth
+
+import (
+	"slices"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// Consent mencatat scope yang sudah disetujui user untuk sebuah client
+type Consent struct {
+	UserID    identity.ID `json:"user_id" gorm:"column:user_id;type:uuid;primaryKey"`
+	TenantID  identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;index"`
+	ClientID  identity.ID `json:"client_id" gorm:"column:client_id;type:uuid;primaryKey"`
+	Scopes    []string    `json:"scopes" gorm:"column:scopes;type:jsonb;serializer:json"`
+	GrantedAt time.Time   `json:"granted_at" gorm:"column:granted_at"`
+	UpdatedAt time.Time   `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Consent) TableName() string {
+	return "oauth_consents"
+}
+
+func NewConsent(userID, clientID identity.ID) *Consent {
+	now := time.Now()
+	return &Consent{UserID: userID, ClientID: clientID, GrantedAt: now, UpdatedAt: now}
+}
+
+func (c *Consent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// Grant menambahkan scope baru tanpa mencabut yang sudah disetujui
+func (c *Consent) Grant(scopes []string) {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			c.Scopes = append(c.Scopes, scope)
+		}
+	}
+	c.UpdatedAt = time.Now()
+}
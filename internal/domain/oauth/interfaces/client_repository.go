@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type IClientRepository interface {
+	Create(ctx context.Context, client *oauth.Client) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *oauth.Client, err error)
+	FindAll(ctx context.Context, filter *model.PaginationFilter) (result []*oauth.Client, totalItems int64, err error)
+	Delete(ctx context.Context, id identity.ID) (err error)
+}
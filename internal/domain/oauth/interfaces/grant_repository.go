@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IAuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *oauth.AuthorizationCode) (err error)
+
+	/**
+	 * Consume deletes the code and returns it, so a code can only be
+	 * redeemed once. It fails with ErrNotFound when the code does not exist.
+	 * @param ctx context.Context
+	 * @param codeHash string
+	 * @return (*oauth.AuthorizationCode, error)
+	 */
+	Consume(ctx context.Context, codeHash string) (result *oauth.AuthorizationCode, err error)
+}
+
+type IConsentRepository interface {
+	Find(ctx context.Context, userID identity.ID, clientID identity.ID) (result *oauth.Consent, err error)
+
+	/**
+	 * Save inserts the consent or replaces the scopes of the existing one.
+	 * @param ctx context.Context
+	 * @param consent *oauth.Consent
+	 * @return error
+	 */
+	Save(ctx context.Context, consent *oauth.Consent) (err error)
+}
+
+type ITokenRepository interface {
+	Create(ctx context.Context, token *oauth.Token) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *oauth.Token, err error)
+	Revoke(ctx context.Context, id identity.ID, at time.Time) (err error)
+}
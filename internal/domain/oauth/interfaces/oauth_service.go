@@ -0,0 +1,52 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type IClientService interface {
+	/**
+	 * Create registers a client. The secret of a confidential client is only
+	 * returned here. Requires PermissionClientsWrite, and PermissionClientsTrust
+	 * for a first party client.
+	 * @param ctx context.Context
+	 * @param payload *oauth.CreateClientRequest
+	 * @return (*oauth.ClientSecretResponse, error)
+	 */
+	Create(ctx context.Context, payload *oauth.CreateClientRequest) (result *oauth.ClientSecretResponse, err error)
+
+	GetByID(ctx context.Context, id identity.ID) (result *oauth.Client, err error)
+	GetAll(ctx context.Context, filter *model.PaginationFilter) (result []*oauth.Client, totalItems int64, err error)
+	Delete(ctx context.Context, id identity.ID) (err error)
+}
+
+type IOAuthService interface {
+	/**
+	 * Authorize validates an authorization request of the signed in user and
+	 * either issues a code or asks for consent.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param payload *oauth.AuthorizeRequest
+	 * @return (*oauth.AuthorizeResponse, error)
+	 */
+	Authorize(ctx context.Context, userID identity.ID, payload *oauth.AuthorizeRequest) (result *oauth.AuthorizeResponse, err error)
+
+	/**
+	 * Decide records the consent decision of the user.
+	 * @param ctx context.Context
+	 * @param userID identity.ID
+	 * @param payload *oauth.ConsentDecisionRequest
+	 * @return (*oauth.AuthorizeResponse, error)
+	 */
+	Decide(ctx context.Context, userID identity.ID, payload *oauth.ConsentDecisionRequest) (result *oauth.AuthorizeResponse, err error)
+
+	Token(ctx context.Context, payload *oauth.TokenRequest) (result *oauth.TokenResponse, err error)
+	Introspect(ctx context.Context, client oauth.ClientCredentials, token string) (result *oauth.IntrospectionResponse, err error)
+	Revoke(ctx context.Context, client oauth.ClientCredentials, token string) (err error)
+	Discovery() *oauth.DiscoveryDocument
+	JWKS() *oauth.JSONWebKeySet
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+)
+
+type ITokenSigner interface {
+	/**
+	 * Sign encodes the claims as a signed JWT.
+	 * @param claims any JSON serializable claims
+	 * @return (string, error)
+	 */
+	Sign(claims any) (token string, err error)
+
+	/**
+	 * Parse checks the signature of a JWT and decodes its claims. Expiry,
+	 * issuer and audience are left to the caller.
+	 * @param token string
+	 * @param claims any
+	 * @return error
+	 */
+	Parse(token string, claims any) (err error)
+
+	Algorithm() string
+	PublicKeys() []oauth.JSONWebKey
+}
@@ -0,0 +1,18 @@
+package oauth
+
+import "github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+
+const (
+	PermissionClientsRead  = "oauth_clients:read"
+	PermissionClientsWrite = "oauth_clients:write"
+	// PermissionClientsTrust dibatasi untuk admin karena client first party
+	// melewati halaman consent
+	PermissionClientsTrust = "oauth_clients:trust"
+)
+
+// Permissions adalah permission yang dideklarasikan modul oauth
+var Permissions = []permission.Definition{
+	{Key: PermissionClientsRead, Description: "View OAuth clients", Group: "oauth_clients"},
+	{Key: PermissionClientsWrite, Description: "Register, update and delete OAuth clients", Group: "oauth_clients"},
+	{Key: PermissionClientsTrust, Description: "Register and delete first party clients that skip the consent screen", Group: "oauth_clients"},
+}
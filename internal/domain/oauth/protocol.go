@@ -0,0 +1,158 @@
+package oauth
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Scope OpenID Connect yang selalu tersedia, di luar permission role
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// Kode error sesuai RFC 6749 bagian 4.1.2.1 dan 5.2
+const (
+	ErrorInvalidRequest          = "invalid_request"
+	ErrorInvalidClient           = "invalid_client"
+	ErrorInvalidGrant            = "invalid_grant"
+	ErrorInvalidScope            = "invalid_scope"
+	ErrorUnauthorizedClient      = "unauthorized_client"
+	ErrorUnsupportedGrantType    = "unsupported_grant_type"
+	ErrorUnsupportedResponseType = "unsupported_response_type"
+	ErrorAccessDenied            = "access_denied"
+)
+
+// Error adalah error protokol OAuth yang dikirim ke client apa adanya
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+func (e Error) StatusCode() int {
+	if e.Code == ErrorInvalidClient {
+		return http.StatusUnauthorized
+	}
+	return http.StatusBadRequest
+}
+
+func IsOIDCScope(scope string) bool {
+	return scope == ScopeOpenID || scope == ScopeProfile || scope == ScopeEmail
+}
+
+// ParseScope memecah parameter scope yang dipisahkan spasi
+func ParseScope(scope string) []string {
+	return strings.Fields(scope)
+}
+
+func FormatScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+type (
+	// AuthorizeRequest adalah parameter endpoint authorize
+	AuthorizeRequest struct {
+		ResponseType        string `json:"response_type"`
+		ClientID            string `json:"client_id"`
+		RedirectURI         string `json:"redirect_uri"`
+		Scope               string `json:"scope"`
+		State               string `json:"state"`
+		Nonce               string `json:"nonce"`
+		CodeChallenge       string `json:"code_challenge"`
+		CodeChallengeMethod string `json:"code_challenge_method"`
+	}
+
+	ConsentDecisionRequest struct {
+		AuthorizeRequest
+		Approve bool `json:"approve"`
+	}
+
+	// AuthorizeResponse berisi RedirectTo saat alur selesai, atau
+	// ConsentRequired saat frontend harus menampilkan halaman persetujuan
+	AuthorizeResponse struct {
+		RedirectTo      string         `json:"redirect_to,omitempty"`
+		ConsentRequired bool           `json:"consent_required,omitempty"`
+		Client          *ClientSummary `json:"client,omitempty"`
+		Scopes          []string       `json:"scopes,omitempty"`
+	}
+
+	ClientSummary struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// ClientCredentials adalah autentikasi client dari header Basic atau form
+	ClientCredentials struct {
+		ClientID     string
+		ClientSecret string
+	}
+
+	TokenRequest struct {
+		ClientCredentials
+		GrantType    string
+		Code         string
+		RedirectURI  string
+		CodeVerifier string
+		Scope        string
+	}
+
+	TokenResponse struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+		Scope       string `json:"scope,omitempty"`
+		IDToken     string `json:"id_token,omitempty"`
+	}
+
+	// IntrospectionResponse sesuai RFC 7662. Token tidak aktif hanya berisi active=false.
+	IntrospectionResponse struct {
+		Active    bool   `json:"active"`
+		Scope     string `json:"scope,omitempty"`
+		ClientID  string `json:"client_id,omitempty"`
+		Subject   string `json:"sub,omitempty"`
+		TokenType string `json:"token_type,omitempty"`
+		ExpiresAt int64  `json:"exp,omitempty"`
+		IssuedAt  int64  `json:"iat,omitempty"`
+		Issuer    string `json:"iss,omitempty"`
+		Audience  string `json:"aud,omitempty"`
+		JTI       string `json:"jti,omitempty"`
+	}
+
+	DiscoveryDocument struct {
+		Issuer                            string   `json:"issuer"`
+		AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+		TokenEndpoint                     string   `json:"token_endpoint"`
+		IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+		RevocationEndpoint                string   `json:"revocation_endpoint"`
+		JWKSURI                           string   `json:"jwks_uri"`
+		ScopesSupported                   []string `json:"scopes_supported"`
+		ResponseTypesSupported            []string `json:"response_types_supported"`
+		GrantTypesSupported               []string `json:"grant_types_supported"`
+		SubjectTypesSupported             []string `json:"subject_types_supported"`
+		IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+		TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+		CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+		ClaimsSupported                   []string `json:"claims_supported"`
+	}
+
+	JSONWebKey struct {
+		KeyID     string `json:"kid"`
+		KeyType   string `json:"kty"`
+		Use       string `json:"use"`
+		Algorithm string `json:"alg"`
+		N         string `json:"n"`
+		E         string `json:"e"`
+	}
+
+	JSONWebKeySet struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+)
@@ -0,0 +1,28 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// Token mencatat access token yang diterbitkan agar bisa diintrospeksi dan
+// dicabut sebelum kedaluwarsa. ID sama dengan klaim jti.
+type Token struct {
+	ID        identity.ID  `gorm:"column:id;type:uuid;primaryKey"`
+	TenantID  identity.ID  `gorm:"column:tenant_id;type:uuid;not null;index"`
+	ClientID  identity.ID  `gorm:"column:client_id;type:uuid;index"`
+	UserID    *identity.ID `gorm:"column:user_id;type:uuid;index"`
+	Scopes    []string     `gorm:"column:scopes;type:jsonb;serializer:json"`
+	ExpiresAt time.Time    `gorm:"column:expires_at"`
+	RevokedAt *time.Time   `gorm:"column:revoked_at"`
+	CreatedAt time.Time    `gorm:"column:created_at"`
+}
+
+func (Token) TableName() string {
+	return "oauth_tokens"
+}
+
+func (t *Token) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,107 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+// DTO untuk API. Entity adalah model persistence dan tidak dikirim langsung ke client.
+type (
+	OrganizationResponse struct {
+		ID          identity.ID `json:"id"`
+		Name        string      `json:"name"`
+		Description string      `json:"description"`
+		CreatedBy   identity.ID `json:"created_by"`
+		// Membership adalah role actor di organization, kosong bila bukan anggota
+		Membership MembershipRole `json:"membership,omitempty"`
+		Version    int64          `json:"version"`
+		CreatedAt  time.Time      `json:"created_at"`
+		UpdatedAt  time.Time      `json:"updated_at"`
+	}
+
+	CreateOrganizationRequest struct {
+		Name        string `json:"name" validate:"required"`
+		Description string `json:"description"`
+	}
+
+	UpdateOrganizationRequest struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+		Version     int64   `json:"version"`
+	}
+
+	TeamResponse struct {
+		ID             identity.ID `json:"id"`
+		OrganizationID identity.ID `json:"organization_id"`
+		Name           string      `json:"name"`
+		Description    string      `json:"description"`
+		CreatedAt      time.Time   `json:"created_at"`
+		UpdatedAt      time.Time   `json:"updated_at"`
+	}
+
+	CreateTeamRequest struct {
+		Name        string `json:"name" validate:"required"`
+		Description string `json:"description"`
+	}
+
+	UpdateTeamRequest struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+	}
+
+	MemberResponse struct {
+		UserID    identity.ID    `json:"user_id"`
+		Role      MembershipRole `json:"role"`
+		InvitedBy identity.ID    `json:"invited_by,omitempty"`
+		JoinedAt  time.Time      `json:"joined_at"`
+	}
+
+	// SetMemberRequest mengubah role anggota organization, atau menambah anggota
+	// organization ke team bila dipakai pada endpoint team
+	SetMemberRequest struct {
+		Role MembershipRole `json:"role" validate:"required"`
+	}
+
+	InvitationResponse struct {
+		ID             identity.ID      `json:"id"`
+		OrganizationID identity.ID      `json:"organization_id"`
+		TeamID         identity.ID      `json:"team_id,omitempty"`
+		Email          string           `json:"email"`
+		Role           MembershipRole   `json:"role"`
+		Status         InvitationStatus `json:"status"`
+		InvitedBy      identity.ID      `json:"invited_by"`
+		AcceptedBy     identity.ID      `json:"accepted_by,omitempty"`
+		AcceptedAt     *time.Time       `json:"accepted_at,omitempty"`
+		ExpiresAt      time.Time        `json:"expires_at"`
+		CreatedAt      time.Time        `json:"created_at"`
+	}
+
+	CreateInvitationRequest struct {
+		Email string         `json:"email" validate:"required,email"`
+		Role  MembershipRole `json:"role" validate:"required"`
+		// TeamID opsional; penerima juga bergabung ke team sebagai member
+		TeamID identity.ID `json:"team_id"`
+	}
+
+	AcceptInvitationRequest struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	AcceptInvitationResponse struct {
+		Organization *OrganizationResponse `json:"organization"`
+		Team         *TeamResponse         `json:"team,omitempty"`
+	}
+)
+
+// OrganizationFilter membatasi daftar organization ke milik anggota tertentu bila MemberID diisi
+type OrganizationFilter struct {
+	model.PaginationFilter
+	MemberID identity.ID `json:"-"`
+}
+
+type InvitationFilter struct {
+	model.PaginationFilter
+	Status InvitationStatus `form:"status" json:"status" query:"status"`
+}
@@ -0,0 +1,146 @@
+package organization
+
+import (
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type (
+	OrganizationCreated struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+		Name           string      `json:"name"`
+		OwnerID        identity.ID `json:"owner_id"`
+	}
+
+	OrganizationUpdated struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+		Fields         []string    `json:"fields"`
+	}
+
+	OrganizationDeleted struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+	}
+
+	MemberAdded struct {
+		event.Base
+		OrganizationID identity.ID    `json:"organization_id"`
+		UserID         identity.ID    `json:"user_id"`
+		Role           MembershipRole `json:"role"`
+	}
+
+	MemberRoleChanged struct {
+		event.Base
+		OrganizationID identity.ID    `json:"organization_id"`
+		UserID         identity.ID    `json:"user_id"`
+		OldRole        MembershipRole `json:"old_role"`
+		NewRole        MembershipRole `json:"new_role"`
+	}
+
+	MemberRemoved struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+		UserID         identity.ID `json:"user_id"`
+	}
+
+	TeamCreated struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+		TeamID         identity.ID `json:"team_id"`
+		Name           string      `json:"name"`
+	}
+
+	TeamUpdated struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+		TeamID         identity.ID `json:"team_id"`
+		Fields         []string    `json:"fields"`
+	}
+
+	TeamDeleted struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+		TeamID         identity.ID `json:"team_id"`
+	}
+
+	TeamMemberAdded struct {
+		event.Base
+		OrganizationID identity.ID    `json:"organization_id"`
+		TeamID         identity.ID    `json:"team_id"`
+		UserID         identity.ID    `json:"user_id"`
+		Role           MembershipRole `json:"role"`
+	}
+
+	TeamMemberRoleChanged struct {
+		event.Base
+		OrganizationID identity.ID    `json:"organization_id"`
+		TeamID         identity.ID    `json:"team_id"`
+		UserID         identity.ID    `json:"user_id"`
+		OldRole        MembershipRole `json:"old_role"`
+		NewRole        MembershipRole `json:"new_role"`
+	}
+
+	TeamMemberRemoved struct {
+		event.Base
+		OrganizationID identity.ID `json:"organization_id"`
+		TeamID         identity.ID `json:"team_id"`
+		UserID         identity.ID `json:"user_id"`
+	}
+
+	// InvitationCreated tidak membawa token, token hanya dikirim lewat email
+	InvitationCreated struct {
+		event.Base
+		InvitationID   identity.ID    `json:"invitation_id"`
+		OrganizationID identity.ID    `json:"organization_id"`
+		TeamID         identity.ID    `json:"team_id,omitempty"`
+		Email          string         `json:"email"`
+		Role           MembershipRole `json:"role"`
+	}
+
+	InvitationAccepted struct {
+		event.Base
+		InvitationID   identity.ID `json:"invitation_id"`
+		OrganizationID identity.ID `json:"organization_id"`
+		UserID         identity.ID `json:"user_id"`
+	}
+
+	InvitationRevoked struct {
+		event.Base
+		InvitationID   identity.ID `json:"invitation_id"`
+		OrganizationID identity.ID `json:"organization_id"`
+	}
+)
+
+func (OrganizationCreated) EventName() string   { return "organization.organization.created" }
+func (OrganizationUpdated) EventName() string   { return "organization.organization.updated" }
+func (OrganizationDeleted) EventName() string   { return "organization.organization.deleted" }
+func (MemberAdded) EventName() string           { return "organization.member.added" }
+func (MemberRoleChanged) EventName() string     { return "organization.member.role_changed" }
+func (MemberRemoved) EventName() string         { return "organization.member.removed" }
+func (TeamCreated) EventName() string           { return "organization.team.created" }
+func (TeamUpdated) EventName() string           { return "organization.team.updated" }
+func (TeamDeleted) EventName() string           { return "organization.team.deleted" }
+func (TeamMemberAdded) EventName() string       { return "organization.team_member.added" }
+func (TeamMemberRoleChanged) EventName() string { return "organization.team_member.role_changed" }
+func (TeamMemberRemoved) EventName() string     { return "organization.team_member.removed" }
+func (InvitationCreated) EventName() string     { return "organization.invitation.created" }
+func (InvitationAccepted) EventName() string    { return "organization.invitation.accepted" }
+func (InvitationRevoked) EventName() string     { return "organization.invitation.revoked" }
+
+func (e OrganizationCreated) AggregateID() string   { return e.OrganizationID.String() }
+func (e OrganizationUpdated) AggregateID() string   { return e.OrganizationID.String() }
+func (e OrganizationDeleted) AggregateID() string   { return e.OrganizationID.String() }
+func (e MemberAdded) AggregateID() string           { return e.OrganizationID.String() }
+func (e MemberRoleChanged) AggregateID() string     { return e.OrganizationID.String() }
+func (e MemberRemoved) AggregateID() string         { return e.OrganizationID.String() }
+func (e TeamCreated) AggregateID() string           { return e.TeamID.String() }
+func (e TeamUpdated) AggregateID() string           { return e.TeamID.String() }
+func (e TeamDeleted) AggregateID() string           { return e.TeamID.String() }
+func (e TeamMemberAdded) AggregateID() string       { return e.TeamID.String() }
+func (e TeamMemberRoleChanged) AggregateID() string { return e.TeamID.String() }
+func (e TeamMemberRemoved) AggregateID() string     { return e.TeamID.String() }
+func (e InvitationCreated) AggregateID() string     { return e.InvitationID.String() }
+func (e InvitationAccepted) AggregateID() string    { return e.InvitationID.String() }
+func (e InvitationRevoked) AggregateID() string     { return e.InvitationID.String() }
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IInvitationRepository interface {
+	Create(ctx context.Context, invitation *organization.Invitation) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *organization.Invitation, err error)
+	FindByHash(ctx context.Context, hash string) (result *organization.Invitation, err error)
+	FindAll(ctx context.Context, organizationID identity.ID, filter *organization.InvitationFilter) (result []*organization.Invitation, totalItems int64, err error)
+
+	// Update hanya berhasil bila invitation masih pending, sehingga satu token tidak bisa diterima dua kali
+	Update(ctx context.Context, invitation *organization.Invitation) (err error)
+}
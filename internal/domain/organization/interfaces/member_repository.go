@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type IMemberRepository interface {
+	// Create mengembalikan ErrConflict bila user sudah menjadi anggota
+	Create(ctx context.Context, member *organization.Member) (err error)
+	Find(ctx context.Context, organizationID, userID identity.ID) (result *organization.Member, err error)
+
+	/**
+	 * FindAll lists the members of an organization. The search term matches
+	 * the member's name, username or email.
+	 * @param ctx context.Context
+	 * @param organizationID identity.ID
+	 * @param filter *model.PaginationFilter
+	 * @return ([]*organization.Member, int64, error)
+	 */
+	FindAll(ctx context.Context, organizationID identity.ID, filter *model.PaginationFilter) (result []*organization.Member, totalItems int64, err error)
+	Update(ctx context.Context, member *organization.Member) (err error)
+
+	// Delete juga mencabut keanggotaan user di semua team milik organization
+	Delete(ctx context.Context, organizationID, userID identity.ID) (err error)
+	FindByUser(ctx context.Context, userID identity.ID) (result []*organization.Member, err error)
+
+	/**
+	 * LockByRole locks the members of an organization that hold role until
+	 * the surrounding transaction ends, so concurrent changes cannot both
+	 * pass a check on the returned count. It must run inside a transaction.
+	 * @param ctx context.Context
+	 * @param organizationID identity.ID
+	 * @param role organization.MembershipRole
+	 * @return ([]identity.ID, error)
+	 */
+	LockByRole(ctx context.Context, organizationID identity.ID, role organization.MembershipRole) (userIDs []identity.ID, err error)
+
+	CreateTeamMember(ctx context.Context, member *organization.TeamMember) (err error)
+	FindTeamMember(ctx context.Context, teamID, userID identity.ID) (result *organization.TeamMember, err error)
+	FindAllTeamMembers(ctx context.Context, teamID identity.ID, filter *model.PaginationFilter) (result []*organization.TeamMember, totalItems int64, err error)
+	UpdateTeamMember(ctx context.Context, member *organization.TeamMember) (err error)
+	DeleteTeamMember(ctx context.Context, teamID, userID identity.ID) (err error)
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IOrganizationRepository interface {
+	Create(ctx context.Context, organization *organization.Organization) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *organization.Organization, err error)
+	FindAll(ctx context.Context, filter *organization.OrganizationFilter) (result []*organization.Organization, totalItems int64, err error)
+	Update(ctx context.Context, organization *organization.Organization) (err error)
+
+	/**
+	 * Delete removes the organization together with its teams, memberships
+	 * and invitations.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @return error
+	 */
+	Delete(ctx context.Context, id identity.ID) (err error)
+}
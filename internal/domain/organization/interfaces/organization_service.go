@@ -0,0 +1,69 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type IOrganizationService interface {
+	/**
+	 * Create creates an organization owned by the authenticated user.
+	 * @param ctx context.Context
+	 * @param payload *organization.CreateOrganizationRequest
+	 * @return (*organization.OrganizationResponse, error)
+	 */
+	Create(ctx context.Context, payload *organization.CreateOrganizationRequest) (result *organization.OrganizationResponse, err error)
+	FindByID(ctx context.Context, id identity.ID) (result *organization.OrganizationResponse, err error)
+
+	/**
+	 * FindAll lists every organization for holders of organizations:read and
+	 * only the actor's own organizations for everyone else.
+	 * @param ctx context.Context
+	 * @param filter *model.PaginationFilter
+	 * @return ([]*organization.OrganizationResponse, int64, error)
+	 */
+	FindAll(ctx context.Context, filter *model.PaginationFilter) (result []*organization.OrganizationResponse, totalItems int64, err error)
+	Update(ctx context.Context, id identity.ID, payload *organization.UpdateOrganizationRequest) (result *organization.OrganizationResponse, err error)
+	Delete(ctx context.Context, id identity.ID) (err error)
+
+	FindMembers(ctx context.Context, id identity.ID, filter *model.PaginationFilter) (result []*organization.MemberResponse, totalItems int64, err error)
+
+	/**
+	 * SetMemberRole changes the membership role of an existing member. Only
+	 * owners may grant or revoke the owner role, and the last owner cannot
+	 * be demoted.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @param userID identity.ID
+	 * @param payload *organization.SetMemberRequest
+	 * @return (*organization.MemberResponse, error)
+	 */
+	SetMemberRole(ctx context.Context, id identity.ID, userID identity.ID, payload *organization.SetMemberRequest) (result *organization.MemberResponse, err error)
+
+	// RemoveMember juga dipakai anggota untuk keluar dari organization
+	RemoveMember(ctx context.Context, id identity.ID, userID identity.ID) (err error)
+
+	/**
+	 * Invite emails a single-use invitation link to join the organization,
+	 * and optionally one of its teams.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @param payload *organization.CreateInvitationRequest
+	 * @return (*organization.InvitationResponse, error)
+	 */
+	Invite(ctx context.Context, id identity.ID, payload *organization.CreateInvitationRequest) (result *organization.InvitationResponse, err error)
+	FindInvitations(ctx context.Context, id identity.ID, filter *organization.InvitationFilter) (result []*organization.InvitationResponse, totalItems int64, err error)
+	RevokeInvitation(ctx context.Context, id identity.ID, invitationID identity.ID) (err error)
+
+	/**
+	 * AcceptInvitation adds the authenticated user to the organization of the
+	 * invitation. The user's verified email must match the invited email.
+	 * @param ctx context.Context
+	 * @param payload *organization.AcceptInvitationRequest
+	 * @return (*organization.AcceptInvitationResponse, error)
+	 */
+	AcceptInvitation(ctx context.Context, payload *organization.AcceptInvitationRequest) (result *organization.AcceptInvitationResponse, err error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type ITeamRepository interface {
+	Create(ctx context.Context, team *organization.Team) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *organization.Team, err error)
+	FindAll(ctx context.Context, organizationID identity.ID, filter *model.PaginationFilter) (result []*organization.Team, totalItems int64, err error)
+	Update(ctx context.Context, team *organization.Team) (err error)
+
+	// Delete juga menghapus keanggotaan team
+	Delete(ctx context.Context, id identity.ID) (err error)
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type ITeamService interface {
+	Create(ctx context.Context, organizationID identity.ID, payload *organization.CreateTeamRequest) (result *organization.TeamResponse, err error)
+	FindByID(ctx context.Context, id identity.ID) (result *organization.TeamResponse, err error)
+	FindAll(ctx context.Context, organizationID identity.ID, filter *model.PaginationFilter) (result []*organization.TeamResponse, totalItems int64, err error)
+	Update(ctx context.Context, id identity.ID, payload *organization.UpdateTeamRequest) (result *organization.TeamResponse, err error)
+	Delete(ctx context.Context, id identity.ID) (err error)
+
+	FindMembers(ctx context.Context, id identity.ID, filter *model.PaginationFilter) (result []*organization.MemberResponse, totalItems int64, err error)
+
+	/**
+	 * SetMember adds a member of the owning organization to the team, or
+	 * changes the role of an existing team member.
+	 * @param ctx context.Context
+	 * @param id identity.ID
+	 * @param userID identity.ID
+	 * @param payload *organization.SetMemberRequest
+	 * @return (*organization.MemberResponse, error)
+	 */
+	SetMember(ctx context.Context, id identity.ID, userID identity.ID, payload *organization.SetMemberRequest) (result *organization.MemberResponse, err error)
+	RemoveMember(ctx context.Context, id identity.ID, userID identity.ID) (err error)
+}
@@ -0,0 +1,118 @@
+package organization
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/token"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+// Invitation mengundang email ke organization, dan opsional langsung ke
+// sebuah team. Hanya hash token yang disimpan, nilai aslinya dikirim lewat email.
+type Invitation struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	ID             identity.ID      `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	OrganizationID identity.ID      `json:"organization_id" gorm:"column:organization_id;type:uuid;index"`
+	TeamID         identity.ID      `json:"team_id" gorm:"column:team_id;type:uuid"`
+	Email          string           `json:"email" gorm:"column:email"`
+	Role           MembershipRole   `json:"role" gorm:"column:role"`
+	TokenHash      string           `json:"-" gorm:"column:token_hash;uniqueIndex"`
+	Status         InvitationStatus `json:"status" gorm:"column:status;index"`
+	InvitedBy      identity.ID      `json:"invited_by" gorm:"column:invited_by;type:uuid"`
+	AcceptedBy     identity.ID      `json:"accepted_by" gorm:"column:accepted_by;type:uuid"`
+	AcceptedAt     *time.Time       `json:"accepted_at" gorm:"column:accepted_at"`
+	ExpiresAt      time.Time        `json:"expires_at" gorm:"column:expires_at"`
+	CreatedAt      time.Time        `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+func (Invitation) TableName() string {
+	return "organization_invitations"
+}
+
+// NewInvitation mengembalikan invitation beserta token plain yang harus dikirim ke penerima
+func NewInvitation(organizationID, teamID identity.ID, email string, role MembershipRole, invitedBy identity.ID, ttl time.Duration) (*Invitation, string, error) {
+	plain, err := token.Generate(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	invitation := &Invitation{
+		ID:             identity.New(),
+		OrganizationID: organizationID,
+		TeamID:         teamID,
+		Email:          strings.ToLower(strings.TrimSpace(email)),
+		Role:           role,
+		TokenHash:      token.Hash(plain),
+		Status:         InvitationStatusPending,
+		InvitedBy:      invitedBy,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	invitation.Record(InvitationCreated{Base: event.NewBase(), InvitationID: invitation.ID, OrganizationID: organizationID, TeamID: teamID, Email: invitation.Email, Role: role})
+	return invitation, plain, nil
+}
+
+func (i *Invitation) IsPending(now time.Time) bool {
+	return i.Status == InvitationStatusPending && now.Before(i.ExpiresAt)
+}
+
+// Accept hanya boleh dilakukan oleh user dengan email yang diundang, agar
+// token yang bocor tidak bisa dipakai akun lain
+func (i *Invitation) Accept(userID identity.ID, email string, now time.Time) error {
+	if !i.IsPending(now) {
+		return fmt.Errorf("invitation is no longer valid: %w", errs.ErrConflict)
+	}
+	if !strings.EqualFold(strings.TrimSpace(email), i.Email) {
+		return fmt.Errorf("invitation was sent to a different email: %w", errs.ErrForbidden)
+	}
+
+	i.Status = InvitationStatusAccepted
+	i.AcceptedBy = userID
+	i.AcceptedAt = &now
+	i.UpdatedAt = now
+	i.Record(InvitationAccepted{Base: event.NewBase(), InvitationID: i.ID, OrganizationID: i.OrganizationID, UserID: userID})
+	return nil
+}
+
+func (i *Invitation) Revoke(now time.Time) error {
+	if i.Status != InvitationStatusPending {
+		return fmt.Errorf("invitation is %s: %w", i.Status, errs.ErrConflict)
+	}
+
+	i.Status = InvitationStatusRevoked
+	i.UpdatedAt = now
+	i.Record(InvitationRevoked{Base: event.NewBase(), InvitationID: i.ID, OrganizationID: i.OrganizationID})
+	return nil
+}
+
+func (i *Invitation) ToInvitationResponse() *InvitationResponse {
+	return &InvitationResponse{
+		ID:             i.ID,
+		OrganizationID: i.OrganizationID,
+		TeamID:         i.TeamID,
+		Email:          i.Email,
+		Role:           i.Role,
+		Status:         i.Status,
+		InvitedBy:      i.InvitedBy,
+		AcceptedBy:     i.AcceptedBy,
+		AcceptedAt:     i.AcceptedAt,
+		ExpiresAt:      i.ExpiresAt,
+		CreatedAt:      i.CreatedAt,
+	}
+}
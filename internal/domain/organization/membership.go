@@ -0,0 +1,161 @@
+package organization
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// MembershipRole adalah peran user di dalam organization atau team, terpisah
+// dari role account yang berlaku untuk seluruh aplikasi
+type MembershipRole string
+
+const (
+	MembershipOwner  MembershipRole = "owner"
+	MembershipAdmin  MembershipRole = "admin"
+	MembershipMember MembershipRole = "member"
+)
+
+func (r MembershipRole) rank() int {
+	switch r {
+	case MembershipOwner:
+		return 3
+	case MembershipAdmin:
+		return 2
+	case MembershipMember:
+		return 1
+	}
+	return 0
+}
+
+func (r MembershipRole) IsValid() bool {
+	return r.rank() > 0
+}
+
+// AtLeast mengecek urutan owner > admin > member; role tidak dikenal selalu false
+func (r MembershipRole) AtLeast(min MembershipRole) bool {
+	return r.IsValid() && r.rank() >= min.rank()
+}
+
+func ParseMembershipRole(value string) (MembershipRole, error) {
+	role := MembershipRole(value)
+	if !role.IsValid() {
+		return "", errs.ValidationError{Field: "role", Message: fmt.Sprintf("must be one of %s, %s, %s", MembershipOwner, MembershipAdmin, MembershipMember)}
+	}
+	return role, nil
+}
+
+// Member adalah keanggotaan user pada organization
+type Member struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	OrganizationID identity.ID    `json:"organization_id" gorm:"column:organization_id;type:uuid;primaryKey"`
+	UserID         identity.ID    `json:"user_id" gorm:"column:user_id;type:uuid;primaryKey;index"`
+	Role           MembershipRole `json:"role" gorm:"column:role"`
+	InvitedBy      identity.ID    `json:"invited_by" gorm:"column:invited_by;type:uuid"`
+	JoinedAt       time.Time      `json:"joined_at" gorm:"column:joined_at"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+func (Member) TableName() string {
+	return "organization_members"
+}
+
+func NewMember(organizationID, userID identity.ID, role MembershipRole, invitedBy identity.ID) *Member {
+	now := time.Now()
+	member := &Member{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		InvitedBy:      invitedBy,
+		JoinedAt:       now,
+		UpdatedAt:      now,
+	}
+	member.Record(MemberAdded{Base: event.NewBase(), OrganizationID: organizationID, UserID: userID, Role: role})
+	return member
+}
+
+// ChangeRole mengembalikan false bila role tidak berubah
+func (m *Member) ChangeRole(role MembershipRole) bool {
+	if m.Role == role {
+		return false
+	}
+
+	previous := m.Role
+	m.Role = role
+	m.UpdatedAt = time.Now()
+	m.Record(MemberRoleChanged{Base: event.NewBase(), OrganizationID: m.OrganizationID, UserID: m.UserID, OldRole: previous, NewRole: role})
+	return true
+}
+
+func (m *Member) Remove() {
+	m.Record(MemberRemoved{Base: event.NewBase(), OrganizationID: m.OrganizationID, UserID: m.UserID})
+}
+
+func (m *Member) ToMemberResponse() *MemberResponse {
+	return &MemberResponse{
+		UserID:    m.UserID,
+		Role:      m.Role,
+		InvitedBy: m.InvitedBy,
+		JoinedAt:  m.JoinedAt,
+	}
+}
+
+// TeamMember adalah keanggotaan user pada team. User harus anggota
+// organization pemilik team terlebih dahulu.
+type TeamMember struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	TeamID    identity.ID    `json:"team_id" gorm:"column:team_id;type:uuid;primaryKey"`
+	UserID    identity.ID    `json:"user_id" gorm:"column:user_id;type:uuid;primaryKey;index"`
+	Role      MembershipRole `json:"role" gorm:"column:role"`
+	AddedBy   identity.ID    `json:"added_by" gorm:"column:added_by;type:uuid"`
+	JoinedAt  time.Time      `json:"joined_at" gorm:"column:joined_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+func (TeamMember) TableName() string {
+	return "team_members"
+}
+
+func NewTeamMember(team *Team, userID identity.ID, role MembershipRole, addedBy identity.ID) *TeamMember {
+	now := time.Now()
+	member := &TeamMember{
+		TeamID:    team.ID,
+		UserID:    userID,
+		Role:      role,
+		AddedBy:   addedBy,
+		JoinedAt:  now,
+		UpdatedAt: now,
+	}
+	member.Record(TeamMemberAdded{Base: event.NewBase(), OrganizationID: team.OrganizationID, TeamID: team.ID, UserID: userID, Role: role})
+	return member
+}
+
+func (m *TeamMember) ChangeRole(team *Team, role MembershipRole) bool {
+	if m.Role == role {
+		return false
+	}
+
+	previous := m.Role
+	m.Role = role
+	m.UpdatedAt = time.Now()
+	m.Record(TeamMemberRoleChanged{Base: event.NewBase(), OrganizationID: team.OrganizationID, TeamID: team.ID, UserID: m.UserID, OldRole: previous, NewRole: role})
+	return true
+}
+
+func (m *TeamMember) Remove(team *Team) {
+	m.Record(TeamMemberRemoved{Base: event.NewBase(), OrganizationID: team.OrganizationID, TeamID: team.ID, UserID: m.UserID})
+}
+
+func (m *TeamMember) ToMemberResponse() *MemberResponse {
+	return &MemberResponse{
+		UserID:    m.UserID,
+		Role:      m.Role,
+		InvitedBy: m.AddedBy,
+		JoinedAt:  m.JoinedAt,
+	}
+}
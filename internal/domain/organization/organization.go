@@ -0,0 +1,161 @@
+package organization
+
+import (
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type Organization struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	ID          identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	TenantID    identity.ID `json:"tenant_id" gorm:"column:tenant_id;type:uuid;not null;uniqueIndex:idx_organizations_tenant_name"`
+	Name        string      `json:"name" gorm:"column:name;uniqueIndex:idx_organizations_tenant_name"`
+	Description string      `json:"description" gorm:"column:description"`
+	CreatedBy   identity.ID `json:"created_by" gorm:"column:created_by;type:uuid"`
+	Version     int64       `json:"version" gorm:"column:version;not null;default:1"`
+	CreatedAt   time.Time   `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// NewOrganization membuat organization beserta keanggotaan owner untuk pembuatnya
+func NewOrganization(name, description string, ownerID identity.ID) (*Organization, *Member, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil, errs.ValidationError{Field: "name", Message: "is required"}
+	}
+
+	now := time.Now()
+	organization := &Organization{
+		ID:          identity.New(),
+		Name:        name,
+		Description: description,
+		CreatedBy:   ownerID,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	organization.Record(OrganizationCreated{Base: event.NewBase(), OrganizationID: organization.ID, Name: name, OwnerID: ownerID})
+	return organization, NewMember(organization.ID, ownerID, MembershipOwner, ownerID), nil
+}
+
+// Update mengembalikan nama field yang berubah
+func (o *Organization) Update(name, description *string) (changed []string, err error) {
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		if trimmed == "" {
+			return nil, errs.ValidationError{Field: "name", Message: "must not be empty"}
+		}
+		if trimmed != o.Name {
+			o.Name = trimmed
+			changed = append(changed, "name")
+		}
+	}
+	if description != nil && *description != o.Description {
+		o.Description = *description
+		changed = append(changed, "description")
+	}
+
+	if len(changed) > 0 {
+		o.UpdatedAt = time.Now()
+		o.Record(OrganizationUpdated{Base: event.NewBase(), OrganizationID: o.ID, Fields: changed})
+	}
+	return changed, nil
+}
+
+func (o *Organization) Delete() {
+	o.Record(OrganizationDeleted{Base: event.NewBase(), OrganizationID: o.ID})
+}
+
+func (o *Organization) ToOrganizationResponse(membership MembershipRole) *OrganizationResponse {
+	return &OrganizationResponse{
+		ID:          o.ID,
+		Name:        o.Name,
+		Description: o.Description,
+		CreatedBy:   o.CreatedBy,
+		Membership:  membership,
+		Version:     o.Version,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+}
+
+type Team struct {
+	event.Recorder `json:"-" gorm:"-"`
+
+	ID             identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	OrganizationID identity.ID `json:"organization_id" gorm:"column:organization_id;type:uuid;not null;uniqueIndex:idx_teams_organization_name"`
+	Name           string      `json:"name" gorm:"column:name;uniqueIndex:idx_teams_organization_name"`
+	Description    string      `json:"description" gorm:"column:description"`
+	CreatedAt      time.Time   `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time   `json:"updated_at" gorm:"column:updated_at" audit:"-"`
+}
+
+func (Team) TableName() string {
+	return "teams"
+}
+
+func NewTeam(organizationID identity.ID, name, description string) (*Team, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errs.ValidationError{Field: "name", Message: "is required"}
+	}
+
+	now := time.Now()
+	team := &Team{
+		ID:             identity.New(),
+		OrganizationID: organizationID,
+		Name:           name,
+		Description:    description,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	team.Record(TeamCreated{Base: event.NewBase(), OrganizationID: organizationID, TeamID: team.ID, Name: name})
+	return team, nil
+}
+
+func (t *Team) Update(name, description *string) (changed []string, err error) {
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		if trimmed == "" {
+			return nil, errs.ValidationError{Field: "name", Message: "must not be empty"}
+		}
+		if trimmed != t.Name {
+			t.Name = trimmed
+			changed = append(changed, "name")
+		}
+	}
+	if description != nil && *description != t.Description {
+		t.Description = *description
+		changed = append(changed, "description")
+	}
+
+	if len(changed) > 0 {
+		t.UpdatedAt = time.Now()
+		t.Record(TeamUpdated{Base: event.NewBase(), OrganizationID: t.OrganizationID, TeamID: t.ID, Fields: changed})
+	}
+	return changed, nil
+}
+
+func (t *Team) Delete() {
+	t.Record(TeamDeleted{Base: event.NewBase(), OrganizationID: t.OrganizationID, TeamID: t.ID})
+}
+
+func (t *Team) ToTeamResponse() *TeamResponse {
+	return &TeamResponse{
+		ID:             t.ID,
+		OrganizationID: t.OrganizationID,
+		Name:           t.Name,
+		Description:    t.Description,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+}
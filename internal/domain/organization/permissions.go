@@ -0,0 +1,15 @@
+package organization
+
+import "github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+
+const (
+	PermissionOrganizationsRead  = "organizations:read"
+	PermissionOrganizationsWrite = "organizations:write"
+)
+
+// Permissions adalah permission yang dideklarasikan modul organization. Anggota
+// organization tidak membutuhkannya; akses mereka ditentukan role keanggotaan.
+var Permissions = []permission.Definition{
+	{Key: PermissionOrganizationsRead, Description: "View every organization, team and membership", Group: "organizations"},
+	{Key: PermissionOrganizationsWrite, Description: "Manage every organization, team and membership", Group: "organizations"},
+}
@@ -0,0 +1,138 @@
+package organization
+
+import (
+	"maps"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/policy"
+)
+
+const (
+	PolicyResourceOrganization = "organization"
+	PolicyResourceTeam         = "team"
+
+	PolicyOrganizationRead   = "organization:read"
+	PolicyOrganizationUpdate = "organization:update"
+	PolicyOrganizationDelete = "organization:delete"
+	PolicyMembersManage      = "organization:manage_members"
+	PolicyMemberRemove       = "organization:remove_member"
+	PolicyTeamsManage        = "organization:manage_teams"
+	PolicyTeamRead           = "team:read"
+	PolicyTeamMembersManage  = "team:manage_members"
+	PolicyTeamMemberRemove   = "team:remove_member"
+
+	// AttributeOrganizationRole dan AttributeTeamRole adalah role keanggotaan
+	// subject pada resource yang sedang dievaluasi
+	AttributeOrganizationRole = "organization_role"
+	AttributeTeamRole         = "team_role"
+
+	// FieldOwner menandai perubahan yang memberi atau mencabut role owner
+	FieldOwner = "owner"
+)
+
+// WithMembership menambahkan role keanggotaan ke atribut subject tanpa mengubah aslinya
+func WithMembership(subject policy.Subject, organizationRole, teamRole MembershipRole) policy.Subject {
+	attributes := maps.Clone(subject.Attributes)
+	if attributes == nil {
+		attributes = make(map[string]string, 2)
+	}
+	attributes[AttributeOrganizationRole] = string(organizationRole)
+	attributes[AttributeTeamRole] = string(teamRole)
+
+	subject.Attributes = attributes
+	return subject
+}
+
+// PolicyResource mengubah organization menjadi resource policy. ownerID diisi
+// dengan anggota yang dikenai action agar anggota bisa keluar sendiri.
+func (o *Organization) PolicyResource(ownerID identity.ID) policy.Resource {
+	return policy.Resource{Type: PolicyResourceOrganization, ID: o.ID, OwnerID: ownerID}
+}
+
+func (t *Team) PolicyResource(ownerID identity.ID) policy.Resource {
+	return policy.Resource{Type: PolicyResourceTeam, ID: t.ID, OwnerID: ownerID}
+}
+
+// HasOrganizationRole cocok bila role keanggotaan subject di organization minimal min
+func HasOrganizationRole(min MembershipRole) policy.Condition {
+	return func(request policy.Request) bool {
+		return MembershipRole(request.Subject.Attributes[AttributeOrganizationRole]).AtLeast(min)
+	}
+}
+
+func HasTeamRole(min MembershipRole) policy.Condition {
+	return func(request policy.Request) bool {
+		return MembershipRole(request.Subject.Attributes[AttributeTeamRole]).AtLeast(min)
+	}
+}
+
+// Policies adalah rule bawaan modul organization. Permission global
+// organizations:* dipakai admin aplikasi, anggota cukup dengan role keanggotaan.
+var Policies = []policy.Rule{
+	{
+		Name:      "organizations-read-permission",
+		Effect:    policy.Allow,
+		Actions:   []string{PolicyOrganizationRead, PolicyTeamRead},
+		Condition: policy.HasPermission(PermissionOrganizationsRead),
+	},
+	{
+		Name:      "organizations-read-member",
+		Effect:    policy.Allow,
+		Actions:   []string{PolicyOrganizationRead, PolicyTeamRead},
+		Condition: HasOrganizationRole(MembershipMember),
+	},
+	{
+		Name:   "organizations-write-permission",
+		Effect: policy.Allow,
+		Actions: []string{
+			PolicyOrganizationUpdate, PolicyOrganizationDelete, PolicyMembersManage, PolicyMemberRemove,
+			PolicyTeamsManage, PolicyTeamMembersManage, PolicyTeamMemberRemove,
+		},
+		Condition: policy.HasPermission(PermissionOrganizationsWrite),
+	},
+	{
+		Name:   "organizations-admin",
+		Effect: policy.Allow,
+		Actions: []string{
+			PolicyOrganizationUpdate, PolicyMembersManage, PolicyMemberRemove,
+			PolicyTeamsManage, PolicyTeamMembersManage, PolicyTeamMemberRemove,
+		},
+		Condition: HasOrganizationRole(MembershipAdmin),
+	},
+	{
+		Name:         "organizations-owner-delete",
+		Effect:       policy.Allow,
+		Actions:      []string{PolicyOrganizationDelete},
+		ResourceType: PolicyResourceOrganization,
+		Condition:    HasOrganizationRole(MembershipOwner),
+	},
+	{
+		Name:      "members-leave",
+		Effect:    policy.Allow,
+		Actions:   []string{PolicyMemberRemove, PolicyTeamMemberRemove},
+		Condition: policy.IsOwner(),
+	},
+	{
+		Name:         "teams-admin",
+		Effect:       policy.Allow,
+		Actions:      []string{PolicyTeamMembersManage, PolicyTeamMemberRemove},
+		ResourceType: PolicyResourceTeam,
+		Condition:    HasTeamRole(MembershipAdmin),
+	},
+	{
+		Name:         "organizations-owner-requires-owner",
+		Effect:       policy.Deny,
+		Actions:      []string{PolicyMembersManage, PolicyMemberRemove},
+		ResourceType: PolicyResourceOrganization,
+		Fields:       []string{FieldOwner},
+		Condition:    policy.Not(policy.AnyOf(HasOrganizationRole(MembershipOwner), policy.HasPermission(PermissionOrganizationsWrite))),
+	},
+	{
+		Name:         "teams-owner-requires-owner",
+		Effect:       policy.Deny,
+		Actions:      []string{PolicyTeamMembersManage, PolicyTeamMemberRemove},
+		ResourceType: PolicyResourceTeam,
+		Fields:       []string{FieldOwner},
+		Condition:    policy.Not(policy.AnyOf(HasTeamRole(MembershipOwner), HasOrganizationRole(MembershipAdmin), policy.HasPermission(PermissionOrganizationsWrite))),
+	},
+}
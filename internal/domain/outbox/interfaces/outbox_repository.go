@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+)
+
+type IOutboxRepository interface {
+	Create(ctx context.Context, messages []*outbox.Message) (err error)
+
+	/**
+	 * Claim leases due messages to the caller until leaseUntil by pushing
+	 * their next_attempt_at forward, skipping rows locked by another relay.
+	 * Run it in its own short transaction; publishing happens after commit.
+	 * @param ctx context.Context
+	 * @param limit int
+	 * @param now time.Time
+	 * @param leaseUntil time.Time
+	 * @return ([]*outbox.Message, error)
+	 */
+	Claim(ctx context.Context, limit int, now time.Time, leaseUntil time.Time) (result []*outbox.Message, err error)
+
+	// Update hanya menulis bila sewa leaseUntil masih dipegang, selain itu errs.ErrConflict
+	Update(ctx context.Context, message *outbox.Message, leaseUntil time.Time) (err error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+)
+
+type IOutboxService interface {
+	// Enqueue harus dipanggil di dalam transaksi yang sama dengan perubahan aggregate
+	Enqueue(ctx context.Context, events ...event.Event) (err error)
+}
+
+type IPublisher interface {
+	Publish(ctx context.Context, message *outbox.Message) (err error)
+}
@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/event"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusPublished Status = "published"
+	StatusFailed    Status = "failed"
+)
+
+type Message struct {
+	ID             identity.ID     `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	IdempotencyKey string          `json:"idempotency_key" gorm:"column:idempotency_key;uniqueIndex"`
+	EventName      string          `json:"event_name" gorm:"column:event_name"`
+	AggregateID    string          `json:"aggregate_id" gorm:"column:aggregate_id"`
+	Payload        json.RawMessage `json:"payload" gorm:"column:payload;type:jsonb"`
+	Status         Status          `json:"status" gorm:"column:status;index"`
+	Attempts       int             `json:"attempts" gorm:"column:attempts"`
+	LastError      string          `json:"last_error" gorm:"column:last_error"`
+	OccurredAt     time.Time       `json:"occurred_at" gorm:"column:occurred_at"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" gorm:"column:next_attempt_at;index"`
+	PublishedAt    *time.Time      `json:"published_at" gorm:"column:published_at"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"column:created_at"`
+}
+
+// Envelope adalah bentuk pesan yang dikirim ke publisher
+type Envelope struct {
+	ID             string          `json:"id"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	EventName      string          `json:"event_name"`
+	AggregateID    string          `json:"aggregate_id"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// NewMessage memakai ID event sebagai idempotency key agar consumer bisa
+// membuang pesan duplikat dari pengiriman at-least-once
+func NewMessage(e event.Event) (*Message, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Message{
+		ID:             identity.New(),
+		IdempotencyKey: e.EventID().String(),
+		EventName:      e.EventName(),
+		AggregateID:    e.AggregateID(),
+		Payload:        payload,
+		Status:         StatusPending,
+		OccurredAt:     e.OccurredAt(),
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}, nil
+}
+
+func (m *Message) Envelope() Envelope {
+	return Envelope{
+		ID:             m.ID.String(),
+		IdempotencyKey: m.IdempotencyKey,
+		EventName:      m.EventName,
+		AggregateID:    m.AggregateID,
+		OccurredAt:     m.OccurredAt,
+		Payload:        m.Payload,
+	}
+}
+
+// Claim menyewa pesan untuk satu relay sampai leaseUntil. Bila relay mati
+// sebelum mencatat hasilnya, pesan dikirim ulang setelah sewa habis.
+func (m *Message) Claim(leaseUntil time.Time) {
+	m.NextAttemptAt = leaseUntil
+}
+
+func (m *Message) MarkPublished(at time.Time) {
+	m.Status = StatusPublished
+	m.PublishedAt = &at
+	m.LastError = ""
+}
+
+// MarkFailed menjadwalkan percobaan berikutnya, atau menandai pesan gagal
+// permanen bila batas percobaan sudah habis
+func (m *Message) MarkFailed(err error, nextAttemptAt time.Time, maxAttempts int) {
+	m.Attempts++
+	m.LastError = err.Error()
+	m.NextAttemptAt = nextAttemptAt
+
+	if maxAttempts > 0 && m.Attempts >= maxAttempts {
+		m.Status = StatusFailed
+	}
+}
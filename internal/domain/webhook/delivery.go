@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// maxResponseBody membatasi body response yang disimpan di riwayat
+const maxResponseBody = 2048
+
+type Delivery struct {
+	ID             identity.ID     `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	SubscriptionID identity.ID     `json:"subscription_id" gorm:"column:subscription_id;type:uuid;index"`
+	DeliveryKey    string          `json:"-" gorm:"column:delivery_key;uniqueIndex"`
+	EventID        string          `json:"event_id" gorm:"column:event_id"`
+	EventName      string          `json:"event_name" gorm:"column:event_name"`
+	Payload        json.RawMessage `json:"payload" gorm:"column:payload;type:jsonb"`
+	Status         DeliveryStatus  `json:"status" gorm:"column:status;index"`
+	Attempts       int             `json:"attempts" gorm:"column:attempts"`
+	ResponseStatus int             `json:"response_status" gorm:"column:response_status"`
+	ResponseBody   string          `json:"response_body" gorm:"column:response_body"`
+	LastError      string          `json:"last_error" gorm:"column:last_error"`
+	RedeliveryOf   *identity.ID    `json:"redelivery_of,omitempty" gorm:"column:redelivery_of;type:uuid"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" gorm:"column:next_attempt_at;index"`
+	DeliveredAt    *time.Time      `json:"delivered_at" gorm:"column:delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type DeliveryResult struct {
+	StatusCode int
+	Body       string
+}
+
+type DeliveryFilter struct {
+	model.PaginationFilter
+	SubscriptionID string         `form:"subscription_id" json:"subscription_id" query:"subscription_id"`
+	Status         DeliveryStatus `form:"status" json:"status" query:"status"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// NewDelivery membuat delivery pertama untuk sebuah event. DeliveryKey unik per
+// subscription dan event sehingga event yang dikirim ulang oleh outbox tidak
+// menghasilkan delivery ganda.
+func NewDelivery(subscriptionID identity.ID, eventID string, eventName string, payload json.RawMessage) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		ID:             identity.New(),
+		SubscriptionID: subscriptionID,
+		DeliveryKey:    subscriptionID.String() + ":" + eventID,
+		EventID:        eventID,
+		EventName:      eventName,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Redeliver membuat delivery baru dengan payload dan event ID yang sama
+func (d *Delivery) Redeliver() *Delivery {
+	redelivery := NewDelivery(d.SubscriptionID, d.EventID, d.EventName, d.Payload)
+	redelivery.DeliveryKey = redelivery.DeliveryKey + ":" + redelivery.ID.String()
+	redelivery.RedeliveryOf = &d.ID
+	return redelivery
+}
+
+// Claim menyewa delivery untuk satu worker sampai leaseUntil. Bila worker mati
+// sebelum mencatat hasilnya, delivery dikirim ulang setelah sewa habis.
+func (d *Delivery) Claim(leaseUntil time.Time) {
+	d.NextAttemptAt = leaseUntil
+}
+
+func (d *Delivery) MarkSucceeded(result *DeliveryResult, at time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.ResponseStatus = result.StatusCode
+	d.ResponseBody = truncate(result.Body)
+	d.LastError = ""
+	d.DeliveredAt = &at
+	d.UpdatedAt = at
+}
+
+func (d *Delivery) MarkFailed(result *DeliveryResult, err error, nextAttemptAt time.Time, maxAttempts int) {
+	d.Attempts++
+	d.LastError = err.Error()
+	d.NextAttemptAt = nextAttemptAt
+	d.UpdatedAt = time.Now()
+	if result != nil {
+		d.ResponseStatus = result.StatusCode
+		d.ResponseBody = truncate(result.Body)
+	}
+
+	if maxAttempts > 0 && d.Attempts >= maxAttempts {
+		d.Status = DeliveryFailed
+	}
+}
+
+func truncate(body string) string {
+	if len(body) > maxResponseBody {
+		return body[:maxResponseBody]
+	}
+	return body
+}
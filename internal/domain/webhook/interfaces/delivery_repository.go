@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type IDeliveryRepository interface {
+	// Create mengabaikan delivery yang DeliveryKey-nya sudah ada
+	Create(ctx context.Context, deliveries []*webhook.Delivery) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *webhook.Delivery, err error)
+	FindAll(ctx context.Context, filter *webhook.DeliveryFilter) (result []*webhook.Delivery, totalItems int64, err error)
+
+	/**
+	 * Claim leases due deliveries to the caller until leaseUntil by pushing
+	 * their next_attempt_at forward, skipping rows locked by another worker.
+	 * Run it in its own short transaction; sending happens after commit.
+	 * @param ctx context.Context
+	 * @param limit int
+	 * @param now time.Time
+	 * @param leaseUntil time.Time
+	 * @return ([]*webhook.Delivery, error)
+	 */
+	Claim(ctx context.Context, limit int, now time.Time, leaseUntil time.Time) (result []*webhook.Delivery, err error)
+
+	// Update hanya menulis bila sewa leaseUntil masih dipegang, selain itu errs.ErrConflict
+	Update(ctx context.Context, delivery *webhook.Delivery, leaseUntil time.Time) (err error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type ISubscriptionRepository interface {
+	Create(ctx context.Context, subscription *webhook.Subscription) (err error)
+	FindByID(ctx context.Context, id identity.ID) (result *webhook.Subscription, err error)
+	FindAll(ctx context.Context, filter *model.PaginationFilter) (result []*webhook.Subscription, totalItems int64, err error)
+	FindActive(ctx context.Context) (result []*webhook.Subscription, err error)
+	Update(ctx context.Context, subscription *webhook.Subscription) (err error)
+	Delete(ctx context.Context, id identity.ID) (err error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+)
+
+type IWebhookSender interface {
+	// Send mengembalikan result bila endpoint merespons, walaupun statusnya gagal
+	Send(ctx context.Context, subscription *webhook.Subscription, delivery *webhook.Delivery) (result *webhook.DeliveryResult, err error)
+}
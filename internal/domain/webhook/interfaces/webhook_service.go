@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+)
+
+type IWebhookService interface {
+	CreateSubscription(ctx context.Context, payload *webhook.CreateSubscriptionRequest) (result *webhook.SubscriptionSecretResponse, err error)
+	GetSubscription(ctx context.Context, id identity.ID) (result *webhook.Subscription, err error)
+	ListSubscriptions(ctx context.Context, filter *model.PaginationFilter) (result []*webhook.Subscription, totalItems int64, err error)
+	UpdateSubscription(ctx context.Context, id identity.ID, payload *webhook.UpdateSubscriptionRequest) (err error)
+	DeleteSubscription(ctx context.Context, id identity.ID) (err error)
+	ListDeliveries(ctx context.Context, filter *webhook.DeliveryFilter) (result []*webhook.Delivery, totalItems int64, err error)
+	GetDelivery(ctx context.Context, id identity.ID) (result *webhook.Delivery, err error)
+	Redeliver(ctx context.Context, id identity.ID) (result *webhook.Delivery, err error)
+}
@@ -0,0 +1,14 @@
+package webhook
+
+import "github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+
+const (
+	PermissionWebhooksRead  = "webhooks:read"
+	PermissionWebhooksWrite = "webhooks:write"
+)
+
+// Permissions adalah permission yang dideklarasikan modul webhook
+var Permissions = []permission.Definition{
+	{Key: PermissionWebhooksRead, Description: "View webhook subscriptions and deliveries", Group: "webhooks"},
+	{Key: PermissionWebhooksWrite, Description: "Manage webhook subscriptions and redeliver events", Group: "webhooks"},
+}
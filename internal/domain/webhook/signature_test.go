@@ -0,0 +1,45 @@
+package webhook
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	got := Sign("whsec_test", 1700000000, []byte(`{"id":1}`))
+	want := "sha256=2f441ba4b3b2d50d28a9ab9d9fd8880376ecd1eb5d0435401553f5d8d0a5dcf8"
+	if got != want {
+		t.Fatalf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const (
+		secret    = "whsec_test"
+		timestamp = int64(1700000000)
+	)
+	body := []byte(`{"id":1}`)
+	signature := Sign(secret, timestamp, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid", secret: secret, timestamp: timestamp, body: body, signature: signature, want: true},
+		{name: "surrounding whitespace", secret: secret, timestamp: timestamp, body: body, signature: " " + signature + "\n", want: true},
+		{name: "wrong secret", secret: "other", timestamp: timestamp, body: body, signature: signature, want: false},
+		{name: "replayed with new timestamp", secret: secret, timestamp: timestamp + 1, body: body, signature: signature, want: false},
+		{name: "tampered body", secret: secret, timestamp: timestamp, body: []byte(`{"id":2}`), signature: signature, want: false},
+		{name: "missing prefix", secret: secret, timestamp: timestamp, body: body, signature: signature[len("sha256="):], want: false},
+		{name: "empty", secret: secret, timestamp: timestamp, body: body, signature: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.timestamp, tt.body, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type Subscription struct {
+	ID          identity.ID `json:"id" gorm:"column:id;type:uuid;default:uuid_generate_v4()"`
+	URL         string      `json:"url" gorm:"column:url"`
+	EventTypes  []string    `json:"event_types" gorm:"column:event_types;type:jsonb;serializer:json"`
+	Secret      string      `json:"-" gorm:"column:secret"`
+	Description string      `json:"description" gorm:"column:description"`
+	IsActive    bool        `json:"is_active" gorm:"column:is_active;default:true"`
+	CreatedAt   time.Time   `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type (
+	CreateSubscriptionRequest struct {
+		URL         string   `json:"url" validate:"required,url"`
+		EventTypes  []string `json:"event_types"`
+		Description string   `json:"description"`
+	}
+
+	UpdateSubscriptionRequest struct {
+		URL         string   `json:"url" validate:"omitempty,url"`
+		EventTypes  []string `json:"event_types"`
+		Description string   `json:"description"`
+		IsActive    *bool    `json:"is_active"`
+	}
+
+	// SubscriptionSecretResponse hanya dikembalikan saat subscription dibuat
+	SubscriptionSecretResponse struct {
+		Subscription
+		Secret string `json:"secret"`
+	}
+)
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Matches mengecek filter event type. Filter kosong atau "*" menerima semua
+// event, sedangkan "account.user.*" menerima semua event dengan prefix tersebut.
+func (s *Subscription) Matches(eventName string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+
+	for _, pattern := range s.EventTypes {
+		if pattern == "*" || pattern == eventName {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(eventName, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/mail"
+)
+
+// LogMailer menulis email ke log, hanya untuk development
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogMailer{logger: logger}
+}
+
+func (l *LogMailer) Send(ctx context.Context, message mail.Message) error {
+	l.logger.InfoContext(ctx, "mail sent",
+		slog.String("to", message.To),
+		slog.String("subject", message.Subject),
+		slog.String("body", message.Body),
+	)
+	return nil
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+// sweepEvery menentukan seberapa sering entri kedaluwarsa dibersihkan
+const sweepEvery = 256
+
+// LoginAttemptStore menyimpan hitungan kegagalan di memori. Cocok untuk satu
+// instance; gunakan implementasi database jika aplikasi berjalan di banyak instance.
+type LoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]account.LoginAttempt
+	maxAge   time.Duration
+	writes   int
+}
+
+var _ interfaces.ILoginAttemptStore = (*LoginAttemptStore)(nil)
+
+// NewLoginAttemptStore membuang entri yang tidak terkunci dan tidak gagal lagi selama maxAge
+func NewLoginAttemptStore(maxAge time.Duration) *LoginAttemptStore {
+	return &LoginAttemptStore{
+		attempts: make(map[string]account.LoginAttempt),
+		maxAge:   maxAge,
+	}
+}
+
+func (s *LoginAttemptStore) Get(ctx context.Context, key string) (result *account.LoginAttempt, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return nil, fmt.Errorf("login attempt '%s' not found: %w", key, errs.ErrNotFound)
+	}
+	return &attempt, nil
+}
+
+func (s *LoginAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (result *account.LoginAttempt, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || now.Sub(attempt.LastFailedAt) > resetAfter {
+		attempt = account.LoginAttempt{Key: key, LockedUntil: attempt.LockedUntil}
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = now
+	s.attempts[key] = attempt
+
+	s.writes++
+	if s.writes%sweepEvery == 0 {
+		s.sweep(now)
+	}
+	return &attempt, nil
+}
+
+// Release mengurangi satu kegagalan tanpa menyentuh lock yang sedang berlaku
+func (s *LoginAttemptStore) Release(ctx context.Context, key string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.attempts[key]; ok && attempt.Failures > 0 {
+		attempt.Failures--
+		s.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (s *LoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		attempt = account.LoginAttempt{Key: key}
+	}
+	attempt.LockedUntil = &until
+	s.attempts[key] = attempt
+	return nil
+}
+
+func (s *LoginAttemptStore) Reset(ctx context.Context, key string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+func (s *LoginAttemptStore) sweep(now time.Time) {
+	for key, attempt := range s.attempts {
+		if !attempt.IsLocked(now) && now.Sub(attempt.LastFailedAt) > s.maxAge {
+			delete(s.attempts, key)
+		}
+	}
+}
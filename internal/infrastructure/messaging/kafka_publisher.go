@@ -0,0 +1,42 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+)
+
+// KafkaProducer dibungkus oleh aplikasi di atas client Kafka yang dipakai
+// (segmentio/kafka-go, franz-go, sarama, ...)
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key []byte, value []byte, headers map[string]string) error
+}
+
+type KafkaPublisher struct {
+	producer KafkaProducer
+	topic    string
+}
+
+func NewKafkaPublisher(producer KafkaProducer, topic string) *KafkaPublisher {
+	return &KafkaPublisher{producer: producer, topic: topic}
+}
+
+// Publish memakai aggregate ID sebagai key agar urutan event per aggregate terjaga
+func (p *KafkaPublisher) Publish(ctx context.Context, message *outbox.Message) error {
+	body, err := json.Marshal(message.Envelope())
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope: %w", err)
+	}
+
+	headers := map[string]string{
+		"idempotency_key": message.IdempotencyKey,
+		"event_name":      message.EventName,
+	}
+
+	if err := p.producer.Produce(ctx, p.topic, []byte(message.AggregateID), body, headers); err != nil {
+		return fmt.Errorf("failed to publish to kafka topic %s: %w", p.topic, err)
+	}
+	return nil
+}
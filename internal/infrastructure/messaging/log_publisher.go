@@ -0,0 +1,30 @@
+package messaging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+)
+
+// LogPublisher menulis pesan ke log, berguna untuk development
+type LogPublisher struct {
+	logger *slog.Logger
+}
+
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogPublisher{logger: logger}
+}
+
+func (l *LogPublisher) Publish(ctx context.Context, message *outbox.Message) error {
+	l.logger.InfoContext(ctx, "outbox message published",
+		slog.String("idempotency_key", message.IdempotencyKey),
+		slog.String("event", message.EventName),
+		slog.String("aggregate_id", message.AggregateID),
+		slog.String("payload", string(message.Payload)),
+	)
+	return nil
+}
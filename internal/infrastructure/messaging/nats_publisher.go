@@ -0,0 +1,41 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+)
+
+// NatsClient dipenuhi oleh *nats.Conn sehingga adapter ini tidak
+// menambah dependency ke client NATS
+type NatsClient interface {
+	Publish(subject string, data []byte) error
+}
+
+type NatsPublisher struct {
+	client        NatsClient
+	subjectPrefix string
+}
+
+func NewNatsPublisher(client NatsClient, subjectPrefix string) *NatsPublisher {
+	return &NatsPublisher{client: client, subjectPrefix: subjectPrefix}
+}
+
+func (p *NatsPublisher) Publish(ctx context.Context, message *outbox.Message) error {
+	body, err := json.Marshal(message.Envelope())
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope: %w", err)
+	}
+
+	subject := message.EventName
+	if p.subjectPrefix != "" {
+		subject = p.subjectPrefix + "." + subject
+	}
+
+	if err := p.client.Publish(subject, body); err != nil {
+		return fmt.Errorf("failed to publish to nats subject %s: %w", subject, err)
+	}
+	return nil
+}
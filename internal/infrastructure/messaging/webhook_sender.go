@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+)
+
+// WebhookSender mengirim delivery sebagai HTTP POST bertanda tangan HMAC-SHA256
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(client *http.Client) *WebhookSender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookSender{client: client}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, subscription *webhook.Subscription, delivery *webhook.Delivery) (*webhook.DeliveryResult, error) {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.IDHeader, delivery.EventID)
+	req.Header.Set(webhook.EventHeader, delivery.EventName)
+	req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	result := &webhook.DeliveryResult{StatusCode: res.StatusCode, Body: string(body)}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return result, fmt.Errorf("webhook endpoint responded with status %d", res.StatusCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+func TestWebhookSenderSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantErr    bool
+		wantStatus int
+	}{
+		{name: "accepted", status: http.StatusNoContent, wantStatus: http.StatusNoContent},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true, wantStatus: http.StatusInternalServerError},
+		{name: "redirect is not success", status: http.StatusNotModified, wantErr: true, wantStatus: http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := &webhook.Subscription{ID: identity.New(), Secret: "whsec_test"}
+			delivery := webhook.NewDelivery(subscription.ID, identity.New().String(), "account.user.registered", json.RawMessage(`{"user_id":"1"}`))
+
+			var verified bool
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+
+				verified = webhook.Verify(subscription.Secret, timestamp, body, r.Header.Get(webhook.SignatureHeader)) &&
+					r.Header.Get(webhook.IDHeader) == delivery.EventID &&
+					r.Header.Get(webhook.EventHeader) == delivery.EventName &&
+					r.Header.Get("Content-Type") == "application/json"
+				w.WriteHeader(tt.status)
+			}))
+			defer receiver.Close()
+			subscription.URL = receiver.URL
+
+			result, err := NewWebhookSender(receiver.Client()).Send(context.Background(), subscription, delivery)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result == nil || result.StatusCode != tt.wantStatus {
+				t.Fatalf("Send() result = %+v, want status %d", result, tt.wantStatus)
+			}
+			if !verified {
+				t.Error("receiver could not verify the signed request")
+			}
+		})
+	}
+}
+
+func TestWebhookSenderUnreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	subscription := &webhook.Subscription{ID: identity.New(), URL: url, Secret: "whsec_test"}
+	delivery := webhook.NewDelivery(subscription.ID, identity.New().String(), "account.user.deleted", json.RawMessage(`{}`))
+
+	result, err := NewWebhookSender(nil).Send(context.Background(), subscription, delivery)
+	if err == nil {
+		t.Fatal("Send() error = nil, want connection error")
+	}
+	if result != nil {
+		t.Errorf("Send() result = %+v, want nil without a response", result)
+	}
+}
@@ -0,0 +1,238 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+var errInvalidIDToken = fmt.Errorf("id token is invalid: %w", errs.ErrUnauthorized)
+
+type ProviderConfig struct {
+	// Name dipakai pada URL login, misalnya /auth/oidc/google/login
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes default openid, email dan profile
+	Scopes []string
+}
+
+// discovery adalah bagian dokumen .well-known/openid-configuration yang dipakai
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider adalah client OIDC untuk satu identity provider
+type Provider struct {
+	config    ProviderConfig
+	client    *http.Client
+	discovery discovery
+	keys      *keySet
+}
+
+// NewProvider membaca dokumen discovery dari issuer. Issuer pada dokumen
+// harus sama persis dengan konfigurasi.
+func NewProvider(ctx context.Context, config ProviderConfig, client *http.Client) (*Provider, error) {
+	if config.Name == "" || config.Issuer == "" || config.ClientID == "" {
+		return nil, errors.New("oidc provider requires name, issuer and client id")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = defaultScopes
+	}
+
+	var doc discovery
+	wellKnown := strings.TrimSuffix(config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, client, wellKnown, &doc); err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider '%s': %w", config.Name, err)
+	}
+	if doc.Issuer != config.Issuer {
+		return nil, fmt.Errorf("oidc provider '%s' reports issuer '%s'", config.Name, doc.Issuer)
+	}
+
+	return &Provider{
+		config:    config,
+		client:    client,
+		discovery: doc,
+		keys:      newKeySet(client, doc.JWKSURI),
+	}, nil
+}
+
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+func (p *Provider) AuthCodeURL(state string, nonce string, codeChallenge string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.discovery.AuthorizationEndpoint + separator + query.Encode()
+}
+
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string) (*account.ExternalClaims, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		// Code yang salah atau kedaluwarsa adalah kesalahan dari sisi client
+		if res.StatusCode == http.StatusBadRequest {
+			return nil, fmt.Errorf("provider rejected the authorization code: %w", errs.ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("token endpoint responded with status %d", res.StatusCode)
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil || tokens.IDToken == "" {
+		return nil, errInvalidIDToken
+	}
+
+	return p.verify(ctx, tokens.IDToken)
+}
+
+// idTokenClaims menerima aud berupa string maupun array sesuai spesifikasi
+type idTokenClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	ExpiresAt     int64    `json:"exp"`
+	IssuedAt      int64    `json:"iat"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// clockSkew adalah toleransi perbedaan jam dengan provider
+const clockSkew = time.Minute
+
+func (p *Provider) verify(ctx context.Context, idToken string) (*account.ExternalClaims, error) {
+	payload, err := p.keys.verify(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errInvalidIDToken
+	}
+
+	now := time.Now()
+	switch {
+	case claims.Issuer != p.discovery.Issuer:
+		return nil, errInvalidIDToken
+	case !containsString(claims.Audience, p.config.ClientID):
+		return nil, errInvalidIDToken
+	case claims.Subject == "":
+		return nil, errInvalidIDToken
+	case now.Add(-clockSkew).Unix() >= claims.ExpiresAt:
+		return nil, errInvalidIDToken
+	case claims.IssuedAt > now.Add(clockSkew).Unix():
+		return nil, errInvalidIDToken
+	}
+
+	return &account.ExternalClaims{
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Nonce:         claims.Nonce,
+	}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func getJSON(ctx context.Context, client *http.Client, target string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with status %d", target, res.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(dst)
+}
@@ -0,0 +1,326 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+const (
+	testClientID     = "client-1"
+	testClientSecret = "secret-1"
+	testCode         = "code-1"
+	testVerifier     = "verifier-1"
+)
+
+// mockProvider adalah identity provider palsu: discovery, JWKS dan token
+// endpoint yang memeriksa code serta PKCE verifier
+type mockProvider struct {
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	kid     string
+	idToken string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &mockProvider{key: key, kid: "key-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(discovery{
+			Issuer:                m.server.URL,
+			AuthorizationEndpoint: m.server.URL + "/authorize",
+			TokenEndpoint:         m.server.URL + "/token",
+			JWKSURI:               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []jsonWebKey{{
+				Kid: m.kid,
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, ok := r.BasicAuth()
+		if !ok || clientID != testClientID || secret != testClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.FormValue("grant_type") != "authorization_code" || r.FormValue("code") != testCode || r.FormValue("code_verifier") != testVerifier {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": m.idToken})
+	})
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockProvider) provider(t *testing.T) *Provider {
+	t.Helper()
+
+	provider, err := NewProvider(context.Background(), ProviderConfig{
+		Name:         "mock",
+		Issuer:       m.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  "https://app.example/callback",
+	}, m.server.Client())
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	return provider
+}
+
+func (m *mockProvider) claims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":            m.server.URL,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          "nonce-1",
+		"email":          "Alice@Example.com",
+		"email_verified": true,
+		"name":           "Alice",
+	}
+}
+
+func (m *mockProvider) sign(t *testing.T, header map[string]interface{}, claims map[string]interface{}) string {
+	t.Helper()
+
+	rawHeader, _ := json.Marshal(header)
+	rawClaims, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(rawHeader) + "." + base64.RawURLEncoding.EncodeToString(rawClaims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, m.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestProviderAuthCodeURL(t *testing.T) {
+	mock := newMockProvider(t)
+	provider := mock.provider(t)
+
+	parsed, err := url.Parse(provider.AuthCodeURL("state-1", "nonce-1", "challenge-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.Scheme + "://" + parsed.Host + parsed.Path; got != mock.server.URL+"/authorize" {
+		t.Errorf("endpoint = %q", got)
+	}
+
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          "https://app.example/callback",
+		"scope":                 "openid email profile",
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        "challenge-1",
+		"code_challenge_method": "S256",
+	}
+	query := parsed.Query()
+	for name, value := range want {
+		if got := query.Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestNewProviderRejectsIssuerMismatch(t *testing.T) {
+	mock := newMockProvider(t)
+
+	_, err := NewProvider(context.Background(), ProviderConfig{
+		Name:     "mock",
+		Issuer:   mock.server.URL + "/other",
+		ClientID: testClientID,
+	}, mock.server.Client())
+	if err == nil {
+		t.Fatal("NewProvider() error = nil, want issuer mismatch")
+	}
+}
+
+func TestProviderExchange(t *testing.T) {
+	now := time.Now()
+	rs256 := func(m *mockProvider) map[string]interface{} {
+		return map[string]interface{}{"alg": "RS256", "kid": m.kid}
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		verifier string
+		token    func(t *testing.T, m *mockProvider) string
+		wantErr  error
+	}{
+		{
+			name: "valid",
+			token: func(t *testing.T, m *mockProvider) string {
+				return m.sign(t, rs256(m), m.claims(now))
+			},
+		},
+		{
+			name: "audience array",
+			token: func(t *testing.T, m *mockProvider) string {
+				claims := m.claims(now)
+				claims["aud"] = []string{"other", testClientID}
+				return m.sign(t, rs256(m), claims)
+			},
+		},
+		{
+			name:     "wrong pkce verifier",
+			verifier: "other-verifier",
+			token: func(t *testing.T, m *mockProvider) string {
+				return m.sign(t, rs256(m), m.claims(now))
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name: "wrong code",
+			code: "other-code",
+			token: func(t *testing.T, m *mockProvider) string {
+				return m.sign(t, rs256(m), m.claims(now))
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name: "wrong issuer",
+			token: func(t *testing.T, m *mockProvider) string {
+				claims := m.claims(now)
+				claims["iss"] = "https://evil.example"
+				return m.sign(t, rs256(m), claims)
+			},
+			wantErr: errInvalidIDToken,
+		},
+		{
+			name: "wrong audience",
+			token: func(t *testing.T, m *mockProvider) string {
+				claims := m.claims(now)
+				claims["aud"] = "other-client"
+				return m.sign(t, rs256(m), claims)
+			},
+			wantErr: errInvalidIDToken,
+		},
+		{
+			name: "missing subject",
+			token: func(t *testing.T, m *mockProvider) string {
+				claims := m.claims(now)
+				delete(claims, "sub")
+				return m.sign(t, rs256(m), claims)
+			},
+			wantErr: errInvalidIDToken,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T, m *mockProvider) string {
+				claims := m.claims(now)
+				claims["exp"] = now.Add(-2 * clockSkew).Unix()
+				return m.sign(t, rs256(m), claims)
+			},
+			wantErr: errInvalidIDToken,
+		},
+		{
+			name: "issued in the future",
+			token: func(t *testing.T, m *mockProvider) string {
+				claims := m.claims(now)
+				claims["iat"] = now.Add(2 * clockSkew).Unix()
+				return m.sign(t, rs256(m), claims)
+			},
+			wantErr: errInvalidIDToken,
+		},
+		{
+			name: "unknown kid",
+			token: func(t *testing.T, m *mockProvider) string {
+				return m.sign(t, map[string]interface{}{"alg": "RS256", "kid": "key-2"}, m.claims(now))
+			},
+			wantErr: errs.ErrUnauthorized,
+		},
+		{
+			name: "alg none",
+			token: func(t *testing.T, m *mockProvider) string {
+				return m.sign(t, map[string]interface{}{"alg": "none", "kid": m.kid}, m.claims(now))
+			},
+			wantErr: errInvalidIDToken,
+		},
+		{
+			name: "alg HS256",
+			token: func(t *testing.T, m *mockProvider) string {
+				return m.sign(t, map[string]interface{}{"alg": "HS256", "kid": m.kid}, m.claims(now))
+			},
+			wantErr: errInvalidIDToken,
+		},
+		{
+			name: "tampered payload",
+			token: func(t *testing.T, m *mockProvider) string {
+				signed := strings.Split(m.sign(t, rs256(m), m.claims(now)), ".")
+				claims := m.claims(now)
+				claims["sub"] = "subject-2"
+				forged := strings.Split(m.sign(t, rs256(m), claims), ".")
+				// payload diganti tetapi tanda tangan tetap milik payload asli
+				return signed[0] + "." + forged[1] + "." + signed[2]
+			},
+			wantErr: errInvalidIDToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockProvider(t)
+			provider := mock.provider(t)
+			mock.idToken = tt.token(t, mock)
+
+			code, verifier := testCode, testVerifier
+			if tt.code != "" {
+				code = tt.code
+			}
+			if tt.verifier != "" {
+				verifier = tt.verifier
+			}
+
+			claims, err := provider.Exchange(context.Background(), code, verifier)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Exchange() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange() error = %v", err)
+			}
+			if claims.Subject != "subject-1" || claims.Email != "alice@example.com" || !claims.EmailVerified || claims.Nonce != "nonce-1" {
+				t.Errorf("Exchange() claims = %+v", claims)
+			}
+		})
+	}
+}
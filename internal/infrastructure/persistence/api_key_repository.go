@@ -0,0 +1,86 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+func (a *APIKeyRepository) Create(ctx context.Context, key *account.APIKey) (err error) {
+	if err := assignTenant(ctx, &key.TenantID); err != nil {
+		return err
+	}
+
+	if err := connection(ctx, a.db).Create(key).Error; err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+func (a *APIKeyRepository) FindByID(ctx context.Context, id identity.ID) (result *account.APIKey, err error) {
+	if err := connection(ctx, a.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("api key with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query api key: %w", err)
+	}
+	return result, nil
+}
+
+// FindByHash mencari key di semua tenant karena tenant request belum diketahui
+// sebelum key terautentikasi. Pemanggil wajib memakai TenantID milik key.
+func (a *APIKeyRepository) FindByHash(ctx context.Context, hash string) (result *account.APIKey, err error) {
+	if err := connection(ctx, a.db).Where("key_hash = ?", hash).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("api key not found: %w", errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query api key: %w", err)
+	}
+	return result, nil
+}
+
+func (a *APIKeyRepository) FindByUser(ctx context.Context, userID identity.ID) (result []*account.APIKey, err error) {
+	if err := connection(ctx, a.db).Scopes(tenantScope(ctx)).Where("user_id = ?", userID).Order("created_at DESC").Find(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	return result, nil
+}
+
+func (a *APIKeyRepository) Revoke(ctx context.Context, id identity.ID, at time.Time) (err error) {
+	result := connection(ctx, a.db).Model(&account.APIKey{}).Scopes(tenantScope(ctx)).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("api key with ID '%s' not found: %w", id, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (a *APIKeyRepository) TouchLastUsed(ctx context.Context, id identity.ID, at time.Time) (err error) {
+	err = connection(ctx, a.db).Model(&account.APIKey{}).Scopes(tenantScope(ctx)).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package presistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (a *AuditRepository) Create(ctx context.Context, entry *audit.Entry) (err error) {
+	if err := assignTenant(ctx, &entry.TenantID); err != nil {
+		return err
+	}
+	if err := connection(ctx, a.db).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+func (a *AuditRepository) FindAll(ctx context.Context, filter *audit.Filter) (result []*audit.Entry, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "desc"
+	}
+	orderBy := fmt.Sprintf("created_at %s", filter.Sort)
+
+	query := connection(ctx, a.db).Model(&audit.Entry{}).Scopes(tenantScope(ctx))
+
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.AggregateType != "" {
+		query = query.Where("aggregate_type = ?", filter.AggregateType)
+	}
+	if filter.AggregateID != "" {
+		query = query.Where("aggregate_id = ?", filter.AggregateID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query audit entries: %w", err)
+	}
+
+	return result, totalItems, nil
+}
@@ -0,0 +1,87 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExternalIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewExternalIdentityRepository(db *gorm.DB) *ExternalIdentityRepository {
+	return &ExternalIdentityRepository{
+		db: db,
+	}
+}
+
+func (e *ExternalIdentityRepository) Create(ctx context.Context, external *account.ExternalIdentity) (err error) {
+	if err := connection(ctx, e.db).Create(external).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("identity '%s' of provider '%s' is already linked: %w", external.Subject, external.Provider, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to create external identity: %w", err)
+	}
+	return nil
+}
+
+func (e *ExternalIdentityRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (result *account.ExternalIdentity, err error) {
+	if err := connection(ctx, e.db).Where("provider = ? AND subject = ?", provider, subject).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("external identity not found: %w", errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query external identity: %w", err)
+	}
+	return result, nil
+}
+
+func (e *ExternalIdentityRepository) TouchLastLogin(ctx context.Context, id identity.ID, at time.Time) (err error) {
+	err = connection(ctx, e.db).Model(&account.ExternalIdentity{}).
+		Where("id = ?", id).
+		Update("last_login_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to update external identity last login: %w", err)
+	}
+	return nil
+}
+
+type OIDCStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCStateRepository(db *gorm.DB) *OIDCStateRepository {
+	return &OIDCStateRepository{
+		db: db,
+	}
+}
+
+func (o *OIDCStateRepository) Create(ctx context.Context, state *account.OIDCState) (err error) {
+	if err := connection(ctx, o.db).Create(state).Error; err != nil {
+		return fmt.Errorf("failed to create oidc state: %w", err)
+	}
+	return nil
+}
+
+func (o *OIDCStateRepository) Consume(ctx context.Context, stateHash string) (result *account.OIDCState, err error) {
+	var states []account.OIDCState
+	// DELETE ... RETURNING memastikan hanya satu callback yang mendapatkan state
+	err = connection(ctx, o.db).
+		Clauses(clause.Returning{}).
+		Where("state_hash = ?", stateHash).
+		Delete(&states).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume oidc state: %w", err)
+	}
+	if len(states) == 0 {
+		return nil, fmt.Errorf("oidc state not found: %w", errs.ErrNotFound)
+	}
+	return &states[0], nil
+}
@@ -0,0 +1,93 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+)
+
+// InvitationRepository hanya membaca invitation milik organization di tenant pada ctx
+type InvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) *InvitationRepository {
+	return &InvitationRepository{
+		db: db,
+	}
+}
+
+func (i *InvitationRepository) Create(ctx context.Context, invitation *organization.Invitation) (err error) {
+	if err := connection(ctx, i.db).Create(invitation).Error; err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	return nil
+}
+
+func (i *InvitationRepository) FindByID(ctx context.Context, id identity.ID) (result *organization.Invitation, err error) {
+	if err := connection(ctx, i.db).Scopes(organizationScope(ctx, i.db)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("invitation with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query invitation: %w", err)
+	}
+	return result, nil
+}
+
+func (i *InvitationRepository) FindByHash(ctx context.Context, hash string) (result *organization.Invitation, err error) {
+	if err := connection(ctx, i.db).Scopes(organizationScope(ctx, i.db)).Where("token_hash = ?", hash).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("invitation not found: %w", errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query invitation: %w", err)
+	}
+	return result, nil
+}
+
+func (i *InvitationRepository) FindAll(ctx context.Context, organizationID identity.ID, filter *organization.InvitationFilter) (result []*organization.Invitation, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "desc"
+	}
+	orderBy := fmt.Sprintf("created_at %s", filter.Sort)
+
+	query := connection(ctx, i.db).Model(&organization.Invitation{}).Scopes(organizationScope(ctx, i.db)).Where("organization_id = ?", organizationID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Search != "" {
+		searchPattern := fmt.Sprintf("%%%s%%", filter.Search)
+		query = query.Where("email ILIKE ?", searchPattern)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query invitations: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (i *InvitationRepository) Update(ctx context.Context, invitation *organization.Invitation) (err error) {
+	result := connection(ctx, i.db).Model(invitation).Scopes(organizationScope(ctx, i.db)).
+		Where("status = ?", organization.InvitationStatusPending).
+		Select("status", "accepted_by", "accepted_at", "updated_at").
+		Updates(invitation)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update invitation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("invitation '%s' is no longer pending: %w", invitation.ID, errs.ErrConflict)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db: db,
+	}
+}
+
+func (l *LoginAttemptRepository) Get(ctx context.Context, key string) (result *account.LoginAttempt, err error) {
+	if err := connection(ctx, l.db).Where("key = ?", key).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("login attempt '%s' not found: %w", key, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query login attempt: %w", err)
+	}
+	return result, nil
+}
+
+func (l *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (result *account.LoginAttempt, err error) {
+	attempt := account.LoginAttempt{Key: key, Failures: 1, LastFailedAt: now}
+
+	// Increment dilakukan di database agar request paralel tidak saling menimpa
+	err = connection(ctx, l.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failures":       gorm.Expr("CASE WHEN login_attempts.last_failed_at < ? THEN 1 ELSE login_attempts.failures + 1 END", now.Add(-resetAfter)),
+			"last_failed_at": now,
+		}),
+	}).Create(&attempt).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return l.Get(ctx, key)
+}
+
+func (l *LoginAttemptRepository) Release(ctx context.Context, key string) (err error) {
+	err = connection(ctx, l.db).Model(&account.LoginAttempt{}).
+		Where("key = ? AND failures > 0", key).
+		Update("failures", gorm.Expr("failures - 1")).Error
+	if err != nil {
+		return fmt.Errorf("failed to release login attempt: %w", err)
+	}
+	return nil
+}
+
+func (l *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) (err error) {
+	attempt := account.LoginAttempt{Key: key, LockedUntil: &until}
+
+	err = connection(ctx, l.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"locked_until": until}),
+	}).Create(&attempt).Error
+	if err != nil {
+		return fmt.Errorf("failed to lock login attempts: %w", err)
+	}
+	return nil
+}
+
+func (l *LoginAttemptRepository) Reset(ctx context.Context, key string) (err error) {
+	if err := connection(ctx, l.db).Where("key = ?", key).Delete(&account.LoginAttempt{}).Error; err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
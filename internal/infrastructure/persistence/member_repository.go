@@ -0,0 +1,209 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MemberRepository menyimpan keanggotaan organization dan team, dibatasi ke
+// organization milik tenant pada ctx
+type MemberRepository struct {
+	db *gorm.DB
+}
+
+func NewMemberRepository(db *gorm.DB) *MemberRepository {
+	return &MemberRepository{
+		db: db,
+	}
+}
+
+func (m *MemberRepository) Create(ctx context.Context, member *organization.Member) (err error) {
+	if err := connection(ctx, m.db).Create(member).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("user '%s' is already a member of the organization: %w", member.UserID, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+	return nil
+}
+
+func (m *MemberRepository) Find(ctx context.Context, organizationID, userID identity.ID) (result *organization.Member, err error) {
+	err = connection(ctx, m.db).Scopes(organizationScope(ctx, m.db)).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user '%s' is not a member of organization '%s': %w", userID, organizationID, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query organization member: %w", err)
+	}
+	return result, nil
+}
+
+func (m *MemberRepository) FindAll(ctx context.Context, organizationID identity.ID, filter *model.PaginationFilter) (result []*organization.Member, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "asc"
+	}
+	orderBy := fmt.Sprintf("joined_at %s", filter.Sort)
+
+	query := connection(ctx, m.db).Model(&organization.Member{}).Scopes(organizationScope(ctx, m.db)).Where("organization_id = ?", organizationID)
+	if query, err = m.searchUsers(ctx, query, filter.Search); err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count organization members: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query organization members: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (m *MemberRepository) Update(ctx context.Context, member *organization.Member) (err error) {
+	result := connection(ctx, m.db).Model(member).Scopes(organizationScope(ctx, m.db)).Select("role", "updated_at").Updates(member)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update organization member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user '%s' is not a member of organization '%s': %w", member.UserID, member.OrganizationID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (m *MemberRepository) Delete(ctx context.Context, organizationID, userID identity.ID) (err error) {
+	db := connection(ctx, m.db)
+
+	teams := db.Model(&organization.Team{}).Select("id").Where("organization_id = ?", organizationID)
+	if err := db.Scopes(teamScope(ctx, m.db)).Where("user_id = ? AND team_id IN (?)", userID, teams).Delete(&organization.TeamMember{}).Error; err != nil {
+		return fmt.Errorf("failed to delete team memberships: %w", err)
+	}
+
+	result := db.Scopes(organizationScope(ctx, m.db)).Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&organization.Member{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete organization member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user '%s' is not a member of organization '%s': %w", userID, organizationID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (m *MemberRepository) FindByUser(ctx context.Context, userID identity.ID) (result []*organization.Member, err error) {
+	if err := connection(ctx, m.db).Scopes(organizationScope(ctx, m.db)).Where("user_id = ?", userID).Find(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to query organization memberships: %w", err)
+	}
+	return result, nil
+}
+
+func (m *MemberRepository) LockByRole(ctx context.Context, organizationID identity.ID, role organization.MembershipRole) (userIDs []identity.ID, err error) {
+	// FOR UPDATE tidak bisa dipakai bersama COUNT, baris dikunci lalu dihitung pemanggil
+	err = connection(ctx, m.db).Model(&organization.Member{}).Scopes(organizationScope(ctx, m.db)).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("organization_id = ? AND role = ?", organizationID, role).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock organization members: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (m *MemberRepository) CreateTeamMember(ctx context.Context, member *organization.TeamMember) (err error) {
+	if err := connection(ctx, m.db).Create(member).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("user '%s' is already a member of the team: %w", member.UserID, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to add team member: %w", err)
+	}
+	return nil
+}
+
+func (m *MemberRepository) FindTeamMember(ctx context.Context, teamID, userID identity.ID) (result *organization.TeamMember, err error) {
+	err = connection(ctx, m.db).Scopes(teamScope(ctx, m.db)).
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user '%s' is not a member of team '%s': %w", userID, teamID, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query team member: %w", err)
+	}
+	return result, nil
+}
+
+func (m *MemberRepository) FindAllTeamMembers(ctx context.Context, teamID identity.ID, filter *model.PaginationFilter) (result []*organization.TeamMember, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "asc"
+	}
+	orderBy := fmt.Sprintf("joined_at %s", filter.Sort)
+
+	query := connection(ctx, m.db).Model(&organization.TeamMember{}).Scopes(teamScope(ctx, m.db)).Where("team_id = ?", teamID)
+	if query, err = m.searchUsers(ctx, query, filter.Search); err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count team members: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query team members: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (m *MemberRepository) UpdateTeamMember(ctx context.Context, member *organization.TeamMember) (err error) {
+	result := connection(ctx, m.db).Model(member).Scopes(teamScope(ctx, m.db)).Select("role", "updated_at").Updates(member)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update team member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user '%s' is not a member of team '%s': %w", member.UserID, member.TeamID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (m *MemberRepository) DeleteTeamMember(ctx context.Context, teamID, userID identity.ID) (err error) {
+	result := connection(ctx, m.db).Scopes(teamScope(ctx, m.db)).Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&organization.TeamMember{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete team member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user '%s' is not a member of team '%s': %w", userID, teamID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+// searchUsers mencocokkan kata kunci dengan nama, username, atau email anggota
+func (m *MemberRepository) searchUsers(ctx context.Context, query *gorm.DB, search string) (*gorm.DB, error) {
+	if search == "" {
+		return query, nil
+	}
+
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	searchPattern := fmt.Sprintf("%%%s%%", search)
+	users := connection(ctx, m.db).Model(&account.User{}).Select("id").
+		Where("tenant_id = ?", tenantID).
+		Where("name ILIKE ? OR username ILIKE ? OR email ILIKE ?", searchPattern, searchPattern, searchPattern)
+	return query.Where("user_id IN (?)", users), nil
+}
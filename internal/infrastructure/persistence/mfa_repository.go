@@ -0,0 +1,117 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MFARepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) *MFARepository {
+	return &MFARepository{
+		db: db,
+	}
+}
+
+func (m *MFARepository) FindByUserID(ctx context.Context, userID identity.ID) (result *account.UserMFA, err error) {
+	if err := connection(ctx, m.db).Scopes(tenantScope(ctx)).Where("user_id = ?", userID).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("mfa for user '%s' not found: %w", userID, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query mfa: %w", err)
+	}
+	return result, nil
+}
+
+func (m *MFARepository) Save(ctx context.Context, mfa *account.UserMFA) (err error) {
+	if err := assignTenant(ctx, &mfa.TenantID); err != nil {
+		return err
+	}
+
+	// baris milik tenant lain tidak ikut ditimpa saat conflict
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "tenant_id"}, Value: mfa.TenantID}}},
+		UpdateAll: true,
+	}
+	err = connection(ctx, m.db).
+		Clauses(onConflict).
+		Create(mfa).Error
+	if err != nil {
+		return fmt.Errorf("failed to save mfa: %w", err)
+	}
+	return nil
+}
+
+func (m *MFARepository) Delete(ctx context.Context, userID identity.ID) (err error) {
+	db := connection(ctx, m.db)
+
+	if err := db.Scopes(tenantScope(ctx)).Where("user_id = ?", userID).Delete(&account.RecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	result := db.Scopes(tenantScope(ctx)).Where("user_id = ?", userID).Delete(&account.UserMFA{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete mfa: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("mfa for user '%s' not found: %w", userID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (m *MFARepository) ConsumeStep(ctx context.Context, userID identity.ID, step int64) (err error) {
+	result := connection(ctx, m.db).Model(&account.UserMFA{}).Scopes(tenantScope(ctx)).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Updates(map[string]interface{}{"last_used_step": step, "updated_at": time.Now()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to consume totp step: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("totp step already used: %w", errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (m *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID identity.ID, codes []account.RecoveryCode) (err error) {
+	db := connection(ctx, m.db)
+
+	if err := db.Scopes(tenantScope(ctx)).Where("user_id = ?", userID).Delete(&account.RecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	for i := range codes {
+		if err := assignTenant(ctx, &codes[i].TenantID); err != nil {
+			return err
+		}
+	}
+	if err := db.Create(&codes).Error; err != nil {
+		return fmt.Errorf("failed to create recovery codes: %w", err)
+	}
+	return nil
+}
+
+func (m *MFARepository) ConsumeRecoveryCode(ctx context.Context, userID identity.ID, hash string) (err error) {
+	result := connection(ctx, m.db).Model(&account.RecoveryCode{}).Scopes(tenantScope(ctx)).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("failed to consume recovery code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("recovery code not found: %w", errs.ErrNotFound)
+	}
+	return nil
+}
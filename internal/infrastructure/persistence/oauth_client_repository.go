@@ -0,0 +1,80 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"gorm.io/gorm"
+)
+
+type OAuthClientRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepository(db *gorm.DB) *OAuthClientRepository {
+	return &OAuthClientRepository{
+		db: db,
+	}
+}
+
+func (o *OAuthClientRepository) Create(ctx context.Context, client *oauth.Client) (err error) {
+	if err := assignTenant(ctx, &client.TenantID); err != nil {
+		return err
+	}
+
+	if err := connection(ctx, o.db).Create(client).Error; err != nil {
+		return fmt.Errorf("failed to create oauth client: %w", err)
+	}
+	return nil
+}
+
+func (o *OAuthClientRepository) FindByID(ctx context.Context, id identity.ID) (result *oauth.Client, err error) {
+	if err := connection(ctx, o.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("oauth client with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query oauth client: %w", err)
+	}
+	return result, nil
+}
+
+func (o *OAuthClientRepository) FindAll(ctx context.Context, filter *model.PaginationFilter) (result []*oauth.Client, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "asc"
+	}
+	orderBy := fmt.Sprintf("created_at %s", filter.Sort)
+
+	query := connection(ctx, o.db).Model(&oauth.Client{}).Scopes(tenantScope(ctx))
+
+	if filter.Search != "" {
+		query = query.Where("name ILIKE ?", fmt.Sprintf("%%%s%%", filter.Search))
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count oauth clients: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query oauth clients: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (o *OAuthClientRepository) Delete(ctx context.Context, id identity.ID) (err error) {
+	result := connection(ctx, o.db).Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&oauth.Client{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete oauth client: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("oauth client with ID '%s' not found: %w", id, errs.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AuthorizationCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorizationCodeRepository(db *gorm.DB) *AuthorizationCodeRepository {
+	return &AuthorizationCodeRepository{
+		db: db,
+	}
+}
+
+func (a *AuthorizationCodeRepository) Create(ctx context.Context, code *oauth.AuthorizationCode) (err error) {
+	if err := assignTenant(ctx, &code.TenantID); err != nil {
+		return err
+	}
+
+	if err := connection(ctx, a.db).Create(code).Error; err != nil {
+		return fmt.Errorf("failed to create authorization code: %w", err)
+	}
+	return nil
+}
+
+func (a *AuthorizationCodeRepository) Consume(ctx context.Context, codeHash string) (result *oauth.AuthorizationCode, err error) {
+	var codes []oauth.AuthorizationCode
+	// DELETE ... RETURNING memastikan code hanya bisa ditukar satu kali
+	err = connection(ctx, a.db).
+		Scopes(tenantScope(ctx)).
+		Clauses(clause.Returning{}).
+		Where("code_hash = ?", codeHash).
+		Delete(&codes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("authorization code not found: %w", errs.ErrNotFound)
+	}
+	return &codes[0], nil
+}
+
+type ConsentRepository struct {
+	db *gorm.DB
+}
+
+func NewConsentRepository(db *gorm.DB) *ConsentRepository {
+	return &ConsentRepository{
+		db: db,
+	}
+}
+
+func (c *ConsentRepository) Find(ctx context.Context, userID identity.ID, clientID identity.ID) (result *oauth.Consent, err error) {
+	if err := connection(ctx, c.db).Scopes(tenantScope(ctx)).Where("user_id = ? AND client_id = ?", userID, clientID).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("oauth consent not found: %w", errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query oauth consent: %w", err)
+	}
+	return result, nil
+}
+
+func (c *ConsentRepository) Save(ctx context.Context, consent *oauth.Consent) (err error) {
+	if err := assignTenant(ctx, &consent.TenantID); err != nil {
+		return err
+	}
+
+	err = connection(ctx, c.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "tenant_id"}, Value: consent.TenantID}}},
+			DoUpdates: clause.AssignmentColumns([]string{"scopes", "updated_at"}),
+		}).
+		Create(consent).Error
+	if err != nil {
+		return fmt.Errorf("failed to save oauth consent: %w", err)
+	}
+	return nil
+}
+
+type OAuthTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthTokenRepository(db *gorm.DB) *OAuthTokenRepository {
+	return &OAuthTokenRepository{
+		db: db,
+	}
+}
+
+func (o *OAuthTokenRepository) Create(ctx context.Context, token *oauth.Token) (err error) {
+	if err := assignTenant(ctx, &token.TenantID); err != nil {
+		return err
+	}
+
+	if err := connection(ctx, o.db).Create(token).Error; err != nil {
+		return fmt.Errorf("failed to create oauth token: %w", err)
+	}
+	return nil
+}
+
+func (o *OAuthTokenRepository) FindByID(ctx context.Context, id identity.ID) (result *oauth.Token, err error) {
+	if err := connection(ctx, o.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("oauth token with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query oauth token: %w", err)
+	}
+	return result, nil
+}
+
+func (o *OAuthTokenRepository) Revoke(ctx context.Context, id identity.ID, at time.Time) (err error) {
+	err = connection(ctx, o.db).Model(&oauth.Token{}).Scopes(tenantScope(ctx)).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke oauth token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository membatasi semua query ke tenant pada ctx
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+func (o *OrganizationRepository) Create(ctx context.Context, org *organization.Organization) (err error) {
+	if err := assignTenant(ctx, &org.TenantID); err != nil {
+		return err
+	}
+
+	if err := connection(ctx, o.db).Create(org).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("organization with name '%s' already exists: %w", org.Name, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	return nil
+}
+
+func (o *OrganizationRepository) FindByID(ctx context.Context, id identity.ID) (result *organization.Organization, err error) {
+	if err := connection(ctx, o.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("organization with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query organization: %w", err)
+	}
+	return result, nil
+}
+
+func (o *OrganizationRepository) FindAll(ctx context.Context, filter *organization.OrganizationFilter) (result []*organization.Organization, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "asc"
+	}
+	orderBy := fmt.Sprintf("name %s", filter.Sort)
+
+	query := connection(ctx, o.db).Model(&organization.Organization{}).Scopes(tenantScope(ctx))
+
+	if !filter.MemberID.IsNil() {
+		members := connection(ctx, o.db).Model(&organization.Member{}).Select("organization_id").Where("user_id = ?", filter.MemberID)
+		query = query.Where("id IN (?)", members)
+	}
+	if filter.Search != "" {
+		searchPattern := fmt.Sprintf("%%%s%%", filter.Search)
+		query = query.Where("name ILIKE ?", searchPattern)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count organizations: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query organizations: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (o *OrganizationRepository) Update(ctx context.Context, org *organization.Organization) (err error) {
+	currentVersion := org.Version
+	org.Version = currentVersion + 1
+
+	result := connection(ctx, o.db).Model(org).Scopes(tenantScope(ctx)).Where("version = ?", currentVersion).Select("*").Omit("tenant_id").Updates(org)
+	if result.Error != nil {
+		org.Version = currentVersion
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("organization with name '%s' already exists: %w", org.Name, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to update organization: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		org.Version = currentVersion
+		return o.versionConflict(ctx, org.ID, currentVersion)
+	}
+	return nil
+}
+
+func (o *OrganizationRepository) versionConflict(ctx context.Context, id identity.ID, expected int64) error {
+	var current organization.Organization
+	if err := connection(ctx, o.db).Scopes(tenantScope(ctx)).Select("version").Where("id = ?", id).First(&current).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("organization with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return fmt.Errorf("failed to query organization: %w", err)
+	}
+	return errs.VersionConflictError{Resource: "organization", ID: id, Expected: expected, Actual: current.Version}
+}
+
+func (o *OrganizationRepository) Delete(ctx context.Context, id identity.ID) (err error) {
+	db := connection(ctx, o.db)
+
+	teams := db.Model(&organization.Team{}).Select("id").Where("organization_id = ?", id)
+	if err := db.Scopes(teamScope(ctx, o.db)).Where("team_id IN (?)", teams).Delete(&organization.TeamMember{}).Error; err != nil {
+		return fmt.Errorf("failed to delete team members: %w", err)
+	}
+	for _, model := range []any{&organization.Team{}, &organization.Member{}, &organization.Invitation{}} {
+		if err := db.Scopes(organizationScope(ctx, o.db)).Where("organization_id = ?", id).Delete(model).Error; err != nil {
+			return fmt.Errorf("failed to delete organization data: %w", err)
+		}
+	}
+
+	result := db.Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&organization.Organization{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete organization: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("organization with ID '%s' not found: %w", id, errs.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package presistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+func (o *OutboxRepository) Create(ctx context.Context, messages []*outbox.Message) (err error) {
+	if err := connection(ctx, o.db).Create(messages).Error; err != nil {
+		return fmt.Errorf("failed to create outbox messages: %w", err)
+	}
+	return nil
+}
+
+func (o *OutboxRepository) Claim(ctx context.Context, limit int, now time.Time, leaseUntil time.Time) (result []*outbox.Message, err error) {
+	db := connection(ctx, o.db)
+
+	err = db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", outbox.StatusPending, now).
+		Order("created_at asc").
+		Limit(limit).
+		Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox messages: %w", err)
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	ids := make([]identity.ID, 0, len(result))
+	for _, message := range result {
+		message.Claim(leaseUntil)
+		ids = append(ids, message.ID)
+	}
+	if err := db.Model(&outbox.Message{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	return result, nil
+}
+
+func (o *OutboxRepository) Update(ctx context.Context, message *outbox.Message, leaseUntil time.Time) (err error) {
+	result := connection(ctx, o.db).Model(message).
+		Where("status = ? AND next_attempt_at = ?", outbox.StatusPending, leaseUntil).
+		Select("status", "attempts", "last_error", "next_attempt_at", "published_at").
+		Updates(message)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update outbox message: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("lease on outbox message '%s' expired: %w", message.ID, errs.ErrConflict)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/outbox"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+func TestOutboxUpdateRequiresLease(t *testing.T) {
+	db, statements := dryRun(t)
+	leaseUntil := time.Now().Add(time.Minute).Truncate(time.Microsecond)
+
+	message := &outbox.Message{ID: identity.New(), Status: outbox.StatusPublished}
+	// DryRun tidak mengubah baris, sehingga terbaca sebagai sewa yang hilang
+	if err := NewOutboxRepository(db).Update(context.Background(), message, leaseUntil); !errors.Is(err, errs.ErrConflict) {
+		t.Fatalf("Update() error = %v, want ErrConflict", err)
+	}
+
+	if len(*statements) != 1 {
+		t.Fatalf("%d statements built, want 1", len(*statements))
+	}
+	stmt := (*statements)[0]
+	_, where, _ := strings.Cut(stmt.sql, "WHERE")
+	if !strings.Contains(where, "next_attempt_at = ?") {
+		t.Fatalf("%s does not compare the lease", stmt.sql)
+	}
+
+	found := false
+	for _, v := range stmt.vars {
+		if at, ok := v.(time.Time); ok && at.Equal(leaseUntil) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("%s %v is not bound to lease %s", stmt.sql, stmt.vars, leaseUntil)
+	}
+}
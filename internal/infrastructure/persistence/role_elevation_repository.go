@@ -0,0 +1,98 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleElevationRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleElevationRepository(db *gorm.DB) *RoleElevationRepository {
+	return &RoleElevationRepository{
+		db: db,
+	}
+}
+
+func (r *RoleElevationRepository) Create(ctx context.Context, elevation *account.RoleElevation) (err error) {
+	if err := assignTenant(ctx, &elevation.TenantID); err != nil {
+		return err
+	}
+	if err := connection(ctx, r.db).Create(elevation).Error; err != nil {
+		return fmt.Errorf("failed to create role elevation: %w", err)
+	}
+	return nil
+}
+
+func (r *RoleElevationRepository) FindByID(ctx context.Context, id identity.ID) (result *account.RoleElevation, err error) {
+	if err := connection(ctx, r.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("role elevation with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query role elevation: %w", err)
+	}
+	return result, nil
+}
+
+func (r *RoleElevationRepository) FindAll(ctx context.Context, filter *account.RoleElevationFilter) (result []*account.RoleElevation, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "desc"
+	}
+	orderBy := fmt.Sprintf("created_at %s", filter.Sort)
+
+	query := connection(ctx, r.db).Model(&account.RoleElevation{}).Scopes(tenantScope(ctx))
+
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count role elevations: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query role elevations: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (r *RoleElevationRepository) Update(ctx context.Context, elevation *account.RoleElevation) (err error) {
+	result := connection(ctx, r.db).Model(elevation).Scopes(tenantScope(ctx)).Select("*").Updates(elevation)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update role elevation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("role elevation with ID '%s' not found: %w", elevation.ID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+// FindExpired dipakai sweeper untuk semua tenant, tenant tiap elevation
+// diteruskan lewat ctx saat Update
+func (r *RoleElevationRepository) FindExpired(ctx context.Context, now time.Time, limit int) (result []*account.RoleElevation, err error) {
+	err = connection(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND expires_at <= ?", account.ElevationActive, now).
+		Order("expires_at asc").
+		Limit(limit).
+		Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired role elevations: %w", err)
+	}
+	return result, nil
+}
@@ -4,48 +4,165 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
-	"go.mongodb.org/mongo-driver/v2/bson"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// RolePermission adalah baris tabel role_permissions untuk penyimpanan permission yang dinormalisasi
+type RolePermission struct {
+	RoleID     identity.ID `gorm:"column:role_id;type:uuid;primaryKey"`
+	Permission string      `gorm:"column:permission;primaryKey"`
+}
+
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+// RoleRepository membatasi semua query ke tenant pada ctx, termasuk assignment
+// dan hierarki role
 type RoleRepository struct {
-	db *gorm.DB
+	db         *gorm.DB
+	normalized bool
 }
 
+// NewRoleRepository menyimpan permission sebagai JSON di kolom roles.permissions
+// (jsonb di Postgres, teks JSON di SQLite)
 func NewRoleRepository(db *gorm.DB) *RoleRepository {
 	return &RoleRepository{
 		db: db,
 	}
 }
 
+// NewNormalizedRoleRepository menyimpan permission di tabel role_permissions,
+// sehingga kolom roles.permissions tidak dipakai
+func NewNormalizedRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{
+		db:         db,
+		normalized: true,
+	}
+}
+
 func (r *RoleRepository) Create(ctx context.Context, role *account.Role) (err error) {
-	panic("not implemented") // TODO: Implement
+	if err := assignTenant(ctx, &role.TenantID); err != nil {
+		return err
+	}
+
+	result := r.write(ctx).Create(role)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("role with name '%s' already exists: %w", role.Name, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to create role: %w", result.Error)
+	}
+	return r.savePermissions(ctx, role)
 }
 
 func (r *RoleRepository) FindById(ctx context.Context, id string) (result *account.Role, err error) {
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error; err != nil {
+	if err := connection(ctx, r.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("role with ID '%s' not found: %w", id, errs.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to query role: %w", err)
 	}
+	if err := r.loadPermissions(ctx, result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
-func (r *RoleRepository) FindManyByID(ctx context.Context, ids []bson.ObjectID) (result *[]account.Role, err error) {
-	if err := r.db.WithContext(ctx).Where("id IN (?)", ids).First(&result).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("role not found: %w", errs.ErrNotFound)
+// FindChain mengembalikan role beserta leluhurnya, urut dari role itu sendiri ke root.
+// Parent di tenant lain tidak ikut ditelusuri.
+func (r *RoleRepository) FindChain(ctx context.Context, id string) (result []*account.Role, err error) {
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT roles.*, 0 AS depth FROM roles WHERE roles.id = ? AND roles.tenant_id = ?
+			UNION ALL
+			SELECT parent.*, chain.depth + 1 FROM roles parent
+			JOIN chain ON parent.id = chain.parent_id
+			WHERE chain.depth < ? AND parent.tenant_id = ?
+		)
+		SELECT * FROM chain ORDER BY depth`
+
+	var rows []*account.Role
+	if err := connection(ctx, r.db).Raw(query, id, tenantID, account.MaxRoleDepth, tenantID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query role hierarchy: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("role with ID '%s' not found: %w", id, errs.ErrNotFound)
+	}
+
+	// rantai yang membentuk siklus berhenti di batas depth, buang duplikatnya
+	seen := make(map[identity.ID]struct{}, len(rows))
+	result = make([]*account.Role, 0, len(rows))
+	for _, role := range rows {
+		if _, ok := seen[role.ID]; ok {
+			break
 		}
-		return nil, fmt.Errorf("failed to query role: %w", err)
+		seen[role.ID] = struct{}{}
+		result = append(result, role)
+	}
+	if err := r.loadPermissions(ctx, result...); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
+func (r *RoleRepository) CountChildren(ctx context.Context, id string) (total int64, err error) {
+	if err := connection(ctx, r.db).Model(&account.Role{}).Scopes(tenantScope(ctx)).Where("parent_id = ?", id).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count child roles: %w", err)
+	}
+	return total, nil
+}
+
+func (r *RoleRepository) DescendantDepth(ctx context.Context, id string) (depth int, err error) {
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// batas depth juga menghentikan penelusuran bila data sudah membentuk siklus
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT roles.id, 1 AS depth FROM roles WHERE roles.parent_id = ? AND roles.tenant_id = ?
+			UNION ALL
+			SELECT child.id, subtree.depth + 1 FROM roles child
+			JOIN subtree ON child.parent_id = subtree.id
+			WHERE subtree.depth < ? AND child.tenant_id = ?
+		)
+		SELECT COALESCE(MAX(depth), 0) FROM subtree`
+
+	if err := connection(ctx, r.db).Raw(query, id, tenantID, account.MaxRoleDepth, tenantID).Scan(&depth).Error; err != nil {
+		return 0, fmt.Errorf("failed to query role descendants: %w", err)
+	}
+	return depth, nil
+}
+
+func (r *RoleRepository) FindManyByID(ctx context.Context, ids []identity.ID) (result *[]account.Role, err error) {
+	roles := make([]account.Role, 0, len(ids))
+	if len(ids) == 0 {
+		return &roles, nil
+	}
+
+	if err := connection(ctx, r.db).Scopes(tenantScope(ctx)).Where("id IN (?)", ids).Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to query roles: %w", err)
+	}
+	if err := r.loadPermissions(ctx, rolePointers(roles)...); err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
+
 func (r *RoleRepository) FindAll(ctx context.Context, filter *model.PaginationFilter) (result *[]account.Role, totalItems int64, err error) {
 	offset := (filter.Page - 1) * filter.Limit
 
@@ -55,7 +172,7 @@ func (r *RoleRepository) FindAll(ctx context.Context, filter *model.PaginationFi
 	}
 	orderBy := fmt.Sprintf("%s %s", sortField, filter.Sort)
 
-	query := r.db.WithContext(ctx).Model(&result).Order(orderBy).Limit(filter.Limit).Offset(offset)
+	query := connection(ctx, r.db).Model(&result).Scopes(tenantScope(ctx)).Order(orderBy).Limit(filter.Limit).Offset(offset)
 
 	if filter.Search != "" {
 		searchPattern := fmt.Sprintf("%%%s%%", filter.Search)
@@ -70,25 +187,60 @@ func (r *RoleRepository) FindAll(ctx context.Context, filter *model.PaginationFi
 		return nil, 0, err
 	}
 
+	if err := r.loadPermissions(ctx, rolePointers(*result)...); err != nil {
+		return nil, 0, err
+	}
+
 	return result, totalItems, nil
 }
 
 func (r *RoleRepository) Update(ctx context.Context, id string, role *account.Role) (err error) {
-	result := r.db.WithContext(ctx).Save(role)
+	currentVersion := role.Version
+	role.Version = currentVersion + 1
+
+	result := r.write(ctx, "tenant_id").Model(role).Scopes(tenantScope(ctx)).Where("id = ? AND version = ?", id, currentVersion).Select("*").Updates(role)
 	if result.Error != nil {
+		role.Version = currentVersion
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return fmt.Errorf("role with ID '%s' not found: %w", role.ID, errs.ErrNotFound)
+			return fmt.Errorf("role with ID '%s' not found: %w", id, errs.ErrNotFound)
 		}
 		return fmt.Errorf("failed to update role: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("role with ID '%s' not found: %w", role.ID, errs.ErrNotFound)
+		role.Version = currentVersion
+		return r.versionConflict(ctx, id, currentVersion)
 	}
-	return nil
+	return r.savePermissions(ctx, role)
+}
+
+func (r *RoleRepository) versionConflict(ctx context.Context, id string, expected int64) error {
+	var current account.Role
+	if err := connection(ctx, r.db).Scopes(tenantScope(ctx)).Select("version").Where("id = ?", id).First(&current).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("role with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return fmt.Errorf("failed to query role: %w", err)
+	}
+	return errs.VersionConflictError{Resource: "role", ID: id, Expected: expected, Actual: current.Version}
 }
 
 func (r *RoleRepository) Delete(ctx context.Context, id string) (err error) {
-	result := r.db.WithContext(ctx).Where("id", id).Delete(&account.Role{})
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return err
+	}
+	db := connection(ctx, r.db)
+
+	if err := db.Where("role_id = ? AND role_id IN (?)", id, tenantRoles(ctx, r.db, tenantID)).Delete(&account.UserRole{}).Error; err != nil {
+		return fmt.Errorf("failed to delete role assignments: %w", err)
+	}
+	if r.normalized {
+		if err := db.Where("role_id = ? AND role_id IN (?)", id, tenantRoles(ctx, r.db, tenantID)).Delete(&RolePermission{}).Error; err != nil {
+			return fmt.Errorf("failed to delete role permissions: %w", err)
+		}
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&account.Role{})
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("role with ID '%s' not found: %w", id, errs.ErrNotFound)
@@ -101,10 +253,179 @@ func (r *RoleRepository) Delete(ctx context.Context, id string) (err error) {
 	return nil
 }
 
-func (r *RoleRepository) AssignUser(ctx context.Context, userId string, roleId string) (err error) {
-	panic("not implemented") // TODO: Implement
+// AssignUser menyimpan assignment; assignment yang sudah ada diperbarui metadata dan masa berlakunya.
+// User dan role harus berada di tenant pada ctx.
+func (r *RoleRepository) AssignUser(ctx context.Context, assignment *account.UserRole) (err error) {
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	var matched int64
+	err = connection(ctx, r.db).Model(&account.Role{}).
+		Where("id = ? AND tenant_id = ?", assignment.RoleID, tenantID).
+		Where("EXISTS (?)", connection(ctx, r.db).Model(&account.User{}).Select("1").Where("id = ? AND tenant_id = ?", assignment.UserID, tenantID)).
+		Count(&matched).Error
+	if err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	if matched == 0 {
+		return fmt.Errorf("user '%s' or role '%s' not found: %w", assignment.UserID, assignment.RoleID, errs.ErrNotFound)
+	}
+
+	err = connection(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "role_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"assigned_by", "assigned_at", "expires_at"}),
+		}).
+		Create(assignment).Error
+	if err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	return nil
 }
 
+// UnassignUser mencabut assignment dan juga role utama user bila sama
 func (r *RoleRepository) UnassignUser(ctx context.Context, userId string, roleId string) (err error) {
-	panic("not implemented") // TODO: Implement
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return err
+	}
+	db := connection(ctx, r.db)
+
+	assignments := db.Where("user_id = ? AND role_id = ? AND role_id IN (?)", userId, roleId, tenantRoles(ctx, r.db, tenantID)).Delete(&account.UserRole{})
+	if assignments.Error != nil {
+		return fmt.Errorf("failed to unassign role: %w", assignments.Error)
+	}
+
+	primary := db.Model(&account.User{}).Where("id = ? AND role_id = ? AND tenant_id = ?", userId, roleId, tenantID).Update("role_id", nil)
+	if primary.Error != nil {
+		return fmt.Errorf("failed to unassign role: %w", primary.Error)
+	}
+
+	if assignments.RowsAffected == 0 && primary.RowsAffected == 0 {
+		return fmt.Errorf("user with ID '%s' does not have role '%s': %w", userId, roleId, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *RoleRepository) FindUserRoles(ctx context.Context, userId string, now time.Time) (result []*account.UserRole, err error) {
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = connection(ctx, r.db).
+		Where("user_id = ? AND (expires_at IS NULL OR expires_at > ?)", userId, now).
+		Where("role_id IN (?)", tenantRoles(ctx, r.db, tenantID)).
+		Order("assigned_at asc").
+		Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query role assignments: %w", err)
+	}
+	return result, nil
+}
+
+// FindExpiredAssignments dipakai sweeper yang berjalan sebagai proses sistem
+// untuk semua tenant; hanya membaca baris user_roles, tidak data user atau role.
+// FindExpiredAssignments dipakai sweeper untuk semua tenant. Tenant diambil
+// dari user agar audit tiap assignment tercatat di tenant yang benar.
+func (r *RoleRepository) FindExpiredAssignments(ctx context.Context, now time.Time, limit int) (result []*account.UserRole, err error) {
+	err = connection(ctx, r.db).
+		Select("user_roles.*, users.tenant_id").
+		Joins("JOIN users ON users.id = user_roles.user_id").
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "user_roles"}, Options: "SKIP LOCKED"}).
+		Where("user_roles.expires_at IS NOT NULL AND user_roles.expires_at <= ?", now).
+		Order("user_roles.expires_at asc").
+		Limit(limit).
+		Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired role assignments: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteExpiredAssignment hanya menghapus bila assignment masih kedaluwarsa,
+// sehingga assignment yang baru diperpanjang tidak ikut terhapus
+func (r *RoleRepository) DeleteExpiredAssignment(ctx context.Context, assignment *account.UserRole, now time.Time) (err error) {
+	result := connection(ctx, r.db).
+		Where("user_id = ? AND role_id = ? AND expires_at IS NOT NULL AND expires_at <= ?", assignment.UserID, assignment.RoleID, now).
+		Delete(&account.UserRole{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete expired role assignment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("expired assignment of role '%s' for user '%s' not found: %w", assignment.RoleID, assignment.UserID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+// write mengabaikan kolom omit dan juga kolom permissions bila permission
+// disimpan di tabel terpisah. Omit gorm menimpa pemanggilan sebelumnya,
+// sehingga semua kolom digabung di sini.
+func (r *RoleRepository) write(ctx context.Context, omit ...string) *gorm.DB {
+	db := connection(ctx, r.db)
+	if r.normalized {
+		omit = append(omit, "permissions")
+	}
+	if len(omit) > 0 {
+		return db.Omit(omit...)
+	}
+	return db
+}
+
+func (r *RoleRepository) savePermissions(ctx context.Context, role *account.Role) error {
+	if !r.normalized {
+		return nil
+	}
+
+	db := connection(ctx, r.db)
+	if err := db.Where("role_id = ?", role.ID).Delete(&RolePermission{}).Error; err != nil {
+		return fmt.Errorf("failed to replace role permissions: %w", err)
+	}
+	if len(role.Permissions) == 0 {
+		return nil
+	}
+
+	rows := make([]RolePermission, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		rows = append(rows, RolePermission{RoleID: role.ID, Permission: permission})
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to save role permissions: %w", err)
+	}
+	return nil
+}
+
+func (r *RoleRepository) loadPermissions(ctx context.Context, roles ...*account.Role) error {
+	if !r.normalized || len(roles) == 0 {
+		return nil
+	}
+
+	byID := make(map[identity.ID]*account.Role, len(roles))
+	ids := make([]identity.ID, 0, len(roles))
+	for _, role := range roles {
+		role.Permissions = []string{}
+		byID[role.ID] = role
+		ids = append(ids, role.ID)
+	}
+
+	var rows []RolePermission
+	if err := connection(ctx, r.db).Where("role_id IN (?)", ids).Order("permission asc").Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to query role permissions: %w", err)
+	}
+	for _, row := range rows {
+		if role, ok := byID[row.RoleID]; ok {
+			role.Permissions = append(role.Permissions, row.Permission)
+		}
+	}
+	return nil
+}
+
+func rolePointers(roles []account.Role) []*account.Role {
+	result := make([]*account.Role, 0, len(roles))
+	for i := range roles {
+		result = append(result, &roles[i])
+	}
+	return result
 }
@@ -0,0 +1,117 @@
+package presistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"gorm.io/gorm/schema"
+)
+
+// storedPermissions mengembalikan nilai kolom permissions pada INSERT yang dikirim ke database
+func storedPermissions(t *testing.T, stmt statement) interface{} {
+	t.Helper()
+
+	columns := strings.Split(stmt.sql[strings.Index(stmt.sql, "(")+1:strings.Index(stmt.sql, ")")], ",")
+	index := slices.Index(columns, "`permissions`")
+	if index < 0 {
+		t.Fatalf("%s does not write the permissions column", stmt.sql)
+	}
+
+	value := stmt.vars[index]
+	if valuer, ok := value.(driver.Valuer); ok {
+		var err error
+		if value, err = valuer.Value(); err != nil {
+			t.Fatalf("Value() error = %v", err)
+		}
+	}
+	return value
+}
+
+func TestRoleRepositoryPermissionsJSONRoundTrip(t *testing.T) {
+	roleSchema, err := schema.Parse(&account.Role{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("schema.Parse() error = %v", err)
+	}
+	field := roleSchema.LookUpField("permissions")
+	if field.DataType != "jsonb" || field.Serializer == nil {
+		t.Fatalf("permissions column = %s with serializer %v, want jsonb with a JSON serializer", field.DataType, field.Serializer)
+	}
+
+	tests := []struct {
+		name        string
+		permissions []string
+		wantStored  interface{}
+	}{
+		{name: "several permissions", permissions: []string{account.PermissionUsersRead, account.PermissionRolesWrite}, wantStored: `["users:read","roles:write"]`},
+		{name: "empty list", permissions: []string{}, wantStored: `[]`},
+		{name: "nil list", wantStored: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := dryRun(t)
+			ctx := requestctx.WithTenantID(context.Background(), identity.New())
+			role := &account.Role{ID: identity.New(), Name: "editor", Permissions: tt.permissions, Version: 1}
+
+			if err := NewRoleRepository(db).Create(ctx, role); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if len(*statements) != 1 {
+				t.Fatalf("statements = %v, want one insert", *statements)
+			}
+			stored := storedPermissions(t, (*statements)[0])
+			if stored != tt.wantStored {
+				t.Fatalf("stored permissions = %v, want %v", stored, tt.wantStored)
+			}
+
+			// baca kembali nilai kolom seperti saat gorm memindai baris
+			var loaded account.Role
+			if err := field.Serializer.Scan(ctx, field, reflect.ValueOf(&loaded).Elem(), stored); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if !slices.Equal(loaded.Permissions, tt.permissions) {
+				t.Errorf("loaded permissions = %v, want %v", loaded.Permissions, tt.permissions)
+			}
+		})
+	}
+}
+
+func TestNormalizedRoleRepositoryWritesPermissionRows(t *testing.T) {
+	db, statements := dryRun(t)
+	ctx := requestctx.WithTenantID(context.Background(), identity.New())
+	role := &account.Role{ID: identity.New(), Name: "editor", Permissions: []string{account.PermissionUsersRead, account.PermissionRolesWrite}, Version: 1}
+
+	if err := NewNormalizedRoleRepository(db).Create(ctx, role); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	var roleInsert, permissionInsert *statement
+	for i, stmt := range *statements {
+		switch {
+		case strings.Contains(stmt.sql, "`roles`"):
+			roleInsert = &(*statements)[i]
+		case strings.Contains(stmt.sql, "`role_permissions`"):
+			permissionInsert = &(*statements)[i]
+		}
+	}
+	if roleInsert == nil || permissionInsert == nil {
+		t.Fatalf("statements = %v, want inserts into roles and role_permissions", *statements)
+	}
+	// kolom permissions tidak dipakai pada mode normalized
+	if strings.Contains(roleInsert.sql, "permissions") {
+		t.Errorf("%s writes the permissions column", roleInsert.sql)
+	}
+	for _, permission := range role.Permissions {
+		if !slices.Contains(permissionInsert.vars, interface{}(permission)) {
+			t.Errorf("%s %v does not insert %s", permissionInsert.sql, permissionInsert.vars, permission)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+func (s *SessionRepository) Create(ctx context.Context, session *account.Session) (err error) {
+	if err := assignTenant(ctx, &session.TenantID); err != nil {
+		return err
+	}
+
+	if err := connection(ctx, s.db).Create(session).Error; err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (s *SessionRepository) FindByID(ctx context.Context, id identity.ID) (result *account.Session, err error) {
+	if err := connection(ctx, s.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("session with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query session: %w", err)
+	}
+	return result, nil
+}
+
+func (s *SessionRepository) FindActiveByUser(ctx context.Context, userID identity.ID, now time.Time) (result []*account.Session, err error) {
+	err = connection(ctx, s.db).Scopes(tenantScope(ctx)).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	return result, nil
+}
+
+func (s *SessionRepository) Revoke(ctx context.Context, id identity.ID, at time.Time) (err error) {
+	result := connection(ctx, s.db).Model(&account.Session{}).Scopes(tenantScope(ctx)).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke session: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("session with ID '%s' not found: %w", id, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *SessionRepository) RevokeAllForUser(ctx context.Context, userID identity.ID, at time.Time) (revoked int64, err error) {
+	result := connection(ctx, s.db).Model(&account.Session{}).Scopes(tenantScope(ctx)).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, at).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (s *SessionRepository) TouchLastSeen(ctx context.Context, id identity.ID, at time.Time) (err error) {
+	err = connection(ctx, s.db).Model(&account.Session{}).Scopes(tenantScope(ctx)).
+		Where("id = ?", id).
+		Update("last_seen_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to update session last seen: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"gorm.io/gorm"
+)
+
+// TeamRepository hanya membaca team milik organization di tenant pada ctx
+type TeamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository(db *gorm.DB) *TeamRepository {
+	return &TeamRepository{
+		db: db,
+	}
+}
+
+func (t *TeamRepository) Create(ctx context.Context, team *organization.Team) (err error) {
+	if err := connection(ctx, t.db).Create(team).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("team with name '%s' already exists: %w", team.Name, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return nil
+}
+
+func (t *TeamRepository) FindByID(ctx context.Context, id identity.ID) (result *organization.Team, err error) {
+	if err := connection(ctx, t.db).Scopes(organizationScope(ctx, t.db)).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("team with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query team: %w", err)
+	}
+	return result, nil
+}
+
+func (t *TeamRepository) FindAll(ctx context.Context, organizationID identity.ID, filter *model.PaginationFilter) (result []*organization.Team, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "asc"
+	}
+	orderBy := fmt.Sprintf("name %s", filter.Sort)
+
+	query := connection(ctx, t.db).Model(&organization.Team{}).Scopes(organizationScope(ctx, t.db)).Where("organization_id = ?", organizationID)
+
+	if filter.Search != "" {
+		searchPattern := fmt.Sprintf("%%%s%%", filter.Search)
+		query = query.Where("name ILIKE ?", searchPattern)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query teams: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (t *TeamRepository) Update(ctx context.Context, team *organization.Team) (err error) {
+	result := connection(ctx, t.db).Model(team).Scopes(organizationScope(ctx, t.db)).Select("*").Omit("organization_id").Updates(team)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("team with name '%s' already exists: %w", team.Name, errs.ErrConflict)
+		}
+		return fmt.Errorf("failed to update team: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("team with ID '%s' not found: %w", team.ID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (t *TeamRepository) Delete(ctx context.Context, id identity.ID) (err error) {
+	db := connection(ctx, t.db)
+
+	if err := db.Scopes(teamScope(ctx, t.db)).Where("team_id = ?", id).Delete(&organization.TeamMember{}).Error; err != nil {
+		return fmt.Errorf("failed to delete team members: %w", err)
+	}
+
+	result := db.Scopes(organizationScope(ctx, t.db)).Where("id = ?", id).Delete(&organization.Team{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete team: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("team with ID '%s' not found: %w", id, errs.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package presistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errTenantRequired = fmt.Errorf("tenant is required: %w", errs.ErrBadRequest)
+
+// tenantOf mengembalikan tenant pada ctx. Tidak ada fallback lintas tenant,
+// query tanpa tenant selalu ditolak.
+func tenantOf(ctx context.Context) (identity.ID, error) {
+	tenantID, ok := requestctx.TenantID(ctx)
+	if !ok {
+		return identity.ID{}, errTenantRequired
+	}
+	return tenantID, nil
+}
+
+// tenantScope membatasi query ke baris milik tenant pada ctx. Dipakai lewat
+// db.Scopes agar error tenant tidak menandai transaksi yang sedang berjalan.
+func tenantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenantID, err := tenantOf(ctx)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "tenant_id"}, Value: tenantID})
+	}
+}
+
+// assignTenant mengisi tenant entity baru dari ctx dan menolak entity milik tenant lain
+func assignTenant(ctx context.Context, current *identity.ID) error {
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return err
+	}
+	if !current.IsNil() && *current != tenantID {
+		return fmt.Errorf("entity belongs to another tenant: %w", errs.ErrForbidden)
+	}
+	*current = tenantID
+	return nil
+}
+
+// tenantUsers adalah subquery ID user milik tenant. Error pada subquery tidak
+// diteruskan gorm, sehingga tenant diambil dulu lewat tenantOf.
+func tenantUsers(ctx context.Context, db *gorm.DB, tenantID identity.ID) *gorm.DB {
+	return connection(ctx, db).Model(&account.User{}).Select("id").Where("tenant_id = ?", tenantID)
+}
+
+// tenantRoles adalah subquery ID role milik tenant
+func tenantRoles(ctx context.Context, db *gorm.DB, tenantID identity.ID) *gorm.DB {
+	return connection(ctx, db).Model(&account.Role{}).Select("id").Where("tenant_id = ?", tenantID)
+}
+
+// organizationScope membatasi tabel turunan organization (team, anggota,
+// invitation) ke organization milik tenant pada ctx
+func organizationScope(ctx context.Context, db *gorm.DB) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		tenantID, err := tenantOf(ctx)
+		if err != nil {
+			query.AddError(err)
+			return query
+		}
+		return query.Where("organization_id IN (?)", tenantOrganizations(ctx, db, tenantID))
+	}
+}
+
+// teamScope membatasi team_members ke team milik organization di tenant pada ctx
+func teamScope(ctx context.Context, db *gorm.DB) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		tenantID, err := tenantOf(ctx)
+		if err != nil {
+			query.AddError(err)
+			return query
+		}
+		teams := connection(ctx, db).Model(&organization.Team{}).Select("id").Where("organization_id IN (?)", tenantOrganizations(ctx, db, tenantID))
+		return query.Where("team_id IN (?)", teams)
+	}
+}
+
+func tenantOrganizations(ctx context.Context, db *gorm.DB, tenantID identity.ID) *gorm.DB {
+	return connection(ctx, db).Model(&organization.Organization{}).Select("id").Where("tenant_id = ?", tenantID)
+}
@@ -0,0 +1,139 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+// statement adalah query yang dibangun gorm tanpa dijalankan
+type statement struct {
+	sql  string
+	vars []interface{}
+}
+
+// dryRun membuka koneksi DryRun dan mencatat setiap statement yang dibangun
+func dryRun(t *testing.T) (*gorm.DB, *[]statement) {
+	t.Helper()
+
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	statements := make([]statement, 0)
+	record := func(db *gorm.DB) {
+		statements = append(statements, statement{sql: db.Statement.SQL.String(), vars: db.Statement.Vars})
+	}
+	_ = db.Callback().Query().After("gorm:query").Register("test:record", record)
+	_ = db.Callback().Update().After("gorm:update").Register("test:record", record)
+	_ = db.Callback().Create().After("gorm:create").Register("test:record", record)
+	return db, &statements
+}
+
+// boundTo memastikan setiap statement memfilter tenant_id dengan tenant yang diharapkan
+func boundTo(t *testing.T, statements []statement, tenantID identity.ID) {
+	t.Helper()
+
+	if len(statements) == 0 {
+		t.Fatal("no statement was built")
+	}
+	for _, stmt := range statements {
+		if !strings.Contains(stmt.sql, "tenant_id") {
+			t.Errorf("%s is not scoped to a tenant", stmt.sql)
+			continue
+		}
+		found := false
+		for _, v := range stmt.vars {
+			if id, ok := v.(identity.ID); ok && id == tenantID {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s %v is not bound to tenant %s", stmt.sql, stmt.vars, tenantID)
+		}
+	}
+}
+
+func TestTenantScopedRepositories(t *testing.T) {
+	tenantA := identity.New()
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context, db *gorm.DB) error
+	}{
+		{name: "elevation find by id", call: func(ctx context.Context, db *gorm.DB) error {
+			_, err := NewRoleElevationRepository(db).FindByID(ctx, identity.New())
+			return err
+		}},
+		{name: "elevation find all", call: func(ctx context.Context, db *gorm.DB) error {
+			_, _, err := NewRoleElevationRepository(db).FindAll(ctx, &account.RoleElevationFilter{PaginationFilter: model.PaginationFilter{Page: 1, Limit: 10}})
+			return err
+		}},
+		{name: "elevation update", call: func(ctx context.Context, db *gorm.DB) error {
+			return NewRoleElevationRepository(db).Update(ctx, &account.RoleElevation{ID: identity.New(), TenantID: tenantA})
+		}},
+		{name: "elevation create", call: func(ctx context.Context, db *gorm.DB) error {
+			return NewRoleElevationRepository(db).Create(ctx, &account.RoleElevation{ID: identity.New()})
+		}},
+		{name: "audit find all", call: func(ctx context.Context, db *gorm.DB) error {
+			_, _, err := NewAuditRepository(db).FindAll(ctx, &audit.Filter{PaginationFilter: model.PaginationFilter{Page: 1, Limit: 10}})
+			return err
+		}},
+		{name: "audit create", call: func(ctx context.Context, db *gorm.DB) error {
+			return NewAuditRepository(db).Create(ctx, &audit.Entry{Action: audit.ActionCreate})
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := dryRun(t)
+			ctx := requestctx.WithTenantID(context.Background(), tenantA)
+
+			err := tt.call(ctx, db)
+			// DryRun tidak mengembalikan baris sehingga Update melaporkan not found
+			if err != nil && !errors.Is(err, errs.ErrNotFound) {
+				t.Fatalf("error = %v", err)
+			}
+			boundTo(t, *statements, tenantA)
+
+			if err := tt.call(context.Background(), db); !errors.Is(err, errTenantRequired) {
+				t.Errorf("without tenant error = %v, want errTenantRequired", err)
+			}
+		})
+	}
+}
+
+func TestTenantCreateRejectsOtherTenant(t *testing.T) {
+	db, statements := dryRun(t)
+	ctx := requestctx.WithTenantID(context.Background(), identity.New())
+
+	calls := map[string]func() error{
+		"audit entry": func() error {
+			return NewAuditRepository(db).Create(ctx, &audit.Entry{TenantID: identity.New()})
+		},
+		"role elevation": func() error {
+			return NewRoleElevationRepository(db).Create(ctx, &account.RoleElevation{ID: identity.New(), TenantID: identity.New()})
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); !errors.Is(err, errs.ErrForbidden) {
+				t.Fatalf("Create() error = %v, want ErrForbidden", err)
+			}
+		})
+	}
+	if len(*statements) != 0 {
+		t.Errorf("%d statements were built for another tenant", len(*statements))
+	}
+}
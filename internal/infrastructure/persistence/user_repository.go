@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
 	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
@@ -12,6 +13,7 @@ import (
 	"gorm.io/gorm"
 )
 
+// UserRepository membatasi semua query ke tenant pada ctx
 type UserRepository struct {
 	db *gorm.DB
 }
@@ -40,7 +42,7 @@ func (u *UserRepository) GetAll(ctx context.Context, search string, limit int, p
 	}
 	orderBy := fmt.Sprintf("%s %s", sortField, sort)
 
-	query := u.db.WithContext(ctx).Model(&result).Order(orderBy).Limit(limit).Offset(offset)
+	query := connection(ctx, u.db).Model(&result).Scopes(tenantScope(ctx)).Order(orderBy).Limit(limit).Offset(offset)
 
 	if search != "" {
 		searchPattern := fmt.Sprintf("%%%s%%", search)
@@ -58,6 +60,52 @@ func (u *UserRepository) GetAll(ctx context.Context, search string, limit int, p
 	return result, totalItems, nil
 }
 
+/**
+ * GetAllByRole retrieves users holding a role, either as their primary
+ * role or through an unexpired user_roles assignment.
+ * @param ctx context.Context
+ * @param roleID identity.ID
+ * @param search string
+ * @param limit int
+ * @param page int
+ * @param sort string
+ * @return ([]*User, int64, error)
+ */
+func (u *UserRepository) GetAllByRole(ctx context.Context, roleID identity.ID, search string, limit int, page int, sort string) (result []*account.User, totalItems int64, err error) {
+	offset := (page - 1) * limit
+
+	if sort != "asc" && sort != "desc" {
+		sort = "asc"
+	}
+	orderBy := fmt.Sprintf("updated_at %s", sort)
+
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	assigned := connection(ctx, u.db).Model(&account.UserRole{}).
+		Select("user_id").
+		Where("role_id = ? AND (expires_at IS NULL OR expires_at > ?)", roleID, time.Now())
+
+	query := connection(ctx, u.db).Model(&account.User{}).Where("tenant_id = ?", tenantID).Where("role_id = ? OR id IN (?)", roleID, assigned)
+
+	if search != "" {
+		searchPattern := fmt.Sprintf("%%%s%%", search)
+		query = query.Where("name ILIKE ? OR email ILIKE ?", searchPattern, searchPattern)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query users: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
 /**
  * GetByID retrieves a user by their ID.
  * @param ctx context.Context
@@ -65,7 +113,7 @@ func (u *UserRepository) GetAll(ctx context.Context, search string, limit int, p
  * @return (*User, error)
  */
 func (u *UserRepository) GetByID(ctx context.Context, id identity.ID) (result *account.User, err error) {
-	if err := u.db.WithContext(ctx).Where("id = ?", id).First(&result).Error; err != nil {
+	if err := connection(ctx, u.db).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&result).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("user with ID '%s' not found: %w", id, errs.ErrNotFound)
 		}
@@ -81,7 +129,7 @@ func (u *UserRepository) GetByID(ctx context.Context, id identity.ID) (result *a
  * @return (*User, error)
  */
 func (u *UserRepository) GetByEmail(ctx context.Context, email string) (result *account.User, err error) {
-	if err := u.db.WithContext(ctx).Where("email = ?", email).First(&result).Error; err != nil {
+	if err := connection(ctx, u.db).Scopes(tenantScope(ctx)).Where("email = ?", email).First(&result).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("user with email '%s' not found: %w", email, errs.ErrNotFound)
 		}
@@ -97,7 +145,7 @@ func (u *UserRepository) GetByEmail(ctx context.Context, email string) (result *
  * @return (*User, error)
  */
 func (u *UserRepository) GetByUsername(ctx context.Context, username string) (result *account.User, err error) {
-	if err := u.db.WithContext(ctx).Where("username = ?", username).First(&result).Error; err != nil {
+	if err := connection(ctx, u.db).Scopes(tenantScope(ctx)).Where("username = ?", username).First(&result).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("user with username '%s' not found: %w", username, errs.ErrNotFound)
 		}
@@ -113,7 +161,11 @@ func (u *UserRepository) GetByUsername(ctx context.Context, username string) (re
  * @return error
  */
 func (u *UserRepository) Create(ctx context.Context, user *account.User) (err error) {
-	result := u.db.WithContext(ctx).Create(user)
+	if err := assignTenant(ctx, &user.TenantID); err != nil {
+		return err
+	}
+
+	result := connection(ctx, u.db).Create(user)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
 			return fmt.Errorf("user with email '%s' already exists: %w", user.Email, errs.ErrConflict)
@@ -130,8 +182,12 @@ func (u *UserRepository) Create(ctx context.Context, user *account.User) (err er
  * @return (*User, error)
  */
 func (u *UserRepository) Update(ctx context.Context, user *account.User) (err error) {
-	result := u.db.WithContext(ctx).Save(user)
+	currentVersion := user.Version
+	user.Version = currentVersion + 1
+
+	result := connection(ctx, u.db).Model(user).Scopes(tenantScope(ctx)).Where("version = ?", currentVersion).Select("*").Omit("tenant_id").Updates(user)
 	if result.Error != nil {
+		user.Version = currentVersion
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("user with ID '%s' not found: %w", user.ID, errs.ErrNotFound)
 		}
@@ -147,11 +203,47 @@ func (u *UserRepository) Update(ctx context.Context, user *account.User) (err er
 		return fmt.Errorf("failed to update user: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("user with ID '%s' not found: %w", user.ID, errs.ErrNotFound)
+		user.Version = currentVersion
+		return u.versionConflict(ctx, user.ID, currentVersion)
+	}
+	return nil
+}
+
+/**
+ * UpdatePassword stores a new password hash without touching the version.
+ * @param ctx context.Context
+ * @param id identity.ID
+ * @param password PasswordHash
+ * @return error
+ */
+func (u *UserRepository) UpdatePassword(ctx context.Context, id identity.ID, password account.PasswordHash) (err error) {
+	err = connection(ctx, u.db).Model(&account.User{}).Scopes(tenantScope(ctx)).
+		Where("id = ?", id).
+		Update("password", password).Error
+	if err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
 	}
 	return nil
 }
 
+/**
+ * versionConflict explains why a conditional update matched no rows.
+ * @param ctx context.Context
+ * @param id identity.ID
+ * @param expected int64
+ * @return error
+ */
+func (u *UserRepository) versionConflict(ctx context.Context, id identity.ID, expected int64) error {
+	var current account.User
+	if err := connection(ctx, u.db).Scopes(tenantScope(ctx)).Select("version").Where("id = ?", id).First(&current).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return fmt.Errorf("failed to query user: %w", err)
+	}
+	return errs.VersionConflictError{Resource: "user", ID: id, Expected: expected, Actual: current.Version}
+}
+
 /**
  * Delete deletes a user.
  * @param ctx context.Context
@@ -159,7 +251,17 @@ func (u *UserRepository) Update(ctx context.Context, user *account.User) (err er
  * @return error
  */
 func (u *UserRepository) Delete(ctx context.Context, id identity.ID) (err error) {
-	result := u.db.WithContext(ctx).Where("id", id).Delete(&account.User{})
+	tenantID, err := tenantOf(ctx)
+	if err != nil {
+		return err
+	}
+	db := connection(ctx, u.db)
+
+	if err := db.Where("user_id = ? AND user_id IN (?)", id, tenantUsers(ctx, u.db, tenantID)).Delete(&account.UserRole{}).Error; err != nil {
+		return fmt.Errorf("failed to delete role assignments: %w", err)
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&account.User{})
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("user with ID '%s' not found: %w", id, errs.ErrNotFound)
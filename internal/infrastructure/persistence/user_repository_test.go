@@ -0,0 +1,32 @@
+package presistence
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+func TestUserRepositoryUpdatePasswordKeepsVersion(t *testing.T) {
+	db, statements := dryRun(t)
+	tenantID := identity.New()
+	ctx := requestctx.WithTenantID(context.Background(), tenantID)
+
+	if err := NewUserRepository(db).UpdatePassword(ctx, identity.New(), account.NewPasswordHash("$argon2id$new")); err != nil {
+		t.Fatalf("UpdatePassword() error = %v", err)
+	}
+
+	boundTo(t, *statements, tenantID)
+	for _, stmt := range *statements {
+		if !strings.Contains(stmt.sql, "password") {
+			t.Errorf("%s does not update the password", stmt.sql)
+		}
+		// rehash tidak boleh membatalkan If-Match yang sedang dipegang client
+		if strings.Contains(stmt.sql, "version") {
+			t.Errorf("%s touches the version", stmt.sql)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) *UserTokenRepository {
+	return &UserTokenRepository{
+		db: db,
+	}
+}
+
+func (t *UserTokenRepository) Create(ctx context.Context, token *account.UserToken) (err error) {
+	if err := connection(ctx, t.db).Create(token).Error; err != nil {
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+	return nil
+}
+
+func (t *UserTokenRepository) FindByHash(ctx context.Context, hash string, purpose account.TokenPurpose) (result *account.UserToken, err error) {
+	if err := connection(ctx, t.db).Where("token_hash = ? AND purpose = ?", hash, purpose).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user token not found: %w", errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query user token: %w", err)
+	}
+	return result, nil
+}
+
+func (t *UserTokenRepository) MarkUsed(ctx context.Context, token *account.UserToken) (err error) {
+	now := time.Now()
+
+	result := connection(ctx, t.db).Model(&account.UserToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return fmt.Errorf("failed to consume user token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user token already used: %w", errs.ErrNotFound)
+	}
+
+	token.UsedAt = &now
+	return nil
+}
+
+func (t *UserTokenRepository) InvalidateForUser(ctx context.Context, userID identity.ID, purpose account.TokenPurpose) (err error) {
+	err = connection(ctx, t.db).Model(&account.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("failed to invalidate user tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package presistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		db: db,
+	}
+}
+
+func (s *SubscriptionRepository) Create(ctx context.Context, subscription *webhook.Subscription) (err error) {
+	if err := connection(ctx, s.db).Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *SubscriptionRepository) FindByID(ctx context.Context, id identity.ID) (result *webhook.Subscription, err error) {
+	if err := connection(ctx, s.db).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook subscription with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query webhook subscription: %w", err)
+	}
+	return result, nil
+}
+
+func (s *SubscriptionRepository) FindAll(ctx context.Context, filter *model.PaginationFilter) (result []*webhook.Subscription, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "asc"
+	}
+	orderBy := fmt.Sprintf("created_at %s", filter.Sort)
+
+	query := connection(ctx, s.db).Model(&webhook.Subscription{})
+
+	if filter.Search != "" {
+		query = query.Where("url ILIKE ?", fmt.Sprintf("%%%s%%", filter.Search))
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook subscriptions: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (s *SubscriptionRepository) FindActive(ctx context.Context) (result []*webhook.Subscription, err error) {
+	if err := connection(ctx, s.db).Where("is_active = ?", true).Find(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+	return result, nil
+}
+
+func (s *SubscriptionRepository) Update(ctx context.Context, subscription *webhook.Subscription) (err error) {
+	result := connection(ctx, s.db).Model(subscription).Select("*").Updates(subscription)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook subscription with ID '%s' not found: %w", subscription.ID, errs.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *SubscriptionRepository) Delete(ctx context.Context, id identity.ID) (err error) {
+	result := connection(ctx, s.db).Where("id = ?", id).Delete(&webhook.Subscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook subscription with ID '%s' not found: %w", id, errs.ErrNotFound)
+	}
+	return nil
+}
+
+type DeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRepository(db *gorm.DB) *DeliveryRepository {
+	return &DeliveryRepository{
+		db: db,
+	}
+}
+
+func (d *DeliveryRepository) Create(ctx context.Context, deliveries []*webhook.Delivery) (err error) {
+	err = connection(ctx, d.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "delivery_key"}}, DoNothing: true}).
+		Create(deliveries).Error
+	if err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (d *DeliveryRepository) FindByID(ctx context.Context, id identity.ID) (result *webhook.Delivery, err error) {
+	if err := connection(ctx, d.db).Where("id = ?", id).First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook delivery with ID '%s' not found: %w", id, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to query webhook delivery: %w", err)
+	}
+	return result, nil
+}
+
+func (d *DeliveryRepository) FindAll(ctx context.Context, filter *webhook.DeliveryFilter) (result []*webhook.Delivery, totalItems int64, err error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	if filter.Sort != "asc" && filter.Sort != "desc" {
+		filter.Sort = "desc"
+	}
+	orderBy := fmt.Sprintf("created_at %s", filter.Sort)
+
+	query := connection(ctx, d.db).Model(&webhook.Delivery{})
+
+	if filter.SubscriptionID != "" {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Search != "" {
+		query = query.Where("event_name ILIKE ?", fmt.Sprintf("%%%s%%", filter.Search))
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	if err = query.Order(orderBy).Limit(filter.Limit).Offset(offset).Find(&result).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+
+	return result, totalItems, nil
+}
+
+func (d *DeliveryRepository) Claim(ctx context.Context, limit int, now time.Time, leaseUntil time.Time) (result []*webhook.Delivery, err error) {
+	db := connection(ctx, d.db)
+
+	err = db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", webhook.DeliveryPending, now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	ids := make([]identity.ID, 0, len(result))
+	for _, delivery := range result {
+		delivery.Claim(leaseUntil)
+		ids = append(ids, delivery.ID)
+	}
+	if err := db.Model(&webhook.Delivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	return result, nil
+}
+
+func (d *DeliveryRepository) Update(ctx context.Context, delivery *webhook.Delivery, leaseUntil time.Time) (err error) {
+	result := connection(ctx, d.db).Model(delivery).
+		Where("status = ? AND next_attempt_at = ?", webhook.DeliveryPending, leaseUntil).
+		Select("status", "attempts", "response_status", "response_body", "last_error", "next_attempt_at", "delivered_at", "updated_at").
+		Updates(delivery)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("lease on webhook delivery '%s' expired: %w", delivery.ID, errs.ErrConflict)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package security
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// BreachedPasswordFile memeriksa password terhadap daftar hash SHA-1 lokal.
+// Format file sama dengan dump Have I Been Pwned yang diurutkan per hash: satu
+// "HASH" atau "HASH:COUNT" per baris. File tidak dimuat ke memori, setiap
+// pemeriksaan melakukan binary search langsung pada file.
+type BreachedPasswordFile struct {
+	file *os.File
+	size int64
+}
+
+func NewBreachedPasswordFile(path string) (*BreachedPasswordFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+
+	return &BreachedPasswordFile{file: file, size: info.Size()}, nil
+}
+
+func (b *BreachedPasswordFile) IsBreached(ctx context.Context, password string) (breached bool, err error) {
+	sum := sha1.Sum([]byte(password))
+	target := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	// cari baris pertama yang hash-nya >= target. lo selalu berada di awal baris.
+	lo, hi := int64(0), b.size
+	for lo < hi {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		mid := lo + (hi-lo)/2
+		start, err := b.lineStart(mid)
+		if err != nil {
+			return false, err
+		}
+		if start >= hi {
+			hi = mid
+			continue
+		}
+
+		hash, next, err := b.hashAt(start)
+		if err != nil {
+			return false, err
+		}
+		if hash < target {
+			lo = next
+		} else {
+			hi = mid
+		}
+	}
+
+	if lo >= b.size {
+		return false, nil
+	}
+	hash, _, err := b.hashAt(lo)
+	if err != nil {
+		return false, err
+	}
+	return hash == target, nil
+}
+
+/**
+ * Close releases the underlying file.
+ * @return error
+ */
+func (b *BreachedPasswordFile) Close() error {
+	return b.file.Close()
+}
+
+// lineStart mengembalikan offset awal baris pertama yang dimulai di atau setelah offset
+func (b *BreachedPasswordFile) lineStart(offset int64) (int64, error) {
+	if offset == 0 {
+		return 0, nil
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(b.file, offset-1, b.size-offset+1))
+	skipped, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+	return offset - 1 + int64(len(skipped)), nil
+}
+
+// hashAt membaca hash pada baris yang dimulai di offset beserta offset baris berikutnya
+func (b *BreachedPasswordFile) hashAt(offset int64) (hash string, next int64, err error) {
+	reader := bufio.NewReader(io.NewSectionReader(b.file, offset, b.size-offset))
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", 0, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+
+	hash, _, _ = strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash), offset + int64(len(line)), nil
+}
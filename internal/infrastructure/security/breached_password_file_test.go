@@ -0,0 +1,91 @@
+package security
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBreachedPasswordFile(t *testing.T) {
+	listed := make([]string, 0, 500)
+	for i := range 500 {
+		listed = append(listed, fmt.Sprintf("password%d", i))
+	}
+
+	hashes := make([]string, 0, len(listed))
+	for _, password := range listed {
+		sum := sha1.Sum([]byte(password))
+		hashes = append(hashes, strings.ToUpper(hex.EncodeToString(sum[:])))
+	}
+	sort.Strings(hashes)
+
+	tests := []struct {
+		name  string
+		lines func(hashes []string) string
+	}{
+		{name: "hash only", lines: func(hashes []string) string {
+			return strings.Join(hashes, "\n")
+		}},
+		{name: "hash with count and crlf", lines: func(hashes []string) string {
+			var b strings.Builder
+			for i, hash := range hashes {
+				fmt.Fprintf(&b, "%s:%d\r\n", hash, i+1)
+			}
+			return b.String()
+		}},
+		{name: "lowercase hashes", lines: func(hashes []string) string {
+			return strings.ToLower(strings.Join(hashes, "\n")) + "\n"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pwned.txt")
+			if err := os.WriteFile(path, []byte(tt.lines(hashes)), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			list, err := NewBreachedPasswordFile(path)
+			if err != nil {
+				t.Fatalf("NewBreachedPasswordFile() error = %v", err)
+			}
+			defer list.Close()
+
+			for _, password := range listed {
+				breached, err := list.IsBreached(context.Background(), password)
+				if err != nil || !breached {
+					t.Fatalf("IsBreached(%q) = %v, %v, want true", password, breached, err)
+				}
+			}
+			for _, password := range []string{"", "not-listed", "password500", "correct horse battery staple"} {
+				breached, err := list.IsBreached(context.Background(), password)
+				if err != nil || breached {
+					t.Fatalf("IsBreached(%q) = %v, %v, want false", password, breached, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBreachedPasswordFileEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := NewBreachedPasswordFile(path)
+	if err != nil {
+		t.Fatalf("NewBreachedPasswordFile() error = %v", err)
+	}
+	defer list.Close()
+
+	if breached, err := list.IsBreached(context.Background(), "password"); err != nil || breached {
+		t.Fatalf("IsBreached() = %v, %v, want false", breached, err)
+	}
+}
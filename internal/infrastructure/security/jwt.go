@@ -0,0 +1,239 @@
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+var (
+	errMissingSigningKey  = errors.New("jwt signing key is empty")
+	errInvalidAccessToken = fmt.Errorf("access token is invalid or has expired: %w", errs.ErrUnauthorized)
+)
+
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+	// SigningKey adalah secret HS256, minimal 32 byte. Diabaikan jika
+	// PrivateKey diisi.
+	SigningKey []byte
+	// PrivateKey mengaktifkan RS256. Public key-nya dipublikasikan melalui
+	// JWKS sehingga aplikasi lain bisa memverifikasi token, dan wajib diisi
+	// jika OAuth server dipakai.
+	PrivateKey *rsa.PrivateKey
+	KeyID      string
+	TTL        time.Duration
+}
+
+// Claims adalah payload access token
+type Claims struct {
+	Issuer    string      `json:"iss"`
+	Subject   identity.ID `json:"sub"`
+	Audience  string      `json:"aud,omitempty"`
+	IssuedAt  int64       `json:"iat"`
+	ExpiresAt int64       `json:"exp"`
+	ID        identity.ID `json:"jti"`
+	Role      identity.ID `json:"role,omitempty"`
+	SessionID identity.ID `json:"sid,omitempty"`
+	TenantID  identity.ID `json:"tid,omitempty"`
+}
+
+// JWTIssuer menerbitkan dan memverifikasi JWT bertanda tangan HS256 atau RS256
+type JWTIssuer struct {
+	config JWTConfig
+}
+
+func NewJWTIssuer(config JWTConfig) (*JWTIssuer, error) {
+	if len(config.SigningKey) == 0 && config.PrivateKey == nil {
+		return nil, errMissingSigningKey
+	}
+	return &JWTIssuer{config: config}, nil
+}
+
+// ParseRSAPrivateKey membaca private key PEM format PKCS#1 atau PKCS#8
+func ParseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
+
+func (j *JWTIssuer) Issue(ctx context.Context, user *account.User, sessionID identity.ID) (token string, expiresAt time.Time, err error) {
+	now := time.Now()
+	expiresAt = now.Add(j.config.TTL)
+
+	claims := Claims{
+		Issuer:    j.config.Issuer,
+		Subject:   user.ID,
+		Audience:  j.config.Audience,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		ID:        identity.New(),
+		Role:      user.Role,
+		SessionID: sessionID,
+		TenantID:  user.TenantID,
+	}
+
+	token, err = j.Sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// Verify memeriksa tanda tangan, issuer, audience dan masa berlaku token
+func (j *JWTIssuer) Verify(ctx context.Context, token string) (*account.Principal, error) {
+	var claims Claims
+	if err := j.Parse(token, &claims); err != nil {
+		return nil, errInvalidAccessToken
+	}
+	if claims.Issuer != j.config.Issuer || claims.Audience != j.config.Audience {
+		return nil, errInvalidAccessToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt || claims.Subject.IsNil() {
+		return nil, errInvalidAccessToken
+	}
+
+	return &account.Principal{UserID: claims.Subject, Method: account.AuthMethodAccessToken, SessionID: claims.SessionID, TenantID: claims.TenantID}, nil
+}
+
+func (j *JWTIssuer) Algorithm() string {
+	if j.config.PrivateKey != nil {
+		return algRS256
+	}
+	return algHS256
+}
+
+// PublicKeys mengembalikan JWKS. Secret HS256 tidak pernah dipublikasikan.
+func (j *JWTIssuer) PublicKeys() []oauth.JSONWebKey {
+	if j.config.PrivateKey == nil {
+		return []oauth.JSONWebKey{}
+	}
+
+	public := j.config.PrivateKey.PublicKey
+	return []oauth.JSONWebKey{{
+		KeyID:     j.config.KeyID,
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: algRS256,
+		N:         base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+	}}
+}
+
+func (j *JWTIssuer) Sign(claims any) (string, error) {
+	header := map[string]string{"alg": j.Algorithm(), "typ": "JWT"}
+	if j.config.KeyID != "" {
+		header["kid"] = j.config.KeyID
+	}
+
+	encodedHeader, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode jwt claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(encodedHeader) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature, err := j.signature(signingInput)
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Parse memeriksa tanda tangan dan mengisi claims. alg pada header harus sama
+// dengan algoritma issuer agar token "none" atau algoritma lain ditolak.
+func (j *JWTIssuer) Parse(token string, claims any) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errInvalidAccessToken
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return errInvalidAccessToken
+	}
+	var head struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(header, &head); err != nil || head.Alg != j.Algorithm() {
+		return errInvalidAccessToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errInvalidAccessToken
+	}
+	if !j.validSignature(parts[0]+"."+parts[1], signature) {
+		return errInvalidAccessToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return errInvalidAccessToken
+	}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return errInvalidAccessToken
+	}
+	return nil
+}
+
+func (j *JWTIssuer) signature(signingInput string) ([]byte, error) {
+	if j.config.PrivateKey != nil {
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.SignPKCS1v15(rand.Reader, j.config.PrivateKey, crypto.SHA256, digest[:])
+	}
+
+	mac := hmac.New(sha256.New, j.config.SigningKey)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil), nil
+}
+
+func (j *JWTIssuer) validSignature(signingInput string, signature []byte) bool {
+	if j.config.PrivateKey != nil {
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.VerifyPKCS1v15(&j.config.PrivateKey.PublicKey, crypto.SHA256, digest[:], signature) == nil
+	}
+
+	mac := hmac.New(sha256.New, j.config.SigningKey)
+	mac.Write([]byte(signingInput))
+	return hmac.Equal(signature, mac.Sum(nil))
+}
@@ -0,0 +1,210 @@
+package security
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+var testSigningKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func testClaims(now time.Time) Claims {
+	return Claims{
+		Issuer:    "issuer",
+		Subject:   identity.New(),
+		Audience:  "audience",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+		ID:        identity.New(),
+	}
+}
+
+// hmacToken menandatangani token secara manual untuk meniru token buatan penyerang
+func hmacToken(t *testing.T, alg string, key []byte, claims any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTIssuerRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  JWTConfig
+		wantAlg string
+	}{
+		{name: "HS256", config: JWTConfig{SigningKey: testSigningKey}, wantAlg: algHS256},
+		{name: "RS256", config: JWTConfig{PrivateKey: testRSAKey(t), KeyID: "key-1"}, wantAlg: algRS256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Issuer, tt.config.Audience, tt.config.TTL = "issuer", "audience", time.Hour
+			issuer, err := NewJWTIssuer(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if issuer.Algorithm() != tt.wantAlg {
+				t.Errorf("Algorithm() = %s, want %s", issuer.Algorithm(), tt.wantAlg)
+			}
+
+			user := &account.User{ID: identity.New(), TenantID: identity.New()}
+			sessionID := identity.New()
+			token, expiresAt, err := issuer.Issue(context.Background(), user, sessionID)
+			if err != nil {
+				t.Fatalf("Issue() error = %v", err)
+			}
+			if time.Until(expiresAt) <= 0 {
+				t.Errorf("Issue() expiresAt = %v is not in the future", expiresAt)
+			}
+
+			principal, err := issuer.Verify(context.Background(), token)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if principal.UserID != user.ID || principal.SessionID != sessionID || principal.TenantID != user.TenantID {
+				t.Errorf("Verify() principal = %+v", principal)
+			}
+		})
+	}
+}
+
+func TestJWTIssuerVerifyRejects(t *testing.T) {
+	now := time.Now()
+	rsaKey := testRSAKey(t)
+	hsIssuer, _ := NewJWTIssuer(JWTConfig{Issuer: "issuer", Audience: "audience", SigningKey: testSigningKey})
+	rsIssuer, _ := NewJWTIssuer(JWTConfig{Issuer: "issuer", Audience: "audience", PrivateKey: rsaKey})
+
+	sign := func(issuer *JWTIssuer, mutate func(*Claims)) string {
+		claims := testClaims(now)
+		mutate(&claims)
+		token, err := issuer.Sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	tests := []struct {
+		name   string
+		issuer *JWTIssuer
+		token  string
+	}{
+		{name: "wrong issuer", issuer: hsIssuer, token: sign(hsIssuer, func(c *Claims) { c.Issuer = "other" })},
+		{name: "wrong audience", issuer: hsIssuer, token: sign(hsIssuer, func(c *Claims) { c.Audience = "other" })},
+		{name: "expired", issuer: hsIssuer, token: sign(hsIssuer, func(c *Claims) { c.ExpiresAt = now.Add(-time.Second).Unix() })},
+		{name: "missing subject", issuer: hsIssuer, token: sign(hsIssuer, func(c *Claims) { c.Subject = identity.ID{} })},
+		{name: "other secret", issuer: hsIssuer, token: hmacToken(t, algHS256, []byte("another-secret-another-secret-00"), testClaims(now))},
+		{name: "malformed", issuer: hsIssuer, token: "not-a-jwt"},
+		{
+			name:   "alg none",
+			issuer: hsIssuer,
+			token: func() string {
+				parts := strings.Split(hmacToken(t, "none", nil, testClaims(now)), ".")
+				return parts[0] + "." + parts[1] + "."
+			}(),
+		},
+		{
+			// RS256 issuer tidak boleh menerima HS256 yang ditandatangani
+			// memakai public key-nya sendiri sebagai secret
+			name:   "alg confusion with public key",
+			issuer: rsIssuer,
+			token:  hmacToken(t, algHS256, publicPEM, testClaims(now)),
+		},
+		{
+			name:   "RS256 token on HS256 issuer",
+			issuer: hsIssuer,
+			token:  sign(rsIssuer, func(*Claims) {}),
+		},
+		{
+			name:   "tampered payload",
+			issuer: rsIssuer,
+			token: func() string {
+				parts := strings.Split(sign(rsIssuer, func(*Claims) {}), ".")
+				forged := strings.Split(sign(rsIssuer, func(c *Claims) { c.Subject = identity.New() }), ".")
+				return parts[0] + "." + forged[1] + "." + parts[2]
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.issuer.Verify(context.Background(), tt.token); !errors.Is(err, errs.ErrUnauthorized) {
+				t.Fatalf("Verify() error = %v, want ErrUnauthorized", err)
+			}
+		})
+	}
+}
+
+func TestNewJWTIssuerRequiresKey(t *testing.T) {
+	if _, err := NewJWTIssuer(JWTConfig{Issuer: "issuer"}); !errors.Is(err, errMissingSigningKey) {
+		t.Fatalf("NewJWTIssuer() error = %v, want errMissingSigningKey", err)
+	}
+}
+
+func TestParseRSAPrivateKey(t *testing.T) {
+	key := testRSAKey(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "PKCS1", data: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})},
+		{name: "PKCS8", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})},
+		{name: "not PEM", data: []byte("secret"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseRSAPrivateKey(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRSAPrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !parsed.Equal(key) {
+				t.Errorf("ParseRSAPrivateKey() returned a different key")
+			}
+		})
+	}
+}
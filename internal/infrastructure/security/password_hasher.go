@@ -0,0 +1,48 @@
+package security
+
+import (
+	"errors"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+)
+
+var errUnknownHashFormat = errors.New("unknown password hash format")
+
+// algorithm adalah satu skema hash yang bisa mengenali hash miliknya sendiri
+type algorithm interface {
+	account.IPasswordHasher
+	Identifies(encoded string) bool
+}
+
+// PasswordHasher meng-hash dengan algoritma pilihan dan tetap bisa
+// memverifikasi hash dari algoritma lain yang didaftarkan
+type PasswordHasher struct {
+	preferred algorithm
+	known     []algorithm
+}
+
+var _ account.IPasswordHasher = (*PasswordHasher)(nil)
+
+func NewPasswordHasher(preferred algorithm, legacy ...algorithm) *PasswordHasher {
+	return &PasswordHasher{
+		preferred: preferred,
+		known:     append([]algorithm{preferred}, legacy...),
+	}
+}
+
+func (h *PasswordHasher) Hash(password string) (encoded string, err error) {
+	return h.preferred.Hash(password)
+}
+
+func (h *PasswordHasher) Verify(password string, encoded string) (ok bool, err error) {
+	for _, algo := range h.known {
+		if algo.Identifies(encoded) {
+			return algo.Verify(password, encoded)
+		}
+	}
+	return false, errUnknownHashFormat
+}
+
+func (h *PasswordHasher) NeedsRehash(encoded string) bool {
+	return !h.preferred.Identifies(encoded) || h.preferred.NeedsRehash(encoded)
+}
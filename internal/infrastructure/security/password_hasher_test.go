@@ -0,0 +1,125 @@
+package security
+
+import (
+	"strings"
+	"testing"
+)
+
+// testArgon2idParams memakai biaya kecil agar test cepat
+var testArgon2idParams = Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestPasswordHasherRoundTrip(t *testing.T) {
+	argon := NewArgon2idHasher(testArgon2idParams)
+	bcryptHasher := NewBcryptHasher(4)
+
+	tests := []struct {
+		name   string
+		hasher interface {
+			Hash(string) (string, error)
+			Verify(string, string) (bool, error)
+		}
+		prefix string
+	}{
+		{name: "argon2id", hasher: argon, prefix: "$argon2id$v=19$m=64,t=1,p=1$"},
+		{name: "bcrypt", hasher: bcryptHasher, prefix: "$2a$04$"},
+		{name: "multi hasher", hasher: NewPasswordHasher(argon, bcryptHasher), prefix: "$argon2id$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.hasher.Hash("correct horse")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if !strings.HasPrefix(encoded, tt.prefix) {
+				t.Errorf("Hash() = %s, want prefix %s", encoded, tt.prefix)
+			}
+
+			if ok, err := tt.hasher.Verify("correct horse", encoded); !ok || err != nil {
+				t.Errorf("Verify(correct) = %v, %v, want true", ok, err)
+			}
+			if ok, err := tt.hasher.Verify("wrong horse", encoded); ok || err != nil {
+				t.Errorf("Verify(wrong) = %v, %v, want false without error", ok, err)
+			}
+		})
+	}
+}
+
+func TestPasswordHasherNeedsRehash(t *testing.T) {
+	argon := NewArgon2idHasher(testArgon2idParams)
+	hasher := NewPasswordHasher(argon, NewBcryptHasher(4))
+
+	current, _ := argon.Hash("secret")
+	weaker := testArgon2idParams
+	weaker.Iterations = 2
+	older, _ := NewArgon2idHasher(weaker).Hash("secret")
+	legacy, _ := NewBcryptHasher(4).Hash("secret")
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    bool
+	}{
+		{name: "current parameters", encoded: current},
+		{name: "different parameters", encoded: older, want: true},
+		{name: "legacy algorithm", encoded: legacy, want: true},
+		{name: "malformed", encoded: "$argon2id$broken", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasher.NeedsRehash(tt.encoded); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// hash lama tetap bisa diverifikasi sebelum di-rehash
+	for _, encoded := range []string{older, legacy} {
+		if ok, err := hasher.Verify("secret", encoded); !ok || err != nil {
+			t.Errorf("Verify(%s) = %v, %v, want true", encoded, ok, err)
+		}
+	}
+}
+
+func TestArgon2idRejectsMalformedHash(t *testing.T) {
+	argon := NewArgon2idHasher(testArgon2idParams)
+	salt := "c29tZXNhbHRzb21lc2FsdA"                     // 16 byte
+	key := "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U" // 32 byte
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "empty", encoded: ""},
+		{name: "other algorithm", encoded: "$argon2i$v=19$m=64,t=1,p=1$" + salt + "$" + key},
+		{name: "missing part", encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt},
+		{name: "unknown version", encoded: "$argon2id$v=16$m=64,t=1,p=1$" + salt + "$" + key},
+		{name: "zero parallelism", encoded: "$argon2id$v=19$m=64,t=1,p=0$" + salt + "$" + key},
+		{name: "zero iterations", encoded: "$argon2id$v=19$m=64,t=0,p=1$" + salt + "$" + key},
+		{name: "too many iterations", encoded: "$argon2id$v=19$m=64,t=1000000,p=1$" + salt + "$" + key},
+		{name: "memory below 8 per lane", encoded: "$argon2id$v=19$m=8,t=1,p=4$" + salt + "$" + key},
+		{name: "huge memory", encoded: "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + key},
+		{name: "parallelism overflow", encoded: "$argon2id$v=19$m=64,t=1,p=256$" + salt + "$" + key},
+		{name: "bad salt encoding", encoded: "$argon2id$v=19$m=64,t=1,p=1$!!!$" + key},
+		{name: "short salt", encoded: "$argon2id$v=19$m=64,t=1,p=1$c2FsdA$" + key},
+		{name: "empty key", encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$"},
+		{name: "short key", encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$a2V5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := argon.Verify("secret", tt.encoded)
+			if ok || err == nil {
+				t.Fatalf("Verify() = %v, %v, want an error", ok, err)
+			}
+			if !argon.NeedsRehash(tt.encoded) {
+				t.Error("NeedsRehash() = false for a malformed hash")
+			}
+		})
+	}
+
+	if _, err := NewPasswordHasher(argon).Verify("secret", "plaintext"); err == nil {
+		t.Error("Verify() of an unknown format succeeded")
+	}
+}
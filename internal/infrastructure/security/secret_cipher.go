@@ -0,0 +1,75 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+)
+
+// secretCipherPrefix menandai format ciphertext agar kunci atau algoritma
+// bisa diganti nanti tanpa kehilangan data lama
+const secretCipherPrefix = "v1:"
+
+var (
+	errInvalidCipherKey = errors.New("secret cipher key must be 32 bytes")
+	errMalformedSecret  = errors.New("malformed encrypted secret")
+)
+
+// AESSecretCipher mengenkripsi secret dengan AES-256-GCM. Nonce acak
+// disimpan di depan ciphertext.
+type AESSecretCipher struct {
+	aead cipher.AEAD
+}
+
+var _ account.ISecretCipher = (*AESSecretCipher)(nil)
+
+func NewAESSecretCipher(key []byte) (*AESSecretCipher, error) {
+	if len(key) != 32 {
+		return nil, errInvalidCipherKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret cipher: %w", err)
+	}
+	return &AESSecretCipher{aead: aead}, nil
+}
+
+func (c *AESSecretCipher) Encrypt(plain string) (encrypted string, err error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return secretCipherPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *AESSecretCipher) Decrypt(encrypted string) (plain string, err error) {
+	encoded, ok := strings.CutPrefix(encrypted, secretCipherPrefix)
+	if !ok {
+		return "", errMalformedSecret
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", errMalformedSecret
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	opened, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(opened), nil
+}
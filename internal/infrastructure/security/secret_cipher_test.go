@@ -0,0 +1,62 @@
+package security
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAESSecretCipher(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	cipher, err := NewAESSecretCipher(key)
+	if err != nil {
+		t.Fatalf("NewAESSecretCipher() error = %v", err)
+	}
+
+	encrypted, err := cipher.Encrypt("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if strings.Contains(encrypted, "JBSWY3DPEHPK3PXP") {
+		t.Fatalf("Encrypt() leaked the plaintext: %s", encrypted)
+	}
+	again, _ := cipher.Encrypt("JBSWY3DPEHPK3PXP")
+	if again == encrypted {
+		t.Error("Encrypt() is deterministic, want a random nonce")
+	}
+
+	other, _ := NewAESSecretCipher(bytes.Repeat([]byte{8}, 32))
+	tampered := encrypted[:len(encrypted)-2] + "AA"
+
+	tests := []struct {
+		name    string
+		cipher  *AESSecretCipher
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "round trip", cipher: cipher, value: encrypted, want: "JBSWY3DPEHPK3PXP"},
+		{name: "wrong key", cipher: other, value: encrypted, wantErr: true},
+		{name: "tampered", cipher: cipher, value: tampered, wantErr: true},
+		{name: "plaintext is rejected", cipher: cipher, value: "JBSWY3DPEHPK3PXP", wantErr: true},
+		{name: "malformed", cipher: cipher, value: secretCipherPrefix + "!!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cipher.Decrypt(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decrypt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Decrypt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAESSecretCipherKeySize(t *testing.T) {
+	if _, err := NewAESSecretCipher([]byte("short")); err == nil {
+		t.Error("NewAESSecretCipher() accepted a short key")
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type APIKeyHandler struct {
+	service interfaces.IAPIKeyService
+}
+
+func NewAPIKeyHandler(service interfaces.IAPIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+// RegisterRoutes mendaftarkan route /me untuk key milik sendiri dan route
+// /users/{id} untuk admin. Service memeriksa permission untuk user lain.
+func (h *APIKeyHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(account.PermissionAPIKeysRead)
+	write := middleware.RequireScope(account.PermissionAPIKeysWrite)
+
+	mux.Handle("GET /me/api-keys", read(http.HandlerFunc(h.List)))
+	mux.Handle("POST /me/api-keys", write(http.HandlerFunc(h.Create)))
+	mux.Handle("DELETE /me/api-keys/{keyId}", write(http.HandlerFunc(h.Revoke)))
+	mux.Handle("GET /users/{id}/api-keys", read(http.HandlerFunc(h.List)))
+	mux.Handle("POST /users/{id}/api-keys", write(http.HandlerFunc(h.Create)))
+	mux.Handle("DELETE /users/{id}/api-keys/{keyId}", write(http.HandlerFunc(h.Revoke)))
+}
+
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := targetUserID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	keys, err := h.service.List(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, keys)
+}
+
+/**
+ * Create returns the full key once; only its prefix is shown afterwards.
+ */
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id, err := targetUserID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload account.CreateAPIKeyRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	key, err := h.service.Create(r.Context(), id, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, key)
+}
+
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := targetUserID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	keyID, err := pathID(r, "keyId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), id, keyID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
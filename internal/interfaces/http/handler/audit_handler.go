@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+type AuditHandler struct {
+	service interfaces.IAuditService
+}
+
+func NewAuditHandler(service interfaces.IAuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+func (h *AuditHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(audit.PermissionAuditRead)
+
+	mux.Handle("GET /audit-logs", read(http.HandlerFunc(h.FindAll)))
+}
+
+/**
+ * FindAll lists audit entries. Besides pagination it accepts actor_id,
+ * action, aggregate_type, aggregate_id and an RFC 3339 from/to range.
+ */
+func (h *AuditHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := &audit.Filter{
+		PaginationFilter: *paginationFilter(r),
+		ActorID:          query.Get("actor_id"),
+		Action:           audit.Action(query.Get("action")),
+		AggregateType:    query.Get("aggregate_type"),
+		AggregateID:      query.Get("aggregate_id"),
+	}
+
+	var err error
+	if filter.From, err = queryTime(r, "from"); err != nil {
+		response.Error(w, err)
+		return
+	}
+	if filter.To, err = queryTime(r, "to"); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	entries, totalItems, err := h.service.FindAll(r.Context(), filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, entries, filter.Page, filter.Limit, totalItems)
+}
+
+func queryTime(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errs.ValidationError{Field: name, Message: "must be an RFC 3339 timestamp"}
+	}
+	return &parsed, nil
+}
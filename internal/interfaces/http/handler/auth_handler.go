@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type AuthHandler struct {
+	service interfaces.IAuthService
+}
+
+func NewAuthHandler(service interfaces.IAuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+func (h *AuthHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /auth/login", h.Login)
+	mux.HandleFunc("POST /auth/mfa/verify", h.VerifyMFA)
+}
+
+/**
+ * Login returns an access token, or a challenge token when the user has MFA
+ * enabled.
+ */
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var payload account.LoginRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.Login(r.Context(), &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var payload account.VerifyMFARequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.VerifyMFA(r.Context(), &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
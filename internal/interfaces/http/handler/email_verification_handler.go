@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type EmailVerificationHandler struct {
+	service interfaces.IEmailVerificationService
+}
+
+func NewEmailVerificationHandler(service interfaces.IEmailVerificationService) *EmailVerificationHandler {
+	return &EmailVerificationHandler{service: service}
+}
+
+func (h *EmailVerificationHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /email/verify", h.Confirm)
+	mux.HandleFunc("POST /email/verify/resend", h.Resend)
+}
+
+func (h *EmailVerificationHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	var payload account.ConfirmEmailRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Confirm(r.Context(), payload.Token); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+/**
+ * Resend answers 202, or 429 once the email or client IP is throttled, the
+ * same way for every email so the response does not reveal whether it is
+ * registered.
+ */
+func (h *EmailVerificationHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	var payload account.ResendVerificationRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Resend(r.Context(), payload.Email); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type MFAHandler struct {
+	service interfaces.IMFAService
+}
+
+func NewMFAHandler(service interfaces.IMFAService) *MFAHandler {
+	return &MFAHandler{service: service}
+}
+
+// RegisterRoutes mendaftarkan route MFA milik user yang sedang login, serta
+// route pendaftaran MFA yang dituntut login untuk akun admin
+func (h *MFAHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /me/mfa/totp", middleware.RequireAuth(http.HandlerFunc(h.Enroll)))
+	mux.Handle("POST /me/mfa/totp/activate", middleware.RequireAuth(http.HandlerFunc(h.Activate)))
+	mux.Handle("DELETE /me/mfa/totp", middleware.RequireAuth(http.HandlerFunc(h.Disable)))
+	mux.Handle("POST /me/mfa/recovery-codes", middleware.RequireAuth(http.HandlerFunc(h.RegenerateRecoveryCodes)))
+	mux.HandleFunc("POST /auth/mfa/enroll", h.EnrollWithChallenge)
+	mux.HandleFunc("POST /auth/mfa/enroll/activate", h.ActivateWithChallenge)
+}
+
+/**
+ * Enroll returns the secret, the otpauth URI and a QR code PNG for a new
+ * TOTP enrollment.
+ */
+func (h *MFAHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	id, err := actorID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.EnrollTOTP(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, result)
+}
+
+func (h *MFAHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	id, err := actorID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload account.MFACodeRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.ActivateTOTP(r.Context(), id, payload.Code)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+func (h *MFAHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	id, err := actorID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload account.MFACodeRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), id, payload.Code); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *MFAHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	id, err := actorID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload account.MFACodeRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.RegenerateRecoveryCodes(r.Context(), id, payload.Code)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+/**
+ * EnrollWithChallenge starts the enrollment with the challenge token that
+ * login returned together with mfa_enrollment_required.
+ */
+func (h *MFAHandler) EnrollWithChallenge(w http.ResponseWriter, r *http.Request) {
+	var payload account.MFAEnrollmentRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.EnrollTOTPWithChallenge(r.Context(), payload.ChallengeToken)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, result)
+}
+
+func (h *MFAHandler) ActivateWithChallenge(w http.ResponseWriter, r *http.Request) {
+	var payload account.MFAEnrollmentRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.ActivateTOTPWithChallenge(r.Context(), payload.ChallengeToken, payload.Code)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type OAuthClientHandler struct {
+	service interfaces.IClientService
+}
+
+func NewOAuthClientHandler(service interfaces.IClientService) *OAuthClientHandler {
+	return &OAuthClientHandler{service: service}
+}
+
+func (h *OAuthClientHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(oauth.PermissionClientsRead)
+	write := middleware.RequireScope(oauth.PermissionClientsWrite)
+
+	mux.Handle("GET /oauth/clients", read(http.HandlerFunc(h.GetAll)))
+	mux.Handle("GET /oauth/clients/{id}", read(http.HandlerFunc(h.GetByID)))
+	mux.Handle("POST /oauth/clients", write(http.HandlerFunc(h.Create)))
+	mux.Handle("DELETE /oauth/clients/{id}", write(http.HandlerFunc(h.Delete)))
+}
+
+func (h *OAuthClientHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter := paginationFilter(r)
+
+	clients, totalItems, err := h.service.GetAll(r.Context(), filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, clients, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *OAuthClientHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	client, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, client)
+}
+
+/**
+ * Create returns the client secret once for confidential clients.
+ */
+func (h *OAuthClientHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var payload oauth.CreateClientRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	client, err := h.service.Create(r.Context(), &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, client)
+}
+
+func (h *OAuthClientHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
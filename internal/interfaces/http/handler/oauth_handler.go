@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+type OAuthHandler struct {
+	service interfaces.IOAuthService
+}
+
+func NewOAuthHandler(service interfaces.IOAuthService) *OAuthHandler {
+	return &OAuthHandler{service: service}
+}
+
+func (h *OAuthHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /oauth/authorize", h.Authorize)
+	mux.HandleFunc("POST /oauth/authorize", h.Decide)
+	mux.HandleFunc("POST /oauth/token", h.Token)
+	mux.HandleFunc("POST /oauth/introspect", h.Introspect)
+	mux.HandleFunc("POST /oauth/revoke", h.Revoke)
+	mux.HandleFunc("GET /.well-known/openid-configuration", h.Discovery)
+	mux.HandleFunc("GET /.well-known/jwks.json", h.JWKS)
+}
+
+/**
+ * Authorize is called by the login frontend with the query of the client's
+ * authorization request. It answers with redirect_to, or consent_required
+ * when the frontend must ask the user first.
+ */
+func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requestctx.ActorID(r.Context())
+	if !ok {
+		response.Error(w, errs.ErrUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	payload := oauth.AuthorizeRequest{
+		ResponseType:        query.Get("response_type"),
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		Nonce:               query.Get("nonce"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+	}
+
+	result, err := h.service.Authorize(r.Context(), userID, &payload)
+	if err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+/**
+ * Decide submits the consent decision with the same parameters as Authorize.
+ */
+func (h *OAuthHandler) Decide(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requestctx.ActorID(r.Context())
+	if !ok {
+		response.Error(w, errs.ErrUnauthorized)
+		return
+	}
+
+	var payload oauth.ConsentDecisionRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.Decide(r.Context(), userID, &payload)
+	if err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+func (h *OAuthHandler) Token(w http.ResponseWriter, r *http.Request) {
+	form, credentials, err := oauthForm(r)
+	if err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	result, err := h.service.Token(r.Context(), &oauth.TokenRequest{
+		ClientCredentials: credentials,
+		GrantType:         form.Get("grant_type"),
+		Code:              form.Get("code"),
+		RedirectURI:       form.Get("redirect_uri"),
+		CodeVerifier:      form.Get("code_verifier"),
+		Scope:             form.Get("scope"),
+	})
+	if err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.Raw(w, http.StatusOK, result)
+}
+
+func (h *OAuthHandler) Introspect(w http.ResponseWriter, r *http.Request) {
+	form, credentials, err := oauthForm(r)
+	if err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	result, err := h.service.Introspect(r.Context(), credentials, form.Get("token"))
+	if err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.Raw(w, http.StatusOK, result)
+}
+
+func (h *OAuthHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	form, credentials, err := oauthForm(r)
+	if err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), credentials, form.Get("token")); err != nil {
+		oauthError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *OAuthHandler) Discovery(w http.ResponseWriter, r *http.Request) {
+	response.Raw(w, http.StatusOK, h.service.Discovery())
+}
+
+func (h *OAuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	response.Raw(w, http.StatusOK, h.service.JWKS())
+}
+
+// oauthForm membaca body form dan kredensial client dari header Basic
+// (client_secret_basic) atau dari form (client_secret_post)
+func oauthForm(r *http.Request) (url.Values, oauth.ClientCredentials, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, oauth.ClientCredentials{}, oauth.Error{Code: oauth.ErrorInvalidRequest, Description: "request body must be form encoded"}
+	}
+
+	credentials := oauth.ClientCredentials{
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+	}
+	if id, secret, ok := r.BasicAuth(); ok {
+		// Nilai Basic auth di-encode form-urlencoded sesuai RFC 6749 bagian 2.3.1
+		id, idErr := url.QueryUnescape(id)
+		secret, secretErr := url.QueryUnescape(secret)
+		if idErr != nil || secretErr != nil {
+			return nil, credentials, oauth.Error{Code: oauth.ErrorInvalidClient}
+		}
+		credentials = oauth.ClientCredentials{ClientID: id, ClientSecret: secret}
+	}
+	return r.PostForm, credentials, nil
+}
+
+// oauthError menulis error protokol dalam format RFC 6749, error lain
+// dipetakan seperti endpoint biasa
+func oauthError(w http.ResponseWriter, err error) {
+	var protocolErr oauth.Error
+	if !errors.As(err, &protocolErr) {
+		response.Error(w, err)
+		return
+	}
+
+	if protocolErr.Code == oauth.ErrorInvalidClient {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	response.Raw(w, protocolErr.StatusCode(), protocolErr)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+type OIDCHandler struct {
+	service interfaces.IOIDCService
+}
+
+func NewOIDCHandler(service interfaces.IOIDCService) *OIDCHandler {
+	return &OIDCHandler{service: service}
+}
+
+func (h *OIDCHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /auth/oidc/{provider}/login", h.Login)
+	mux.HandleFunc("GET /auth/oidc/{provider}/callback", h.Callback)
+}
+
+/**
+ * Login redirects the browser to the login page of the provider.
+ */
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	authURL, err := h.service.Start(r.Context(), r.PathValue("provider"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+/**
+ * Callback receives the redirect from the provider and answers like
+ * POST /auth/login, including the MFA challenge.
+ */
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if reason := query.Get("error"); reason != "" {
+		response.Error(w, fmt.Errorf("identity provider returned '%s': %w", reason, errs.ErrUnauthorized))
+		return
+	}
+
+	result, err := h.service.Callback(r.Context(), r.PathValue("provider"), query.Get("state"), query.Get("code"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(w, http.StatusOK, result)
+}
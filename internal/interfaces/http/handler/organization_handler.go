@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type OrganizationHandler struct {
+	service interfaces.IOrganizationService
+}
+
+func NewOrganizationHandler(service interfaces.IOrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{service: service}
+}
+
+// RegisterRoutes mewajibkan login pada semua route, akses per organization
+// diputuskan service berdasarkan keanggotaan actor
+func (h *OrganizationHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("GET /organizations", middleware.RequireAuth(http.HandlerFunc(h.FindAll)))
+	mux.Handle("GET /organizations/{id}", middleware.RequireAuth(http.HandlerFunc(h.FindByID)))
+	mux.Handle("POST /organizations", middleware.RequireAuth(http.HandlerFunc(h.Create)))
+	mux.Handle("PUT /organizations/{id}", middleware.RequireAuth(http.HandlerFunc(h.Update)))
+	mux.Handle("DELETE /organizations/{id}", middleware.RequireAuth(http.HandlerFunc(h.Delete)))
+	mux.Handle("GET /organizations/{id}/members", middleware.RequireAuth(http.HandlerFunc(h.FindMembers)))
+	mux.Handle("PUT /organizations/{id}/members/{userId}", middleware.RequireAuth(http.HandlerFunc(h.SetMemberRole)))
+	mux.Handle("DELETE /organizations/{id}/members/{userId}", middleware.RequireAuth(http.HandlerFunc(h.RemoveMember)))
+	mux.Handle("GET /organizations/{id}/invitations", middleware.RequireAuth(http.HandlerFunc(h.FindInvitations)))
+	mux.Handle("POST /organizations/{id}/invitations", middleware.RequireAuth(http.HandlerFunc(h.Invite)))
+	mux.Handle("DELETE /organizations/{id}/invitations/{invitationId}", middleware.RequireAuth(http.HandlerFunc(h.RevokeInvitation)))
+	mux.Handle("POST /invitations/accept", middleware.RequireAuth(http.HandlerFunc(h.AcceptInvitation)))
+}
+
+/**
+ * FindAll lists the organizations visible to the caller.
+ */
+func (h *OrganizationHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	filter := paginationFilter(r)
+
+	organizations, totalItems, err := h.service.FindAll(r.Context(), filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, organizations, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *OrganizationHandler) FindByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	org, err := h.service.FindByID(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(org.Version))
+	response.JSON(w, http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var payload organization.CreateOrganizationRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	org, err := h.service.Create(r.Context(), &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(org.Version))
+	response.JSON(w, http.StatusCreated, org)
+}
+
+func (h *OrganizationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload organization.UpdateOrganizationRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+	if version != 0 {
+		payload.Version = version
+	}
+
+	org, err := h.service.Update(r.Context(), id, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(org.Version))
+	response.JSON(w, http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *OrganizationHandler) FindMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	filter := paginationFilter(r)
+	members, totalItems, err := h.service.FindMembers(r.Context(), id, filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, members, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *OrganizationHandler) SetMemberRole(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	userID, err := pathID(r, "userId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload organization.SetMemberRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	member, err := h.service.SetMemberRole(r.Context(), id, userID, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, member)
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	userID, err := pathID(r, "userId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), id, userID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+/**
+ * FindInvitations lists invitations of an organization, optionally filtered by status.
+ */
+func (h *OrganizationHandler) FindInvitations(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	filter := &organization.InvitationFilter{
+		PaginationFilter: *paginationFilter(r),
+		Status:           organization.InvitationStatus(r.URL.Query().Get("status")),
+	}
+
+	invitations, totalItems, err := h.service.FindInvitations(r.Context(), id, filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, invitations, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *OrganizationHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload organization.CreateInvitationRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	invitation, err := h.service.Invite(r.Context(), id, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, invitation)
+}
+
+func (h *OrganizationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	invitationID, err := pathID(r, "invitationId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.RevokeInvitation(r.Context(), id, invitationID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *OrganizationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var payload organization.AcceptInvitationRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.service.AcceptInvitation(r.Context(), &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
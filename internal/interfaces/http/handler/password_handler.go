@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type PasswordHandler struct {
+	service interfaces.IPasswordResetService
+}
+
+func NewPasswordHandler(service interfaces.IPasswordResetService) *PasswordHandler {
+	return &PasswordHandler{service: service}
+}
+
+func (h *PasswordHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /password/forgot", h.RequestReset)
+	mux.HandleFunc("POST /password/reset", h.ConfirmReset)
+}
+
+/**
+ * RequestReset always answers 202 so the response does not reveal whether
+ * the email is registered.
+ */
+func (h *PasswordHandler) RequestReset(w http.ResponseWriter, r *http.Request) {
+	var payload account.RequestPasswordResetRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.RequestReset(r.Context(), payload.Email); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *PasswordHandler) ConfirmReset(w http.ResponseWriter, r *http.Request) {
+	var payload account.ConfirmPasswordResetRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.ConfirmReset(r.Context(), payload.Token, payload.Password); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/model"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+func decodeJSON(r *http.Request, dst interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		return fmt.Errorf("invalid request body: %w", errs.ErrBadRequest)
+	}
+	return nil
+}
+
+func pathID(r *http.Request, name string) (identity.ID, error) {
+	id, err := identity.Parse(r.PathValue(name))
+	if err != nil {
+		return id, errs.ValidationError{Field: name, Message: "must be a valid UUID"}
+	}
+	return id, nil
+}
+
+// actorID mengambil user yang sedang login untuk route self-service /me
+func actorID(r *http.Request) (identity.ID, error) {
+	id, ok := requestctx.ActorID(r.Context())
+	if !ok {
+		return id, fmt.Errorf("authentication required: %w", errs.ErrUnauthorized)
+	}
+	return id, nil
+}
+
+// targetUserID memakai {id} pada route /users/{id}/..., atau user yang sedang
+// login pada route /me/... yang tidak memiliki parameter tersebut
+func targetUserID(r *http.Request) (identity.ID, error) {
+	if r.PathValue("id") == "" {
+		return actorID(r)
+	}
+	return pathID(r, "id")
+}
+
+func paginationFilter(r *http.Request) *model.PaginationFilter {
+	query := r.URL.Query()
+
+	filter := &model.PaginationFilter{
+		Limit:  defaultLimit,
+		Page:   1,
+		Sort:   query.Get("sort"),
+		Search: query.Get("search"),
+	}
+
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		filter.Limit = min(limit, maxLimit)
+	}
+	if page, err := strconv.Atoi(query.Get("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+
+	return filter
+}
+
+// queryFields membaca parameter fields yang dipisahkan koma
+func queryFields(r *http.Request) []string {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return nil
+	}
+
+	fields := make([]string, 0)
+	for _, field := range strings.Split(raw, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// formatETag mengubah versi aggregate menjadi nilai header ETag
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion membaca versi dari header If-Match sesuai RFC 9110, 0 berarti
+// tanpa prasyarat. If-Match memakai strong comparison sehingga weak tag dan tag
+// yang bukan versi tidak pernah cocok. Header yang hanya berisi tag seperti itu
+// menghasilkan ErrPreconditionFailed, sedangkan daftar dengan beberapa versi
+// berbeda ditolak karena service hanya memeriksa satu versi.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	values := r.Header.Values("If-Match")
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	header := strings.TrimSpace(strings.Join(values, ","))
+	if header == "*" {
+		return 0, nil
+	}
+
+	tags, err := parseEntityTags(header)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	for _, tag := range tags {
+		if tag.weak {
+			continue
+		}
+		candidate, err := strconv.ParseInt(tag.opaque, 10, 64)
+		if err != nil || candidate <= 0 {
+			continue
+		}
+		if version != 0 && version != candidate {
+			return 0, errs.ValidationError{Field: "If-Match", Message: "must not list more than one version"}
+		}
+		version = candidate
+	}
+	if version == 0 {
+		return 0, fmt.Errorf("If-Match does not match any version: %w", errs.ErrPreconditionFailed)
+	}
+	return version, nil
+}
+
+type entityTag struct {
+	weak   bool
+	opaque string
+}
+
+// parseEntityTags mengurai daftar entity-tag yang dipisahkan koma. Koma boleh
+// muncul di dalam tag, jadi daftar tidak bisa dipecah dengan strings.Split.
+func parseEntityTags(header string) ([]entityTag, error) {
+	invalid := errs.ValidationError{Field: "If-Match", Message: "must be * or a list of quoted entity tags"}
+
+	tags := make([]entityTag, 0, 1)
+	rest := header
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		if rest == "" {
+			break
+		}
+
+		var tag entityTag
+		if strings.HasPrefix(rest, "W/") {
+			tag.weak = true
+			rest = rest[len("W/"):]
+		}
+		if !strings.HasPrefix(rest, `"`) {
+			return nil, invalid
+		}
+		end := strings.IndexByte(rest[1:], '"')
+		if end < 0 {
+			return nil, invalid
+		}
+		tag.opaque = rest[1 : end+1]
+		tags = append(tags, tag)
+
+		rest = strings.TrimLeft(rest[end+2:], " \t")
+		if rest != "" && rest[0] != ',' {
+			return nil, invalid
+		}
+	}
+	if len(tags) == 0 {
+		return nil, invalid
+	}
+	return tags, nil
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    int64
+		wantErr error
+	}{
+		{name: "absent"},
+		{name: "wildcard", headers: []string{"*"}},
+		{name: "strong tag", headers: []string{`"3"`}, want: 3},
+		{name: "list with one version", headers: []string{`W/"2", "3"`}, want: 3},
+		{name: "repeated version", headers: []string{`"3", "3"`}, want: 3},
+		{name: "split over header lines", headers: []string{`"abc"`, `"3"`}, want: 3},
+		{name: "comma inside tag", headers: []string{`"a,b" , "3"`}, want: 3},
+		{name: "weak tag never matches", headers: []string{`W/"3"`}, wantErr: errs.ErrPreconditionFailed},
+		{name: "foreign tag never matches", headers: []string{`"abc"`}, wantErr: errs.ErrPreconditionFailed},
+		{name: "several versions", headers: []string{`"3", "4"`}, wantErr: errs.ErrBadRequest},
+		{name: "bare version", headers: []string{`3`}, wantErr: errs.ErrBadRequest},
+		{name: "unterminated tag", headers: []string{`"3`}, wantErr: errs.ErrBadRequest},
+		{name: "missing comma", headers: []string{`"3" "4"`}, wantErr: errs.ErrBadRequest},
+		{name: "wildcard in list", headers: []string{`*, "3"`}, wantErr: errs.ErrBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/", nil)
+			for _, value := range tt.headers {
+				req.Header.Add("If-Match", value)
+			}
+
+			got, err := ifMatchVersion(req)
+			switch {
+			case tt.wantErr == errs.ErrBadRequest:
+				if !errs.IsValidationError(err) {
+					t.Fatalf("ifMatchVersion() error = %v, want a validation error", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ifMatchVersion() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("ifMatchVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ifMatchVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "absent"},
+		{name: "empty", query: "?fields="},
+		{name: "single", query: "?fields=username", want: []string{"username"}},
+		{name: "trims and skips blanks", query: "?fields=username,%20role%20,,is_active", want: []string{"username", "role", "is_active"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users"+tt.query, nil)
+			if got := queryFields(req); !slices.Equal(got, tt.want) {
+				t.Errorf("queryFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type RoleElevationHandler struct {
+	service interfaces.IRoleElevationService
+}
+
+func NewRoleElevationHandler(service interfaces.IRoleElevationService) *RoleElevationHandler {
+	return &RoleElevationHandler{service: service}
+}
+
+func (h *RoleElevationHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(account.PermissionRolesRead)
+	elevate := middleware.RequireScope(account.PermissionRolesElevate)
+	approve := middleware.RequireScope(account.PermissionRolesApprove)
+
+	mux.Handle("POST /users/{id}/role-elevations", elevate(http.HandlerFunc(h.Request)))
+	mux.Handle("GET /role-elevations", read(http.HandlerFunc(h.FindAll)))
+	mux.Handle("GET /role-elevations/{id}", read(http.HandlerFunc(h.FindByID)))
+	mux.Handle("POST /role-elevations/{id}/approve", approve(http.HandlerFunc(h.Approve)))
+	mux.Handle("POST /role-elevations/{id}/reject", approve(http.HandlerFunc(h.Reject)))
+}
+
+/**
+ * Request grants a role to the user in the path for duration_seconds,
+ * either immediately or pending a second admin's approval.
+ */
+func (h *RoleElevationHandler) Request(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload account.RequestRoleElevationRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	elevation, err := h.service.Request(r.Context(), userID, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, elevation)
+}
+
+/**
+ * FindAll lists elevations, optionally filtered by user_id and status.
+ */
+func (h *RoleElevationHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	filter := &account.RoleElevationFilter{
+		PaginationFilter: *paginationFilter(r),
+		UserID:           r.URL.Query().Get("user_id"),
+		Status:           account.ElevationStatus(r.URL.Query().Get("status")),
+	}
+
+	elevations, totalItems, err := h.service.FindAll(r.Context(), filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, elevations, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *RoleElevationHandler) FindByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	elevation, err := h.service.FindByID(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, elevation)
+}
+
+func (h *RoleElevationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	elevation, err := h.service.Approve(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, elevation)
+}
+
+func (h *RoleElevationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	elevation, err := h.service.Reject(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, elevation)
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type RoleHandler struct {
+	service interfaces.IRoleService
+}
+
+func NewRoleHandler(service interfaces.IRoleService) *RoleHandler {
+	return &RoleHandler{service: service}
+}
+
+// RegisterRoutes mendaftarkan route role. GET /users/{id}/roles hanya
+// mewajibkan login karena user boleh melihat role miliknya sendiri.
+func (h *RoleHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(account.PermissionRolesRead)
+	write := middleware.RequireScope(account.PermissionRolesWrite)
+	remove := middleware.RequireScope(account.PermissionRolesDelete)
+	assign := middleware.RequireScope(account.PermissionRolesAssign)
+
+	mux.Handle("GET /roles", read(http.HandlerFunc(h.FindAll)))
+	mux.Handle("GET /permissions", read(http.HandlerFunc(h.Catalog)))
+	mux.Handle("GET /roles/{id}", read(http.HandlerFunc(h.FindById)))
+	mux.Handle("GET /roles/{id}/permissions", read(http.HandlerFunc(h.EffectivePermissions)))
+	mux.Handle("POST /roles", write(http.HandlerFunc(h.Create)))
+	mux.Handle("PUT /roles/{id}", write(http.HandlerFunc(h.Update)))
+	mux.Handle("DELETE /roles/{id}", remove(http.HandlerFunc(h.Delete)))
+	mux.Handle("PUT /roles/{id}/users/{userId}", assign(http.HandlerFunc(h.AssignUser)))
+	mux.Handle("DELETE /roles/{id}/users/{userId}", assign(http.HandlerFunc(h.UnassignUser)))
+	mux.Handle("GET /users/{id}/roles", middleware.RequireAuth(http.HandlerFunc(h.FindUserRoles)))
+}
+
+func (h *RoleHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	filter := paginationFilter(r)
+
+	roles, totalItems, err := h.service.FindAll(r.Context(), filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, roles, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *RoleHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	role, err := h.service.FindById(r.Context(), id.String())
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(role.Version))
+	response.JSON(w, http.StatusOK, role)
+}
+
+func (h *RoleHandler) Catalog(w http.ResponseWriter, r *http.Request) {
+	catalog, err := h.service.Catalog(r.Context())
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, catalog)
+}
+
+func (h *RoleHandler) EffectivePermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	permissions, err := h.service.EffectivePermissions(r.Context(), id.String())
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, permissions)
+}
+
+func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var payload account.CreateRoleRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Create(r.Context(), &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload account.UpdateRoleRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+	if version != 0 {
+		payload.Version = version
+	}
+
+	if err := h.service.Update(r.Context(), id.String(), &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	role, err := h.service.FindById(r.Context(), id.String())
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(role.Version))
+	response.JSON(w, http.StatusOK, role)
+}
+
+func (h *RoleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id.String()); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *RoleHandler) AssignUser(w http.ResponseWriter, r *http.Request) {
+	roleID, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	userID, err := pathID(r, "userId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	// body opsional, tanpa body assignment tidak kedaluwarsa
+	var payload account.AssignRoleRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(r, &payload); err != nil {
+			response.Error(w, err)
+			return
+		}
+	}
+
+	if err := h.service.AssignUser(r.Context(), userID.String(), roleID.String(), &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *RoleHandler) UnassignUser(w http.ResponseWriter, r *http.Request) {
+	roleID, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	userID, err := pathID(r, "userId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.UnassignUser(r.Context(), userID.String(), roleID.String()); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *RoleHandler) FindUserRoles(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	roles, err := h.service.FindUserRoles(r.Context(), userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, roles)
+}
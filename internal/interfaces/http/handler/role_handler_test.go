@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+// updateRoles mencatat versi yang diteruskan handler dan mengembalikan err
+type updateRoles struct {
+	interfaces.IRoleService
+	err     error
+	version int64
+}
+
+func (u *updateRoles) Update(ctx context.Context, id string, role *account.UpdateRoleRequest) error {
+	u.version = role.Version
+	return u.err
+}
+
+func (u *updateRoles) FindById(ctx context.Context, id string) (*account.RoleResponse, error) {
+	return &account.RoleResponse{Version: u.version + 1}, nil
+}
+
+func TestRoleHandlerUpdateStatus(t *testing.T) {
+	id := identity.New()
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		err         error
+		want        int
+		wantVersion int64
+	}{
+		{name: "updated", ifMatch: `"3"`, want: http.StatusOK, wantVersion: 3},
+		{name: "without precondition", want: http.StatusOK},
+		{name: "stale If-Match", ifMatch: `"2"`, err: errs.VersionConflictError{Resource: "role", ID: id, Expected: 2, Actual: 3}, want: http.StatusPreconditionFailed, wantVersion: 2},
+		{name: "weak If-Match", ifMatch: `W/"3"`, want: http.StatusPreconditionFailed},
+		{name: "malformed If-Match", ifMatch: `3`, want: http.StatusBadRequest},
+		{name: "duplicate name", err: fmt.Errorf("role name taken: %w", errs.ErrConflict), want: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &updateRoles{err: tt.err}
+			handler := NewRoleHandler(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/roles/"+id.String(), strings.NewReader(`{"name":"reader"}`))
+			req.SetPathValue("id", id.String())
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			handler.Update(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+			if service.version != tt.wantVersion {
+				t.Errorf("version passed to service = %d, want %d", service.version, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type SessionHandler struct {
+	service interfaces.ISessionService
+}
+
+func NewSessionHandler(service interfaces.ISessionService) *SessionHandler {
+	return &SessionHandler{service: service}
+}
+
+// RegisterRoutes mendaftarkan route /me untuk session milik sendiri dan route
+// /users/{id} untuk admin. Service memeriksa permission untuk user lain.
+func (h *SessionHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(account.PermissionSessionsRead)
+	revoke := middleware.RequireScope(account.PermissionSessionsRevoke)
+
+	mux.Handle("GET /me/sessions", read(http.HandlerFunc(h.List)))
+	mux.Handle("DELETE /me/sessions/{sessionId}", revoke(http.HandlerFunc(h.Revoke)))
+	mux.Handle("DELETE /me/sessions", revoke(http.HandlerFunc(h.RevokeAll)))
+	mux.Handle("GET /users/{id}/sessions", read(http.HandlerFunc(h.List)))
+	mux.Handle("DELETE /users/{id}/sessions/{sessionId}", revoke(http.HandlerFunc(h.Revoke)))
+	mux.Handle("DELETE /users/{id}/sessions", revoke(http.HandlerFunc(h.RevokeAll)))
+}
+
+/**
+ * List returns the devices a user is signed in on.
+ */
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := targetUserID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	sessions, err := h.service.List(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sessions)
+}
+
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := targetUserID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	sessionID, err := pathID(r, "sessionId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), id, sessionID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+/**
+ * RevokeAll signs the user out of every session.
+ */
+func (h *SessionHandler) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	id, err := targetUserID(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.RevokeAll(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
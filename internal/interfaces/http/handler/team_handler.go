@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type TeamHandler struct {
+	service interfaces.ITeamService
+}
+
+func NewTeamHandler(service interfaces.ITeamService) *TeamHandler {
+	return &TeamHandler{service: service}
+}
+
+// RegisterRoutes mewajibkan login pada semua route, akses per organization
+// diputuskan service berdasarkan keanggotaan actor
+func (h *TeamHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("GET /organizations/{id}/teams", middleware.RequireAuth(http.HandlerFunc(h.FindAll)))
+	mux.Handle("POST /organizations/{id}/teams", middleware.RequireAuth(http.HandlerFunc(h.Create)))
+	mux.Handle("GET /teams/{id}", middleware.RequireAuth(http.HandlerFunc(h.FindByID)))
+	mux.Handle("PUT /teams/{id}", middleware.RequireAuth(http.HandlerFunc(h.Update)))
+	mux.Handle("DELETE /teams/{id}", middleware.RequireAuth(http.HandlerFunc(h.Delete)))
+	mux.Handle("GET /teams/{id}/members", middleware.RequireAuth(http.HandlerFunc(h.FindMembers)))
+	mux.Handle("PUT /teams/{id}/members/{userId}", middleware.RequireAuth(http.HandlerFunc(h.SetMember)))
+	mux.Handle("DELETE /teams/{id}/members/{userId}", middleware.RequireAuth(http.HandlerFunc(h.RemoveMember)))
+}
+
+func (h *TeamHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	filter := paginationFilter(r)
+	teams, totalItems, err := h.service.FindAll(r.Context(), organizationID, filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, teams, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *TeamHandler) Create(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload organization.CreateTeamRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	team, err := h.service.Create(r.Context(), organizationID, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, team)
+}
+
+func (h *TeamHandler) FindByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	team, err := h.service.FindByID(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, team)
+}
+
+func (h *TeamHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload organization.UpdateTeamRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	team, err := h.service.Update(r.Context(), id, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, team)
+}
+
+func (h *TeamHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *TeamHandler) FindMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	filter := paginationFilter(r)
+	members, totalItems, err := h.service.FindMembers(r.Context(), id, filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, members, filter.Page, filter.Limit, totalItems)
+}
+
+/**
+ * SetMember adds an organization member to the team or changes their team role.
+ */
+func (h *TeamHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	userID, err := pathID(r, "userId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload organization.SetMemberRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	member, err := h.service.SetMember(r.Context(), id, userID, &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, member)
+}
+
+func (h *TeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	userID, err := pathID(r, "userId")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), id, userID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
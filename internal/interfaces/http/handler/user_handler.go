@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type UserHandler struct {
+	service interfaces.IUserService
+}
+
+func NewUserHandler(service interfaces.IUserService) *UserHandler {
+	return &UserHandler{service: service}
+}
+
+// RegisterRoutes mendaftarkan route user. GET /users/{id} dan Update hanya
+// mewajibkan login karena user boleh melihat dan mengubah profilnya sendiri,
+// policy diperiksa oleh service.
+func (h *UserHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(account.PermissionUsersRead)
+	write := middleware.RequireScope(account.PermissionUsersWrite)
+	remove := middleware.RequireScope(account.PermissionUsersDelete)
+	unlock := middleware.RequireScope(account.PermissionUsersUnlock)
+
+	mux.Handle("GET /users", read(http.HandlerFunc(h.GetAll)))
+	mux.Handle("GET /users/{id}", middleware.RequireAuth(http.HandlerFunc(h.GetByID)))
+	mux.Handle("GET /roles/{id}/users", read(http.HandlerFunc(h.GetAllByRole)))
+	mux.Handle("POST /users", write(http.HandlerFunc(h.Create)))
+	mux.Handle("PUT /users/{id}", middleware.RequireAuth(http.HandlerFunc(h.Update)))
+	mux.Handle("DELETE /users/{id}", remove(http.HandlerFunc(h.Delete)))
+	mux.Handle("POST /users/{id}/deactivate", remove(http.HandlerFunc(h.Deactivate)))
+	mux.Handle("POST /users/{id}/unlock", unlock(http.HandlerFunc(h.Unlock)))
+}
+
+/**
+ * GetAll lists users using the search, limit, page, sort and fields query
+ * parameters.
+ */
+func (h *UserHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter := paginationFilter(r)
+
+	users, totalItems, err := h.service.GetAll(r.Context(), filter.Search, filter.Limit, filter.Page, filter.Sort, queryFields(r))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, users, filter.Page, filter.Limit, totalItems)
+}
+
+/**
+ * GetAllByRole lists users holding the role in the path, either as primary
+ * role or through an assignment, with the same query parameters as GetAll.
+ */
+func (h *UserHandler) GetAllByRole(w http.ResponseWriter, r *http.Request) {
+	roleID, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	filter := paginationFilter(r)
+
+	users, totalItems, err := h.service.GetAllByRole(r.Context(), roleID, filter.Search, filter.Limit, filter.Page, filter.Sort, queryFields(r))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, users, filter.Page, filter.Limit, totalItems)
+}
+
+/**
+ * GetByID returns a single user and its current version as ETag.
+ */
+func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	user, err := h.service.GetByID(r.Context(), id, queryFields(r))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(user.Version))
+	response.JSON(w, http.StatusOK, user)
+}
+
+/**
+ * Create registers a new user.
+ */
+func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var payload account.CreateUserRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Create(r.Context(), &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+/**
+ * Update modifies a user. When If-Match is sent the update only succeeds
+ * if the user is still at that version, otherwise 412 is returned.
+ */
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload account.UpdateUserRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+	if version != 0 {
+		payload.Version = version
+	}
+
+	if err := h.service.Update(r.Context(), id, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	user, err := h.service.GetByID(r.Context(), id, nil)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(user.Version))
+	response.JSON(w, http.StatusOK, user)
+}
+
+/**
+ * Deactivate marks a user as inactive.
+ */
+func (h *UserHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Deactivate(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+/**
+ * Unlock clears the login lockout of a user.
+ */
+func (h *UserHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Unlock(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+/**
+ * Delete removes a user.
+ */
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/middleware"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+)
+
+type WebhookHandler struct {
+	service interfaces.IWebhookService
+}
+
+func NewWebhookHandler(service interfaces.IWebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) RegisterRoutes(mux *http.ServeMux) {
+	read := middleware.RequireScope(webhook.PermissionWebhooksRead)
+	write := middleware.RequireScope(webhook.PermissionWebhooksWrite)
+
+	mux.Handle("GET /webhooks/subscriptions", read(http.HandlerFunc(h.ListSubscriptions)))
+	mux.Handle("GET /webhooks/subscriptions/{id}", read(http.HandlerFunc(h.GetSubscription)))
+	mux.Handle("POST /webhooks/subscriptions", write(http.HandlerFunc(h.CreateSubscription)))
+	mux.Handle("PUT /webhooks/subscriptions/{id}", write(http.HandlerFunc(h.UpdateSubscription)))
+	mux.Handle("DELETE /webhooks/subscriptions/{id}", write(http.HandlerFunc(h.DeleteSubscription)))
+	mux.Handle("GET /webhooks/subscriptions/{id}/deliveries", read(http.HandlerFunc(h.ListDeliveries)))
+	mux.Handle("GET /webhooks/deliveries/{id}", read(http.HandlerFunc(h.GetDelivery)))
+	mux.Handle("POST /webhooks/deliveries/{id}/redeliver", write(http.HandlerFunc(h.Redeliver)))
+}
+
+func (h *WebhookHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	filter := paginationFilter(r)
+
+	subscriptions, totalItems, err := h.service.ListSubscriptions(r.Context(), filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, subscriptions, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *WebhookHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	subscription, err := h.service.GetSubscription(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, subscription)
+}
+
+/**
+ * CreateSubscription registers an endpoint and returns its signing secret once.
+ */
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var payload webhook.CreateSubscriptionRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	subscription, err := h.service.CreateSubscription(r.Context(), &payload)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, subscription)
+}
+
+func (h *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	var payload webhook.UpdateSubscriptionRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.UpdateSubscription(r.Context(), id, &payload); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *WebhookHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.service.DeleteSubscription(r.Context(), id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+/**
+ * ListDeliveries returns the delivery history of a subscription, optionally
+ * filtered by status.
+ */
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	filter := &webhook.DeliveryFilter{
+		PaginationFilter: *paginationFilter(r),
+		SubscriptionID:   id.String(),
+		Status:           webhook.DeliveryStatus(r.URL.Query().Get("status")),
+	}
+
+	deliveries, totalItems, err := h.service.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Paginated(w, deliveries, filter.Page, filter.Limit, totalItems)
+}
+
+func (h *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	delivery, err := h.service.GetDelivery(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, delivery)
+}
+
+/**
+ * Redeliver queues a fresh attempt of a past delivery.
+ */
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, delivery)
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account/interfaces"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/interfaces/http/response"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+var errAuthenticationRequired = fmt.Errorf("authentication required: %w", errs.ErrUnauthorized)
+
+// Authenticate membaca access token JWT atau API key dan menyimpan actor serta
+// scope ke context. Request tanpa kredensial diteruskan apa adanya, gunakan
+// RequireAuth untuk route yang wajib login. Tenant milik kredensial menimpa
+// tenant dari host request.
+func Authenticate(tokens interfaces.IAccessTokenVerifier, apiKeys interfaces.IAPIKeyService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			credential, isAPIKey := credentialFrom(r)
+			if credential == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var (
+				principal *account.Principal
+				err       error
+			)
+			if isAPIKey {
+				principal, err = apiKeys.Authenticate(r.Context(), credential)
+			} else {
+				principal, err = tokens.Verify(r.Context(), credential)
+			}
+			if err != nil {
+				response.Error(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(withPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// RequireAuth menolak request yang belum terautentikasi
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := requestctx.ActorID(r.Context()); !ok {
+			response.Error(w, errAuthenticationRequired)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireScope menolak API key yang tidak memiliki scope tersebut. Access
+// token login tidak dibatasi scope sehingga selalu diteruskan.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if scopes, restricted := requestctx.Scopes(r.Context()); restricted && !slices.Contains(scopes, scope) {
+				response.Error(w, fmt.Errorf("missing scope '%s': %w", scope, errs.ErrForbidden))
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// credentialFrom mengambil kredensial dari header X-API-Key atau
+// Authorization: Bearer. Bearer berawalan ak_ diperlakukan sebagai API key.
+func credentialFrom(r *http.Request) (credential string, isAPIKey bool) {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key, true
+	}
+
+	scheme, value, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	value = strings.TrimSpace(value)
+	return value, account.IsAPIKey(value)
+}
+
+func withPrincipal(ctx context.Context, principal *account.Principal) context.Context {
+	ctx = requestctx.WithActorID(ctx, principal.UserID)
+	if !principal.SessionID.IsNil() {
+		ctx = requestctx.WithSessionID(ctx, principal.SessionID)
+	}
+	if principal.Scopes != nil {
+		ctx = requestctx.WithScopes(ctx, principal.Scopes)
+	}
+	if !principal.TenantID.IsNil() {
+		ctx = requestctx.WithTenantID(ctx, principal.TenantID)
+	}
+	return ctx
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// ClientIP menyimpan IP client ke context. trustedProxies adalah jumlah
+// reverse proxy di depan aplikasi, 0 berarti X-Forwarded-For diabaikan.
+// Setiap proxy menambahkan alamat peer-nya di kanan header, jadi IP client
+// adalah entri ke-trustedProxies dari kanan. Entri di kirinya bisa dipalsukan
+// client dan tidak pernah dipakai.
+func ClientIP(trustedProxies int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := remoteIP(r.RemoteAddr)
+			if trustedProxies > 0 {
+				if forwarded := forwardedIP(r, trustedProxies); forwarded != "" {
+					ip = forwarded
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(requestctx.WithClientIP(r.Context(), ip)))
+		})
+	}
+}
+
+// forwardedIP mengambil entri X-Forwarded-For yang ditambahkan proxy terluar.
+// Rantai yang lebih pendek dari jumlah proxy berarti entri paling kiri pun
+// ditulis proxy tepercaya.
+func forwardedIP(r *http.Request, trustedProxies int) string {
+	entries := make([]string, 0)
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, entry := range strings.Split(header, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+
+	index := max(len(entries)-trustedProxies, 0)
+	parsed := net.ParseIP(entries[index])
+	if parsed == nil {
+		return ""
+	}
+	return parsed.String()
+}
+
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies int
+		remoteAddr     string
+		forwarded      []string
+		want           string
+	}{
+		{name: "no proxy ignores header", trustedProxies: 0, remoteAddr: "203.0.113.7:5000", forwarded: []string{"198.51.100.1"}, want: "203.0.113.7"},
+		{name: "one proxy", trustedProxies: 1, remoteAddr: "10.0.0.2:5000", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "spoofed entry on the left is skipped", trustedProxies: 1, remoteAddr: "10.0.0.2:5000", forwarded: []string{"1.2.3.4, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "two proxies", trustedProxies: 2, remoteAddr: "10.0.0.3:5000", forwarded: []string{"1.2.3.4, 198.51.100.1, 10.0.0.2"}, want: "198.51.100.1"},
+		{name: "repeated headers", trustedProxies: 2, remoteAddr: "10.0.0.3:5000", forwarded: []string{"1.2.3.4, 198.51.100.1", "10.0.0.2"}, want: "198.51.100.1"},
+		{name: "shorter chain than proxies", trustedProxies: 3, remoteAddr: "10.0.0.3:5000", forwarded: []string{"198.51.100.1, 10.0.0.2"}, want: "198.51.100.1"},
+		{name: "invalid entry falls back to peer", trustedProxies: 1, remoteAddr: "10.0.0.2:5000", forwarded: []string{"unknown"}, want: "10.0.0.2"},
+		{name: "no header", trustedProxies: 1, remoteAddr: "10.0.0.2:5000", want: "10.0.0.2"},
+		{name: "ipv6", trustedProxies: 1, remoteAddr: "[::1]:5000", forwarded: []string{"2001:db8::1"}, want: "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := ClientIP(tt.trustedProxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = requestctx.ClientIP(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != tt.want {
+				t.Errorf("client ip = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// Tenant menyimpan tenant milik host request ke context. hosts memetakan host
+// atau subdomain yang dikonfigurasi server ke tenant, misalnya
+// "acme.example.com"; host yang tidak terdaftar memakai defaultTenant
+// (deployment satu tenant) atau berjalan tanpa tenant sehingga repository
+// menolak query-nya. Client tidak bisa memilih tenant lain di luar host yang
+// ia akses. Pasang sebelum Authenticate; tenant milik kredensial yang
+// terautentikasi menimpa nilai ini.
+func Tenant(hosts map[string]identity.ID, defaultTenant identity.ID) func(http.Handler) http.Handler {
+	normalized := make(map[string]identity.ID, len(hosts))
+	for host, tenantID := range hosts {
+		normalized[normalizeHost(host)] = tenantID
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID, ok := normalized[normalizeHost(r.Host)]
+			if !ok {
+				tenantID = defaultTenant
+			}
+
+			ctx := r.Context()
+			if !tenantID.IsNil() {
+				ctx = requestctx.WithTenantID(ctx, tenantID)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// normalizeHost membuang port dan titik di akhir serta mengecilkan huruf
+func normalizeHost(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
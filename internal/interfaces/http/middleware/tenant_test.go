@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+func TestTenant(t *testing.T) {
+	acme, globex, fallback := identity.New(), identity.New(), identity.New()
+	hosts := map[string]identity.ID{"acme.example.com": acme, "Globex.Example.com": globex}
+
+	tests := []struct {
+		name          string
+		host          string
+		header        string
+		defaultTenant identity.ID
+		want          identity.ID
+	}{
+		{name: "mapped subdomain", host: "acme.example.com", want: acme},
+		{name: "host with port", host: "acme.example.com:8443", want: acme},
+		{name: "case and trailing dot", host: "GLOBEX.example.com.", want: globex},
+		{name: "header is ignored", host: "acme.example.com", header: globex.String(), want: acme},
+		{name: "unknown host uses default", host: "other.example.com", header: acme.String(), defaultTenant: fallback, want: fallback},
+		{name: "unknown host without default", host: "other.example.com", header: acme.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got identity.ID
+			handler := Tenant(hosts, tt.defaultTenant)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = requestctx.TenantID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("tenant = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/requestctx"
+)
+
+// maxUserAgentLength membatasi User-Agent yang disimpan ke session
+const maxUserAgentLength = 512
+
+// UserAgent menyimpan header User-Agent ke context
+func UserAgent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent := r.UserAgent()
+		if len(userAgent) > maxUserAgentLength {
+			userAgent = userAgent[:maxUserAgentLength]
+		}
+
+		next.ServeHTTP(w, r.WithContext(requestctx.WithUserAgent(r.Context(), userAgent)))
+	})
+}
@@ -0,0 +1,82 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+type (
+	Body struct {
+		Data  interface{} `json:"data,omitempty"`
+		Meta  *Meta       `json:"meta,omitempty"`
+		Error string      `json:"error,omitempty"`
+	}
+
+	Meta struct {
+		Page       int   `json:"page"`
+		Limit      int   `json:"limit"`
+		TotalItems int64 `json:"total_items"`
+	}
+)
+
+func JSON(w http.ResponseWriter, status int, data interface{}) {
+	write(w, status, Body{Data: data})
+}
+
+// Raw menulis data tanpa envelope Body, untuk response yang formatnya
+// ditentukan spesifikasi eksternal seperti OAuth dan OpenID Connect
+func Raw(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func Paginated(w http.ResponseWriter, data interface{}, page int, limit int, totalItems int64) {
+	write(w, http.StatusOK, Body{Data: data, Meta: &Meta{Page: page, Limit: limit, TotalItems: totalItems}})
+}
+
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Error memetakan error domain ke status HTTP
+func Error(w http.ResponseWriter, err error) {
+	status := StatusFor(err)
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		message = errs.ErrInternal.Error()
+	}
+
+	write(w, status, Body{Error: message})
+}
+
+func StatusFor(err error) int {
+	switch {
+	case errs.IsVersionConflict(err), errors.Is(err, errs.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errs.IsNotFound(err):
+		return http.StatusNotFound
+	case errs.IsValidationError(err), errors.Is(err, errs.ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, errs.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, errs.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errs.ErrTooManyRequests):
+		return http.StatusTooManyRequests
+	case errors.Is(err, errs.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func write(w http.ResponseWriter, status int, body Body) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+func TestStatusFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "version conflict", err: errs.VersionConflictError{Resource: "user", ID: 1, Expected: 2, Actual: 3}, want: http.StatusPreconditionFailed},
+		{name: "wrapped version conflict", err: fmt.Errorf("update: %w", errs.VersionConflictError{Resource: "role"}), want: http.StatusPreconditionFailed},
+		{name: "precondition failed", err: fmt.Errorf("If-Match: %w", errs.ErrPreconditionFailed), want: http.StatusPreconditionFailed},
+		{name: "conflict", err: fmt.Errorf("duplicate: %w", errs.ErrConflict), want: http.StatusConflict},
+		{name: "not found", err: errs.ErrNotFound, want: http.StatusNotFound},
+		{name: "validation", err: errs.ValidationError{Field: "name"}, want: http.StatusBadRequest},
+		{name: "unauthorized", err: errs.ErrUnauthorized, want: http.StatusUnauthorized},
+		{name: "forbidden", err: errs.ErrForbidden, want: http.StatusForbidden},
+		{name: "too many requests", err: errs.ErrTooManyRequests, want: http.StatusTooManyRequests},
+		{name: "unknown", err: errors.New("boom"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusFor(tt.err); got != tt.want {
+				t.Errorf("StatusFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,11 +7,14 @@ import (
 
 // Sentinel Errors (konstanta sederhana)
 var (
-	ErrNotFound     = errors.New("not found")
-	ErrUnauthorized = errors.New("unauthorized")
-	ErrBadRequest   = errors.New("bad request")
-	ErrInternal     = errors.New("internal server error")
-	ErrConflict     = errors.New("conflict")
+	ErrNotFound           = errors.New("not found")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrBadRequest         = errors.New("bad request")
+	ErrInternal           = errors.New("internal server error")
+	ErrConflict           = errors.New("conflict")
+	ErrForbidden          = errors.New("forbidden")
+	ErrTooManyRequests    = errors.New("too many requests")
+	ErrPreconditionFailed = errors.New("precondition failed")
 )
 
 // NotFoundError dengan informasi sumber daya dan ID
@@ -42,6 +45,26 @@ func IsValidationError(err error) bool {
 	return errors.As(err, &ValidationError{})
 }
 
+// VersionConflictError dengan informasi versi yang diharapkan dan versi saat ini
+type VersionConflictError struct {
+	Resource string
+	ID       interface{}
+	Expected int64
+	Actual   int64
+}
+
+func (e VersionConflictError) Error() string {
+	return fmt.Sprintf("%s with ID '%v' has been modified (expected version %d, current version %d)", e.Resource, e.ID, e.Expected, e.Actual)
+}
+
+func (e VersionConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+func IsVersionConflict(err error) bool {
+	return errors.As(err, &VersionConflictError{})
+}
+
 // InternalError dengan kode dan error asli
 type InternalError struct {
 	Code    string
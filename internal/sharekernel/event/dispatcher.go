@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"sync"
+)
+
+type IDispatcher interface {
+	Dispatch(ctx context.Context, events ...Event)
+}
+
+type handler func(ctx context.Context, e Event) error
+
+// Dispatcher mengirim event ke subscriber secara in-process
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]handler
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: make(map[reflect.Type][]handler)}
+}
+
+// Subscribe mendaftarkan handler untuk satu tipe event
+func Subscribe[T Event](d *Dispatcher, fn func(ctx context.Context, e T) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	eventType := reflect.TypeFor[T]()
+	d.handlers[eventType] = append(d.handlers[eventType], func(ctx context.Context, e Event) error {
+		return fn(ctx, e.(T))
+	})
+}
+
+// Dispatch dipanggil setelah perubahan berhasil di-commit. Error dari subscriber
+// hanya di-log karena perubahan aggregate sudah tersimpan.
+func (d *Dispatcher) Dispatch(ctx context.Context, events ...Event) {
+	for _, e := range events {
+		d.mu.RLock()
+		handlers := d.handlers[reflect.TypeOf(e)]
+		d.mu.RUnlock()
+
+		for _, h := range handlers {
+			if err := h(ctx, e); err != nil {
+				slog.ErrorContext(ctx, "event subscriber failed",
+					slog.String("event", e.EventName()),
+					slog.String("event_id", e.EventID().String()),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	"time"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type Event interface {
+	EventID() identity.ID
+	EventName() string
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+// Base berisi metadata yang dimiliki setiap domain event
+type Base struct {
+	ID       identity.ID `json:"event_id"`
+	Occurred time.Time   `json:"occurred_at"`
+}
+
+func NewBase() Base {
+	return Base{ID: identity.New(), Occurred: time.Now()}
+}
+
+func (b Base) EventID() identity.ID {
+	return b.ID
+}
+
+func (b Base) OccurredAt() time.Time {
+	return b.Occurred
+}
+
+// Recorder di-embed pada aggregate untuk menampung event sampai di-flush
+type Recorder struct {
+	events []Event
+}
+
+func (r *Recorder) Record(e Event) {
+	r.events = append(r.events, e)
+}
+
+// PullEvents mengembalikan event yang tercatat dan mengosongkan recorder
+func (r *Recorder) PullEvents() []Event {
+	events := r.events
+	r.events = nil
+	return events
+}
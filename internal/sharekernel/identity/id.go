@@ -1,5 +1,70 @@
 package identity
 
-import "github.com/google/uuid"
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/google/uuid"
+)
 
 type ID uuid.UUID
+
+func New() ID {
+	return ID(uuid.New())
+}
+
+func Parse(s string) (ID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return ID(uuid.Nil), err
+	}
+	return ID(id), nil
+}
+
+func (id ID) String() string {
+	return uuid.UUID(id).String()
+}
+
+func (id ID) IsNil() bool {
+	return uuid.UUID(id) == uuid.Nil
+}
+
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *ID) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*id = ID(uuid.Nil)
+		return nil
+	}
+
+	parsed, err := uuid.ParseBytes(data)
+	if err != nil {
+		return err
+	}
+	*id = ID(parsed)
+	return nil
+}
+
+// Value menyimpan ID kosong sebagai NULL
+func (id ID) Value() (driver.Value, error) {
+	if id.IsNil() {
+		return nil, nil
+	}
+	return id.String(), nil
+}
+
+func (id *ID) Scan(src interface{}) error {
+	if src == nil {
+		*id = ID(uuid.Nil)
+		return nil
+	}
+
+	var parsed uuid.UUID
+	if err := parsed.Scan(src); err != nil {
+		return fmt.Errorf("identity: %w", err)
+	}
+	*id = ID(parsed)
+	return nil
+}
@@ -0,0 +1,110 @@
+package otp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret adalah secret SHA-1 dari lampiran B RFC 6238 dalam base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(time.Unix(tt.unix, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+			if err != nil {
+				t.Fatalf("Code() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Code() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+	code := func(step int64) string {
+		c, err := Code(rfcSecret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		skew     int
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", secret: rfcSecret, code: code(current), skew: 1, wantStep: current, wantOK: true},
+		{name: "previous step within skew", secret: rfcSecret, code: code(current - 1), skew: 1, wantStep: current - 1, wantOK: true},
+		{name: "next step within skew", secret: rfcSecret, code: code(current + 1), skew: 1, wantStep: current + 1, wantOK: true},
+		{name: "outside skew", secret: rfcSecret, code: code(current - 2), skew: 1},
+		{name: "no skew", secret: rfcSecret, code: code(current - 1), skew: 0},
+		{name: "lowercase secret", secret: strings.ToLower(rfcSecret), code: code(current), skew: 0, wantStep: current, wantOK: true},
+		{name: "wrong length", secret: rfcSecret, code: code(current)[:5], skew: 1},
+		{name: "invalid secret", secret: "not base32!", code: "123456", skew: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(tt.secret, tt.code, now, tt.skew)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("Validate() = %d, %v, want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	second, _ := GenerateSecret()
+	if first == second {
+		t.Error("GenerateSecret() returned the same secret twice")
+	}
+	if _, err := Code(first, 1); err != nil {
+		t.Errorf("generated secret is not usable: %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Acme Corp", "alice@example.com", rfcSecret)
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("URI() is not a valid URL: %v", err)
+	}
+	if parsed.Scheme != "otpauth" || parsed.Host != "totp" {
+		t.Errorf("URI() = %s, want otpauth://totp/...", uri)
+	}
+	if parsed.Path != "/Acme Corp:alice@example.com" {
+		t.Errorf("URI() label = %q", parsed.Path)
+	}
+
+	query := parsed.Query()
+	if query.Get("secret") != rfcSecret || query.Get("issuer") != "Acme Corp" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("URI() query = %v", query)
+	}
+}
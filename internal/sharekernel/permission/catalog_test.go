@@ -0,0 +1,45 @@
+package permission_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/account"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/audit"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/oauth"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/organization"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/domain/webhook"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/permission"
+)
+
+// TestCatalog memastikan katalog seluruh modul bisa didaftarkan bersama dan
+// setiap permission memakai format group:action
+func TestCatalog(t *testing.T) {
+	modules := map[string][]permission.Definition{
+		"account":      account.Permissions,
+		"audit":        audit.Permissions,
+		"oauth":        oauth.Permissions,
+		"organization": organization.Permissions,
+		"webhook":      webhook.Permissions,
+	}
+
+	registry := permission.NewRegistry()
+	for name, definitions := range modules {
+		if err := registry.Register(definitions...); err != nil {
+			t.Fatalf("registering the %s catalog: %v", name, err)
+		}
+	}
+
+	for _, definition := range registry.All() {
+		group, action, ok := strings.Cut(definition.Key, ":")
+		if !ok || action == "" {
+			t.Errorf("%s is not in group:action form", definition.Key)
+		}
+		if group != definition.Group {
+			t.Errorf("%s is in group %q, want %q", definition.Key, definition.Group, group)
+		}
+		if definition.Description == "" {
+			t.Errorf("%s has no description", definition.Key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package permission
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+// Definition mendeskripsikan satu permission yang dideklarasikan sebuah modul
+type Definition struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	Group       string `json:"group"`
+}
+
+// Registry menampung katalog permission dari seluruh modul
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: make(map[string]Definition),
+	}
+}
+
+// Register menambahkan permission ke katalog; key yang sudah terdaftar ditolak
+func (r *Registry) Register(definitions ...Definition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(definitions))
+	for _, definition := range definitions {
+		if definition.Key == "" {
+			return fmt.Errorf("permission key is required: %w", errs.ErrBadRequest)
+		}
+		_, registered := r.definitions[definition.Key]
+		_, repeated := seen[definition.Key]
+		if registered || repeated {
+			return fmt.Errorf("permission '%s' already registered: %w", definition.Key, errs.ErrConflict)
+		}
+		seen[definition.Key] = struct{}{}
+	}
+	for _, definition := range definitions {
+		r.definitions[definition.Key] = definition
+	}
+	return nil
+}
+
+// MustRegister dipakai saat wiring aplikasi, di mana duplikasi adalah bug
+func (r *Registry) MustRegister(definitions ...Definition) {
+	if err := r.Register(definitions...); err != nil {
+		panic(err)
+	}
+}
+
+func (r *Registry) Has(key string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.definitions[key]
+	return ok
+}
+
+// All mengembalikan katalog terurut berdasarkan group lalu key
+func (r *Registry) All() []Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Definition, 0, len(r.definitions))
+	for _, definition := range r.definitions {
+		result = append(result, definition)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Group != result[j].Group {
+			return result[i].Group < result[j].Group
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// Validate menolak permission yang tidak ada di katalog
+func (r *Registry) Validate(keys []string) error {
+	unknown := make([]string, 0)
+	for _, key := range keys {
+		if !r.Has(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		return errs.ValidationError{Field: "permissions", Message: "unknown permission(s): " + strings.Join(unknown, ", ")}
+	}
+	return nil
+}
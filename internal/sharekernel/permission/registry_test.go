@@ -0,0 +1,105 @@
+package permission
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+)
+
+func TestRegistryRegister(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []Definition
+		register []Definition
+		wantErr  error
+		wantKeys []string
+	}{
+		{name: "new keys", register: []Definition{{Key: "a:read"}, {Key: "a:write"}}, wantKeys: []string{"a:read", "a:write"}},
+		{name: "empty key", register: []Definition{{Key: "a:read"}, {Key: ""}}, wantErr: errs.ErrBadRequest},
+		{name: "already registered", existing: []Definition{{Key: "a:read"}}, register: []Definition{{Key: "a:write"}, {Key: "a:read"}}, wantErr: errs.ErrConflict, wantKeys: []string{"a:read"}},
+		{name: "repeated in one call", register: []Definition{{Key: "a:read"}, {Key: "a:read"}}, wantErr: errs.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister(tt.existing...)
+
+			err := registry.Register(tt.register...)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// pendaftaran yang gagal tidak boleh menyisakan sebagian key
+			keys := make([]string, 0)
+			for _, definition := range registry.All() {
+				keys = append(keys, definition.Key)
+			}
+			if len(keys) != len(tt.wantKeys) || (len(keys) > 0 && !reflect.DeepEqual(keys, tt.wantKeys)) {
+				t.Errorf("registered keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestRegistryMustRegisterPanicsOnDuplicate(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister(Definition{Key: "a:read"})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustRegister() did not panic on a duplicate key")
+		}
+	}()
+	registry.MustRegister(Definition{Key: "a:read"})
+}
+
+func TestRegistryAllSortsByGroupThenKey(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister(
+		Definition{Key: "users:write", Group: "users"},
+		Definition{Key: "audit:read", Group: "audit"},
+		Definition{Key: "users:read", Group: "users"},
+	)
+
+	got := make([]string, 0)
+	for _, definition := range registry.All() {
+		got = append(got, definition.Key)
+	}
+	want := []string{"audit:read", "users:read", "users:write"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
+
+func TestRegistryValidate(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister(Definition{Key: "users:read"}, Definition{Key: "users:write"})
+
+	tests := []struct {
+		name    string
+		keys    []string
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "known", keys: []string{"users:read", "users:write"}},
+		{name: "unknown", keys: []string{"users:read", "users:purge"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Validate(tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errs.IsValidationError(err) {
+				t.Fatalf("Validate() error = %v, want a validation error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package policy
+
+// IsOwner cocok bila subject adalah pemilik resource
+func IsOwner() Condition {
+	return func(request Request) bool {
+		return !request.Subject.ID.IsNil() && request.Subject.ID == request.Resource.OwnerID
+	}
+}
+
+func HasPermission(permission string) Condition {
+	return func(request Request) bool {
+		return request.Subject.HasPermission(permission)
+	}
+}
+
+// SameAttribute cocok bila subject dan resource memiliki nilai atribut yang sama dan tidak kosong
+func SameAttribute(key string) Condition {
+	return func(request Request) bool {
+		value := request.Subject.Attributes[key]
+		return value != "" && value == request.Resource.Attributes[key]
+	}
+}
+
+func All(conditions ...Condition) Condition {
+	return func(request Request) bool {
+		for _, condition := range conditions {
+			if !condition(request) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func AnyOf(conditions ...Condition) Condition {
+	return func(request Request) bool {
+		for _, condition := range conditions {
+			if condition(request) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func Not(condition Condition) Condition {
+	return func(request Request) bool {
+		return !condition(request)
+	}
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+
+	// AnyAction mencocokkan seluruh action
+	AnyAction = "*"
+)
+
+// Subject adalah pihak yang melakukan action beserta atributnya
+type Subject struct {
+	ID          identity.ID
+	Permissions []string
+	Attributes  map[string]string
+}
+
+func (s Subject) HasPermission(permission string) bool {
+	return slices.Contains(s.Permissions, permission)
+}
+
+// Resource adalah objek yang dikenai action. OwnerID diisi bila resource dimiliki user tertentu.
+type Resource struct {
+	Type       string
+	ID         identity.ID
+	OwnerID    identity.ID
+	Attributes map[string]string
+}
+
+// Request berisi semua atribut yang dievaluasi. Fields adalah field resource
+// yang diubah dan dipakai rule yang hanya berlaku untuk field tertentu.
+type Request struct {
+	Subject  Subject
+	Action   string
+	Resource Resource
+	Fields   []string
+}
+
+type Condition func(request Request) bool
+
+// Rule berlaku bila action, tipe resource, field, dan kondisinya cocok.
+// Actions, ResourceType, Fields, dan Condition yang kosong mencocokkan semua.
+type Rule struct {
+	Name         string
+	Effect       Effect
+	Actions      []string
+	ResourceType string
+	Fields       []string
+	Condition    Condition
+}
+
+func (r Rule) matches(request Request) bool {
+	if len(r.Actions) > 0 && !slices.Contains(r.Actions, AnyAction) && !slices.Contains(r.Actions, request.Action) {
+		return false
+	}
+	if r.ResourceType != "" && r.ResourceType != request.Resource.Type {
+		return false
+	}
+	if len(r.Fields) > 0 && !slices.ContainsFunc(r.Fields, func(field string) bool { return slices.Contains(request.Fields, field) }) {
+		return false
+	}
+	return r.Condition == nil || r.Condition(request)
+}
+
+type Decision struct {
+	Allowed bool
+	// Rule adalah nama rule yang menentukan keputusan, kosong bila tidak ada rule yang cocok
+	Rule string
+}
+
+// Engine mengevaluasi rule dengan strategi deny-overrides: satu rule deny yang
+// cocok menolak request, selain itu dibutuhkan minimal satu rule allow.
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{
+		rules: rules,
+	}
+}
+
+// Add menambahkan rule, dipakai modul untuk mendaftarkan policy miliknya
+func (e *Engine) Add(rules ...Rule) {
+	e.rules = append(e.rules, rules...)
+}
+
+func (e *Engine) Evaluate(request Request) Decision {
+	decision := Decision{}
+	for _, rule := range e.rules {
+		if !rule.matches(request) {
+			continue
+		}
+		if rule.Effect == Deny {
+			return Decision{Allowed: false, Rule: rule.Name}
+		}
+		if !decision.Allowed {
+			decision = Decision{Allowed: true, Rule: rule.Name}
+		}
+	}
+	return decision
+}
+
+// Authorize mengembalikan errs.ErrForbidden bila request tidak diizinkan
+func (e *Engine) Authorize(request Request) error {
+	decision := e.Evaluate(request)
+	if decision.Allowed {
+		return nil
+	}
+
+	target := request.Action
+	if len(request.Fields) > 0 {
+		target += " (" + strings.Join(request.Fields, ", ") + ")"
+	}
+	if decision.Rule != "" {
+		return fmt.Errorf("%s denied by policy '%s': %w", target, decision.Rule, errs.ErrForbidden)
+	}
+	return fmt.Errorf("%s not allowed by any policy: %w", target, errs.ErrForbidden)
+}
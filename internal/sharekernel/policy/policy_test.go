@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/errs"
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+func TestEngineEvaluate(t *testing.T) {
+	owner := identity.New()
+	resource := Resource{Type: "doc", ID: identity.New(), OwnerID: owner, Attributes: map[string]string{"team": "a"}}
+
+	engine := NewEngine(
+		Rule{Name: "edit-own", Effect: Allow, Actions: []string{"doc:edit"}, ResourceType: "doc", Condition: IsOwner()},
+		Rule{Name: "edit-team", Effect: Allow, Actions: []string{"doc:edit"}, ResourceType: "doc", Condition: All(HasPermission("docs:team"), SameAttribute("team"))},
+		Rule{Name: "admin", Effect: Allow, Actions: []string{AnyAction}, Condition: HasPermission("docs:admin")},
+		Rule{Name: "title-locked", Effect: Deny, Actions: []string{"doc:edit"}, Fields: []string{"title"}, Condition: Not(HasPermission("docs:admin"))},
+	)
+
+	tests := []struct {
+		name     string
+		request  Request
+		allowed  bool
+		wantRule string
+	}{
+		{
+			name:    "no matching rule",
+			request: Request{Subject: Subject{ID: identity.New()}, Action: "doc:edit", Resource: resource},
+		},
+		{
+			name:     "owner",
+			request:  Request{Subject: Subject{ID: owner}, Action: "doc:edit", Resource: resource},
+			allowed:  true,
+			wantRule: "edit-own",
+		},
+		{
+			name:    "owner on another action",
+			request: Request{Subject: Subject{ID: owner}, Action: "doc:delete", Resource: resource},
+		},
+		{
+			name:    "owner on another resource type",
+			request: Request{Subject: Subject{ID: owner}, Action: "doc:edit", Resource: Resource{Type: "note", OwnerID: owner}},
+		},
+		{
+			name:    "nil subject is never the owner",
+			request: Request{Subject: Subject{}, Action: "doc:edit", Resource: Resource{Type: "doc"}},
+		},
+		{
+			name:     "same team with permission",
+			request:  Request{Subject: Subject{ID: identity.New(), Permissions: []string{"docs:team"}, Attributes: map[string]string{"team": "a"}}, Action: "doc:edit", Resource: resource},
+			allowed:  true,
+			wantRule: "edit-team",
+		},
+		{
+			name:    "other team with permission",
+			request: Request{Subject: Subject{ID: identity.New(), Permissions: []string{"docs:team"}, Attributes: map[string]string{"team": "b"}}, Action: "doc:edit", Resource: resource},
+		},
+		{
+			name:    "empty attribute on both sides",
+			request: Request{Subject: Subject{ID: identity.New(), Permissions: []string{"docs:team"}}, Action: "doc:edit", Resource: Resource{Type: "doc"}},
+		},
+		{
+			name:     "any action",
+			request:  Request{Subject: Subject{ID: identity.New(), Permissions: []string{"docs:admin"}}, Action: "doc:delete", Resource: resource},
+			allowed:  true,
+			wantRule: "admin",
+		},
+		{
+			name:     "deny overrides allow",
+			request:  Request{Subject: Subject{ID: owner}, Action: "doc:edit", Resource: resource, Fields: []string{"body", "title"}},
+			wantRule: "title-locked",
+		},
+		{
+			name:     "deny on other fields does not apply",
+			request:  Request{Subject: Subject{ID: owner}, Action: "doc:edit", Resource: resource, Fields: []string{"body"}},
+			allowed:  true,
+			wantRule: "edit-own",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := engine.Evaluate(tt.request)
+			if decision.Allowed != tt.allowed || decision.Rule != tt.wantRule {
+				t.Fatalf("Evaluate() = %+v, want allowed %v by '%s'", decision, tt.allowed, tt.wantRule)
+			}
+
+			err := engine.Authorize(tt.request)
+			if tt.allowed && err != nil {
+				t.Fatalf("Authorize() error = %v, want nil", err)
+			}
+			if !tt.allowed && !errors.Is(err, errs.ErrForbidden) {
+				t.Fatalf("Authorize() error = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+func TestEngineAdd(t *testing.T) {
+	engine := NewEngine()
+	request := Request{Subject: Subject{ID: identity.New()}, Action: "doc:edit", Resource: Resource{Type: "doc"}}
+	if engine.Evaluate(request).Allowed {
+		t.Fatal("empty engine allowed the request")
+	}
+
+	engine.Add(Rule{Name: "all", Effect: Allow})
+	if !engine.Evaluate(request).Allowed {
+		t.Fatal("engine ignored the added rule")
+	}
+}
@@ -0,0 +1,102 @@
+package requestctx
+
+import (
+	"context"
+
+	"github.com/HasanNugroho/go-broilerplate-ddd/internal/sharekernel/identity"
+)
+
+type contextKey string
+
+const (
+	actorIDKey   contextKey = "actor_id"
+	requestIDKey contextKey = "request_id"
+	clientIPKey  contextKey = "client_ip"
+	scopesKey    contextKey = "scopes"
+	userAgentKey contextKey = "user_agent"
+	sessionIDKey contextKey = "session_id"
+	tenantIDKey  contextKey = "tenant_id"
+	systemKey    contextKey = "system_actor"
+)
+
+// WithActorID menyimpan ID user yang melakukan request
+func WithActorID(ctx context.Context, id identity.ID) context.Context {
+	return context.WithValue(ctx, actorIDKey, id)
+}
+
+func ActorID(ctx context.Context) (identity.ID, bool) {
+	id, ok := ctx.Value(actorIDKey).(identity.ID)
+	return id, ok && !id.IsNil()
+}
+
+// WithSystemActor menandai pemanggilan internal seperti job, seeder dan event
+// subscriber yang boleh melewati pemeriksaan policy. Tidak pernah dipasang
+// oleh middleware HTTP, pemanggilan tanpa actor maupun tanda ini ditolak.
+func WithSystemActor(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey, true)
+}
+
+func IsSystemActor(ctx context.Context) bool {
+	system, _ := ctx.Value(systemKey).(bool)
+	return system
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithClientIP menyimpan alamat IP client yang sudah diresolusi middleware
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
+
+// WithScopes menyimpan scope kredensial yang dipakai request. Request tanpa
+// scope (misalnya access token login) tidak dibatasi scope.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+func Scopes(ctx context.Context) (scopes []string, restricted bool) {
+	scopes, restricted = ctx.Value(scopesKey).([]string)
+	return scopes, restricted
+}
+
+func WithUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, userAgentKey, userAgent)
+}
+
+func UserAgent(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentKey).(string)
+	return userAgent
+}
+
+// WithSessionID menyimpan session login yang dipakai request
+func WithSessionID(ctx context.Context, id identity.ID) context.Context {
+	return context.WithValue(ctx, sessionIDKey, id)
+}
+
+func SessionID(ctx context.Context) (identity.ID, bool) {
+	id, ok := ctx.Value(sessionIDKey).(identity.ID)
+	return id, ok && !id.IsNil()
+}
+
+// WithTenantID menyimpan tenant tempat request berjalan. Repository yang
+// ber-tenant menolak query tanpa tenant.
+func WithTenantID(ctx context.Context, id identity.ID) context.Context {
+	return context.WithValue(ctx, tenantIDKey, id)
+}
+
+func TenantID(ctx context.Context) (identity.ID, bool) {
+	id, ok := ctx.Value(tenantIDKey).(identity.ID)
+	return id, ok && !id.IsNil()
+}
@@ -0,0 +1,18 @@
+package retry
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff menghitung jeda eksponensial untuk percobaan ke-attempt (mulai dari 1)
+// dengan batas maxDelay dan jitter hingga 20%
+func Backoff(attempt int, base time.Duration, maxDelay time.Duration) time.Duration {
+	delay := base << min(max(attempt-1, 0), 30)
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay)/5 + 1))
+	return delay + jitter
+}
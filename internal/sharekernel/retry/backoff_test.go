@@ -0,0 +1,34 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempt  int
+		base     time.Duration
+		maxDelay time.Duration
+		want     time.Duration
+	}{
+		{name: "first attempt uses base", attempt: 1, base: time.Second, maxDelay: time.Hour, want: time.Second},
+		{name: "doubles per attempt", attempt: 4, base: time.Second, maxDelay: time.Hour, want: 8 * time.Second},
+		{name: "zero attempt treated as first", attempt: 0, base: time.Second, maxDelay: time.Hour, want: time.Second},
+		{name: "capped at max delay", attempt: 20, base: time.Second, maxDelay: time.Minute, want: time.Minute},
+		{name: "shift overflow capped", attempt: 100, base: time.Hour, maxDelay: 6 * time.Hour, want: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// jitter acak hingga 20%, cek beberapa kali agar rentangnya teruji
+			for range 50 {
+				got := Backoff(tt.attempt, tt.base, tt.maxDelay)
+				if got < tt.want || got > tt.want+tt.want/5 {
+					t.Fatalf("Backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.want, tt.want+tt.want/5)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package transaction
+
+import "context"
+
+// IManager menjalankan fn di dalam satu transaksi database. Repository yang
+// menerima ctx dari fn otomatis ikut dalam transaksi tersebut.
+type IManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error)
+}